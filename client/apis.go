@@ -18,6 +18,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 
@@ -35,6 +36,7 @@ const (
 	apiDumpURL     = "/v4/default/admin/dump"
 	apiClustersURL = "/v4/default/admin/clusters"
 	apiHealthURL   = "/v4/default/registry/health"
+	apiLeasesURL   = "/v4/%s/admin/microservices/%s/leases"
 
 	QueryGlobal util.CtxKey = "global"
 )
@@ -48,6 +50,44 @@ func (c *Client) toError(body []byte) *errsvc.Error {
 	return message
 }
 
+// doJSON sends the request with the json encoded in and decodes the response body to out if not nil
+func (c *Client) doJSON(ctx context.Context, method, api string, in, out interface{}) *errsvc.Error {
+	var (
+		reqBody []byte
+		err     error
+	)
+	if in != nil {
+		reqBody, err = json.Marshal(in)
+		if err != nil {
+			return discovery.NewError(discovery.ErrInternal, err.Error())
+		}
+	}
+
+	resp, err := c.RestDoWithContext(ctx, method, api, c.CommonHeaders(ctx), reqBody)
+	if err != nil {
+		return discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c.toError(body)
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(body, out)
+	if err != nil {
+		return discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	return nil
+}
+
 func (c *Client) parseQuery(ctx context.Context) (q string) {
 	switch {
 	case ctx.Value(QueryGlobal) == "1":
@@ -159,3 +199,32 @@ func (c *Client) HealthCheck(ctx context.Context) *errsvc.Error {
 	}
 	return nil
 }
+
+func (c *Client) GetLeases(ctx context.Context, domain, project, serviceID string) ([]*dump.Lease, *errsvc.Error) {
+	headers := c.CommonHeaders(ctx)
+	headers.Set("X-Domain-Name", domain)
+	resp, err := c.RestDoWithContext(ctx, http.MethodGet,
+		fmt.Sprintf(apiLeasesURL, project, serviceID),
+		headers, nil)
+	if err != nil {
+		return nil, discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.toError(body)
+	}
+
+	leases := &dump.LeasesResponse{}
+	err = json.Unmarshal(body, leases)
+	if err != nil {
+		return nil, discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+
+	return leases.Leases, nil
+}
@@ -21,7 +21,7 @@ import (
 )
 
 func NewSCClient(cfg Config) (*Client, error) {
-	client, err := NewLBClient(cfg.Endpoints, cfg.Merge())
+	client, err := NewWeightedLBClient(cfg.LBStrategy, cfg.WeightedEndpoints(), cfg.Merge())
 	if err != nil {
 		return nil, err
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
 	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/go-chassis/cari/discovery"
 )
 
 func NewLBClient(endpoints []string, options rest.URLClientOption) (*LBClient, error) {
@@ -38,6 +39,36 @@ func NewLBClient(endpoints []string, options rest.URLClientOption) (*LBClient, e
 	}, nil
 }
 
+// NewWeightedLBClient returns the client balancing the endpoints by strategy,
+// the endpoints with zero weight are skipped by the weighted strategies
+func NewWeightedLBClient(strategy string, endpoints []lb.WeightedEndpoint, options rest.URLClientOption) (*LBClient, error) {
+	client, err := rest.GetURLClient(options)
+	if err != nil {
+		return nil, err
+	}
+	return &LBClient{
+		Retries:   len(endpoints),
+		LB:        lb.New(strategy, endpoints),
+		URLClient: client,
+	}, nil
+}
+
+// InstanceEndpoints returns the endpoints of the instances with the
+// instances weight, it can be used to create the LBClient of providers
+func InstanceEndpoints(instances []*discovery.MicroServiceInstance) []lb.WeightedEndpoint {
+	var eps []lb.WeightedEndpoint
+	for _, instance := range instances {
+		w, err := lb.ParseWeight(instance.Properties)
+		if err != nil {
+			w = lb.DefaultWeight
+		}
+		for _, ep := range instance.Endpoints {
+			eps = append(eps, lb.WeightedEndpoint{Endpoint: ep, Weight: w})
+		}
+	}
+	return eps
+}
+
 type LBClient struct {
 	*rest.URLClient
 	Retries int
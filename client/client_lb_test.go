@@ -24,7 +24,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/go-chassis/cari/discovery"
 )
 
 func TestNewLBClient(t *testing.T) {
@@ -55,3 +57,22 @@ func TestNewLBClient(t *testing.T) {
 		t.Fatal("TestNewLBClient", err)
 	}
 }
+
+func TestNewWeightedLBClient(t *testing.T) {
+	eps := InstanceEndpoints([]*discovery.MicroServiceInstance{
+		{Endpoints: []string{"rest://1.1.1.1"}, Properties: map[string]string{lb.PropWeight: "0"}},
+		{Endpoints: []string{"rest://2.2.2.2"}},
+	})
+	if len(eps) != 2 || eps[0].Weight != 0 || eps[1].Weight != lb.DefaultWeight {
+		t.Fatal("TestInstanceEndpoints", eps)
+	}
+	client, err := NewWeightedLBClient(lb.StrategyWeightedRoundRobin, eps, rest.DefaultURLClientOption())
+	if err != nil {
+		t.Fatal("TestNewWeightedLBClient", err)
+	}
+	for i := 0; i < 3; i++ {
+		if client.Next() != "rest://2.2.2.2" {
+			t.Fatal("TestNewWeightedLBClient")
+		}
+	}
+}
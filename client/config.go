@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
 )
 
@@ -29,11 +30,28 @@ type Config struct {
 	rest.URLClientOption
 	Name      string
 	Endpoints []string
+	// LBStrategy is the strategy to balance the Endpoints, see pkg/lb
+	LBStrategy string
+	// Weights is the weight of each endpoint, lb.DefaultWeight if not set
+	Weights map[string]int
 	// TODO Expandable header not only token header
 	Token          string
 	CertKeyPWDPath string
 }
 
+// WeightedEndpoints returns the Endpoints with their Weights
+func (cfg *Config) WeightedEndpoints() []lb.WeightedEndpoint {
+	eps := make([]lb.WeightedEndpoint, 0, len(cfg.Endpoints))
+	for _, ep := range cfg.Endpoints {
+		w, ok := cfg.Weights[ep]
+		if !ok {
+			w = lb.DefaultWeight
+		}
+		eps = append(eps, lb.WeightedEndpoint{Endpoint: ep, Weight: w})
+	}
+	return eps
+}
+
 func (cfg *Config) Merge() rest.URLClientOption {
 	ssl := strings.Contains(cfg.Endpoints[0], "https://")
 	if ssl && len(cfg.CertKeyPWD) == 0 && len(cfg.CertKeyPWDPath) > 0 {
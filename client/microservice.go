@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	pb "github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/cari/pkg/errsvc"
@@ -31,13 +32,21 @@ const (
 	apiExistenceURL     = "/v4/%s/registry/existence"
 	apiMicroServicesURL = "/v4/%s/registry/microservices"
 	apiMicroServiceURL  = "/v4/%s/registry/microservices/%s"
+
+	apiMicroServicePropertiesURL = "/v4/%s/registry/microservices/%s/properties"
 )
 
 func (c *Client) CreateService(ctx context.Context, domain, project string, service *pb.MicroService) (string, *errsvc.Error) {
+	return c.CreateServiceEx(ctx, domain, project, &pb.CreateServiceRequest{Service: service})
+}
+
+// CreateServiceEx creates the service together with the rules, tags and instances in request,
+// the service id and instance ids in request are preserved if set
+func (c *Client) CreateServiceEx(ctx context.Context, domain, project string, in *pb.CreateServiceRequest) (string, *errsvc.Error) {
 	headers := c.CommonHeaders(ctx)
 	headers.Set("X-Domain-Name", domain)
 
-	reqBody, err := json.Marshal(&pb.CreateServiceRequest{Service: service})
+	reqBody, err := json.Marshal(in)
 	if err != nil {
 		return "", pb.NewError(pb.ErrInternal, err.Error())
 	}
@@ -67,12 +76,78 @@ func (c *Client) CreateService(ctx context.Context, domain, project string, serv
 	return serviceResp.ServiceId, nil
 }
 
+func (c *Client) GetService(ctx context.Context, domain, project, serviceID string) (*pb.MicroService, *errsvc.Error) {
+	headers := c.CommonHeaders(ctx)
+	headers.Set("X-Domain-Name", domain)
+
+	resp, err := c.RestDoWithContext(ctx, http.MethodGet,
+		fmt.Sprintf(apiMicroServiceURL, project, serviceID)+"?"+c.parseQuery(ctx),
+		headers, nil)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.toError(body)
+	}
+
+	serviceResp := &pb.GetServiceResponse{}
+	err = json.Unmarshal(body, serviceResp)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	return serviceResp.Service, nil
+}
+
+func (c *Client) UpdateServiceProperties(ctx context.Context, domain, project, serviceID string, properties map[string]string) *errsvc.Error {
+	headers := c.CommonHeaders(ctx)
+	headers.Set("X-Domain-Name", domain)
+
+	reqBody, err := json.Marshal(&pb.UpdateServicePropsRequest{ServiceId: serviceID, Properties: properties})
+	if err != nil {
+		return pb.NewError(pb.ErrInternal, err.Error())
+	}
+
+	resp, err := c.RestDoWithContext(ctx, http.MethodPut,
+		fmt.Sprintf(apiMicroServicePropertiesURL, project, serviceID),
+		headers, reqBody)
+	if err != nil {
+		return pb.NewError(pb.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return pb.NewError(pb.ErrInternal, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c.toError(body)
+	}
+	return nil
+}
+
 func (c *Client) DeleteService(ctx context.Context, domain, project, serviceID string) *errsvc.Error {
+	return c.deleteService(ctx, domain, project, serviceID, false)
+}
+
+// ForceDeleteService deletes the service with its instances even if other services depend on it
+func (c *Client) ForceDeleteService(ctx context.Context, domain, project, serviceID string) *errsvc.Error {
+	return c.deleteService(ctx, domain, project, serviceID, true)
+}
+
+func (c *Client) deleteService(ctx context.Context, domain, project, serviceID string, force bool) *errsvc.Error {
 	headers := c.CommonHeaders(ctx)
 	headers.Set("X-Domain-Name", domain)
 
 	resp, err := c.RestDoWithContext(ctx, http.MethodDelete,
-		fmt.Sprintf(apiMicroServiceURL, project, serviceID),
+		fmt.Sprintf(apiMicroServiceURL, project, serviceID)+"?force="+strconv.FormatBool(force),
 		headers, nil)
 	if err != nil {
 		return pb.NewError(pb.ErrInternal, err.Error())
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/go-chassis/cari/pkg/errsvc"
+)
+
+const (
+	apiPoliciesURL = "/v1/%s/gov/%s"
+	apiPolicyURL   = "/v1/%s/gov/%s/%s"
+)
+
+// ListPolicies returns the governance policies of the kind, filtered by app and environment if not empty
+func (c *Client) ListPolicies(ctx context.Context, project, kind, app, environment string) ([]*gov.Policy, *errsvc.Error) {
+	query := url.Values{}
+	if len(app) > 0 {
+		query.Set("app", app)
+	}
+	if len(environment) > 0 {
+		query.Set("environment", environment)
+	}
+	var policies []*gov.Policy
+	err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf(apiPoliciesURL, project, kind)+"?"+query.Encode(), nil, &policies)
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// CreatePolicy creates the governance policy and returns the policy id
+func (c *Client) CreatePolicy(ctx context.Context, project, kind string, policy *gov.Policy) (string, *errsvc.Error) {
+	created := &gov.Policy{}
+	err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf(apiPoliciesURL, project, kind), policy, created)
+	if err != nil {
+		return "", err
+	}
+	if created.GovernancePolicy == nil {
+		return "", nil
+	}
+	return created.ID, nil
+}
+
+func (c *Client) UpdatePolicy(ctx context.Context, project, kind, id string, policy *gov.Policy) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf(apiPolicyURL, project, kind, id), policy, nil)
+}
+
+func (c *Client) DeletePolicy(ctx context.Context, project, kind, id string) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodDelete, fmt.Sprintf(apiPolicyURL, project, kind, id), nil, nil)
+}
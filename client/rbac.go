@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+)
+
+const (
+	apiAccountsURL = "/v4/accounts"
+	apiAccountURL  = "/v4/accounts/%s"
+	apiRolesURL    = "/v4/roles"
+	apiRoleURL     = "/v4/roles/%s"
+)
+
+// ListAccounts returns all the accounts, the passwords are not included
+func (c *Client) ListAccounts(ctx context.Context) ([]*rbac.Account, *errsvc.Error) {
+	accountsResp := &rbac.AccountResponse{}
+	if err := c.doJSON(ctx, http.MethodGet, apiAccountsURL, nil, accountsResp); err != nil {
+		return nil, err
+	}
+	return accountsResp.Accounts, nil
+}
+
+func (c *Client) CreateAccount(ctx context.Context, account *rbac.Account) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodPost, apiAccountsURL, account, nil)
+}
+
+func (c *Client) UpdateAccount(ctx context.Context, account *rbac.Account) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf(apiAccountURL, account.Name), account, nil)
+}
+
+func (c *Client) DeleteAccount(ctx context.Context, name string) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodDelete, fmt.Sprintf(apiAccountURL, name), nil, nil)
+}
+
+func (c *Client) ListRoles(ctx context.Context) ([]*rbac.Role, *errsvc.Error) {
+	rolesResp := &rbac.RoleResponse{}
+	if err := c.doJSON(ctx, http.MethodGet, apiRolesURL, nil, rolesResp); err != nil {
+		return nil, err
+	}
+	return rolesResp.Roles, nil
+}
+
+func (c *Client) CreateRole(ctx context.Context, role *rbac.Role) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodPost, apiRolesURL, role, nil)
+}
+
+func (c *Client) UpdateRole(ctx context.Context, role *rbac.Role) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf(apiRoleURL, role.Name), role, nil)
+}
+
+func (c *Client) DeleteRole(ctx context.Context, name string) *errsvc.Error {
+	return c.doJSON(ctx, http.MethodDelete, fmt.Sprintf(apiRoleURL, name), nil, nil)
+}
@@ -27,11 +27,15 @@ import (
 
 	pb "github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 )
 
 const (
 	apiSchemasURL = "/v4/%s/registry/microservices/%s/schemas"
 	apiSchemaURL  = "/v4/%s/registry/microservices/%s/schemas/%s"
+
+	apiSchemaRevisionsURL = "/v4/%s/registry/microservices/%s/schemas/%s/revisions"
 )
 
 func (c *Client) CreateSchemas(ctx context.Context, domain, project, serviceID string, schemas []*pb.Schema) *errsvc.Error {
@@ -191,6 +195,35 @@ func (c *Client) GetSchemaBySchemaID(ctx context.Context, domain, project, servi
 	}, nil
 }
 
+// ListSchemaRevisions returns the schema history without content, the latest first
+func (c *Client) ListSchemaRevisions(ctx context.Context, domain, project, serviceID, schemaID string) ([]*proto.SchemaRevision, *errsvc.Error) {
+	headers := c.CommonHeaders(ctx)
+	headers.Set("X-Domain-Name", domain)
+	resp, err := c.RestDoWithContext(ctx, http.MethodGet,
+		fmt.Sprintf(apiSchemaRevisionsURL, project, serviceID, schemaID)+"?"+c.parseQuery(ctx),
+		headers, nil)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.toError(body)
+	}
+
+	revisions := &proto.ListSchemaRevisionsResponse{}
+	err = json.Unmarshal(body, revisions)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	return revisions.Revisions, nil
+}
+
 func schemaSummary(context string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(context)))
 }
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+)
+
+// the kinds of the pact broker data which are identified by an increasing id
+const (
+	BrokerParticipant  = "participant"
+	BrokerVersion      = "version"
+	BrokerPact         = "pact"
+	BrokerPactVersion  = "pact-version"
+	BrokerVerification = "verification"
+)
+
+// BrokerManager contains the storage APIs of the pact broker,
+// the Get methods return nil without error if the data does not exist
+type BrokerManager interface {
+	GetParticipant(ctx context.Context, domainProject, appID, serviceName string) (*brokerpb.Participant, error)
+	ListParticipants(ctx context.Context, domainProject string) ([]*brokerpb.Participant, error)
+	CreateParticipant(ctx context.Context, domainProject string, participant *brokerpb.Participant) error
+
+	GetVersion(ctx context.Context, domainProject, number string, participantID int32) (*brokerpb.Version, error)
+	ListVersions(ctx context.Context, domainProject string) ([]*brokerpb.Version, error)
+	CreateVersion(ctx context.Context, domainProject string, version *brokerpb.Version) error
+
+	GetPact(ctx context.Context, domainProject string, consumerParticipantID, providerParticipantID int32,
+		sha []byte) (*brokerpb.Pact, error)
+	ListPacts(ctx context.Context, domainProject string) ([]*brokerpb.Pact, error)
+	CreatePact(ctx context.Context, domainProject string, pact *brokerpb.Pact) error
+
+	GetPactVersion(ctx context.Context, domainProject string, versionID, pactID int32) (*brokerpb.PactVersion, error)
+	ListPactVersions(ctx context.Context, domainProject string) ([]*brokerpb.PactVersion, error)
+	CreatePactVersion(ctx context.Context, domainProject string, pactVersion *brokerpb.PactVersion) error
+
+	// ListVerifications returns the verification results of the pact version
+	ListVerifications(ctx context.Context, domainProject string, pactVersionID int32) ([]*brokerpb.Verification, error)
+	CreateVerification(ctx context.Context, domainProject string, verification *brokerpb.Verification) error
+
+	ListTags(ctx context.Context, domainProject string) ([]*brokerpb.Tag, error)
+	CreateTag(ctx context.Context, domainProject string, tag *brokerpb.Tag) error
+	DeleteTag(ctx context.Context, domainProject string, versionID int32, name string) error
+
+	// ListDeployments returns the versions currently deployed to the environments
+	ListDeployments(ctx context.Context, domainProject string) ([]*brokerpb.Deployment, error)
+	// PutDeployment replaces the version of the participant deployed to the environment
+	PutDeployment(ctx context.Context, domainProject string, deployment *brokerpb.Deployment) error
+	DeleteDeployment(ctx context.Context, domainProject, environment string, participantID int32) error
+
+	GetWebhook(ctx context.Context, domainProject, webhookID string) (*brokerpb.Webhook, error)
+	ListWebhooks(ctx context.Context, domainProject string) ([]*brokerpb.Webhook, error)
+	PutWebhook(ctx context.Context, domainProject string, webhook *brokerpb.Webhook) error
+	// DeleteWebhook deletes the webhook and its executions
+	DeleteWebhook(ctx context.Context, domainProject, webhookID string) error
+	// ListWebhookExecutions returns the delivery logs of the webhook
+	ListWebhookExecutions(ctx context.Context, domainProject, webhookID string) ([]*brokerpb.WebhookExecution, error)
+	CreateWebhookExecution(ctx context.Context, domainProject string, execution *brokerpb.WebhookExecution) error
+	DeleteWebhookExecution(ctx context.Context, domainProject, webhookID, executionID string) error
+
+	// GetLatestBrokerID returns the latest id of the kind of broker data, -1 if nothing created
+	GetLatestBrokerID(ctx context.Context, kind string) (int32, error)
+	// DeleteBrokerData deletes all the pact broker data
+	DeleteBrokerData(ctx context.Context) error
+}
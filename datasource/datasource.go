@@ -25,4 +25,7 @@ type DataSource interface {
 	DependencyManager
 	MetadataManager
 	SCManager
+	BrokerManager
+	EventStreamManager
+	WebhookManager
 }
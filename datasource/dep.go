@@ -21,13 +21,22 @@ import (
 	"context"
 
 	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 )
 
 // DependencyManager contains the CRUD of microservice dependencies
 type DependencyManager interface {
 	SearchProviderDependency(ctx context.Context, request *pb.GetDependenciesRequest) (*pb.GetProDependenciesResponse, error)
 	SearchConsumerDependency(ctx context.Context, request *pb.GetDependenciesRequest) (*pb.GetConDependenciesResponse, error)
+	// ListProviderRules returns the provider rules declared by the consumer, e.g. {appId, serviceName, latest}
+	ListProviderRules(ctx context.Context, consumer *pb.MicroService) ([]*pb.MicroServiceKey, error)
 	AddOrUpdateDependencies(ctx context.Context, dependencyInfos []*pb.ConsumerDependency, override bool) (*pb.Response, error)
 	DeleteDependency()
 	DependencyHandle(ctx context.Context) error
+	// UpdateDependencySeen stamps the relations between consumer and providers with the current time
+	UpdateDependencySeen(ctx context.Context, consumerID string, providers []*pb.MicroServiceKey) error
+	// ListDependencySeen returns the stamps of the relations across all domain projects
+	ListDependencySeen(ctx context.Context) ([]*proto.DependencySeen, error)
+	DeleteDependencySeen(ctx context.Context, seens []*proto.DependencySeen) error
 }
@@ -0,0 +1,299 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// dependencySeenInterval limits the frequency of stamping the same relation
+// in a service center process, FindInstances is called far more frequently
+const dependencySeenInterval = time.Minute
+
+// DependencyClearLockID is the dlock preventing the service centers from
+// clearing the stale dependencies at the same time
+const DependencyClearLockID = "/cse-sr/lock/dependency-clear"
+
+var (
+	// the relation key -> the time stamped last in this process
+	dependencySeenCache sync.Map
+	// unseenStamped is true after the unseen relations are stamped in this process,
+	// it is accessed by the clear loop only
+	unseenStamped bool
+)
+
+// RefreshDependencySeen stamps the relation between the consumer and the provider
+// with the current time, the errors are logged only and do not fail the caller
+func RefreshDependencySeen(ctx context.Context, consumerID string, provider *pb.MicroServiceKey) {
+	if len(consumerID) == 0 || provider.ServiceName == "*" {
+		return
+	}
+	key := dependencySeenKey(util.ParseDomainProject(ctx), consumerID, provider)
+	now := time.Now()
+	if last, ok := dependencySeenCache.Load(key); ok && now.Sub(last.(time.Time)) < dependencySeenInterval {
+		return
+	}
+	dependencySeenCache.Store(key, now)
+	if err := Instance().UpdateDependencySeen(ctx, consumerID, []*pb.MicroServiceKey{provider}); err != nil {
+		dependencySeenCache.Delete(key)
+		log.Error(fmt.Sprintf("stamp consumer[%s] -> provider[%s/%s] last seen time failed",
+			consumerID, provider.AppId, provider.ServiceName), err)
+	}
+}
+
+// StampDependencies stamps the relations declared by the dependency API,
+// so the ones never found by the consumers can also be cleared after ttl
+func StampDependencies(ctx context.Context, dependencies []*pb.ConsumerDependency) error {
+	for _, dependency := range dependencies {
+		consumer := dependency.Consumer
+		resp, err := Instance().ExistService(ctx, &pb.GetExistenceRequest{
+			Type:        pb.ExistenceMicroservice,
+			Environment: consumer.Environment,
+			AppId:       consumer.AppId,
+			ServiceName: consumer.ServiceName,
+			Version:     consumer.Version,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Response.IsSucceed() {
+			continue
+		}
+		providers := make([]*pb.MicroServiceKey, 0, len(dependency.Providers))
+		for _, provider := range dependency.Providers {
+			if provider.ServiceName == "*" {
+				continue
+			}
+			providers = append(providers, seenProvider(provider, consumer.Environment))
+		}
+		if len(providers) == 0 {
+			continue
+		}
+		if err := Instance().UpdateDependencySeen(ctx, resp.ServiceId, providers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StampUnseenDependencies stamps the relations never seen with the current time and
+// returns the count, e.g. the ones created before upgrading, then they are cleared
+// after ttl if the consumers never find the providers again
+func StampUnseenDependencies(ctx context.Context) (int, error) {
+	seens, err := Instance().ListDependencySeen(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stamped := make(map[string]struct{}, len(seens))
+	for _, seen := range seens {
+		stamped[dependencySeenKey(seen.DomainProject, seen.ConsumerId, seen.Provider)] = struct{}{}
+	}
+	cache := Instance().DumpCache(ctx)
+	if cache == nil {
+		return 0, nil
+	}
+	count := 0
+	for _, kv := range cache.Microservices {
+		// key format: /cse-sr/ms/files/{domain}/{project}/{serviceId}
+		arr := strings.Split(strings.TrimPrefix(kv.Key, ServiceKeyPrefix+SPLIT), SPLIT)
+		if len(arr) != 3 || kv.Value == nil {
+			continue
+		}
+		domainProject := util.ToDomainProject(arr[0], arr[1])
+		serviceCtx := util.SetDomainProject(util.CloneContext(ctx), arr[0], arr[1])
+		rules, err := Instance().ListProviderRules(serviceCtx, kv.Value)
+		if err != nil {
+			return count, err
+		}
+		var providers []*pb.MicroServiceKey
+		for _, rule := range rules {
+			if rule.ServiceName == "*" {
+				continue
+			}
+			provider := seenProvider(rule, kv.Value.Environment)
+			if _, ok := stamped[dependencySeenKey(domainProject, kv.Value.ServiceId, provider)]; ok {
+				continue
+			}
+			providers = append(providers, provider)
+		}
+		if len(providers) == 0 {
+			continue
+		}
+		if err := Instance().UpdateDependencySeen(serviceCtx, kv.Value.ServiceId, providers); err != nil {
+			return count, err
+		}
+		count += len(providers)
+	}
+	return count, nil
+}
+
+// RunClearStaleDependencies clears the stale dependencies every interval until ctx is done,
+// the round is skipped if another service center holds the DependencyClearLockID dlock
+func RunClearStaleDependencies(ctx context.Context, interval, ttl time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+			clearStaleDependenciesExclusively(ctx, ttl)
+		}
+	}
+}
+
+func clearStaleDependenciesExclusively(ctx context.Context, ttl time.Duration) {
+	err := Instance().DLock(ctx, &DLockRequest{ID: DependencyClearLockID})
+	if err != nil {
+		log.Errorf(err, "can not clear stale dependencies by this service center instance now")
+		return
+	}
+	if !unseenStamped {
+		stamped, err := StampUnseenDependencies(ctx)
+		if err != nil {
+			log.Errorf(err, "stamp unseen dependencies failed")
+		} else {
+			unseenStamped = true
+			log.Infof("stamp unseen dependencies succeed, %d stamped", stamped)
+		}
+	}
+	cleared, err := ClearStaleDependencies(ctx, ttl)
+	if err := Instance().DUnlock(ctx, &DUnlockRequest{ID: DependencyClearLockID}); err != nil {
+		log.Error("", err)
+	}
+	if err != nil {
+		log.Errorf(err, "stale dependencies cleanup failed")
+		return
+	}
+	for _, seen := range cleared {
+		log.Warnf("clear stale dependency success, domainProject: %s, consumer: %s, provider: %s/%s, last seen: %s",
+			seen.DomainProject, seen.ConsumerId, seen.Provider.AppId, seen.Provider.ServiceName,
+			time.Unix(seen.LastSeen, 0))
+	}
+	log.Infof("stale dependencies cleanup succeed, %d cleared", len(cleared))
+}
+
+// ClearStaleDependencies removes the relations not seen for ttl and returns them,
+// the relations depending on all services(*) are never stamped nor cleared
+func ClearStaleDependencies(ctx context.Context, ttl time.Duration) ([]*proto.DependencySeen, error) {
+	seens, err := Instance().ListDependencySeen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeLimit := time.Now().Add(-ttl)
+	log.Info(fmt.Sprintf("clear dependencies last seen before %s", timeLimit))
+
+	var consumers []string
+	stale := make(map[string][]*proto.DependencySeen)
+	for _, seen := range seens {
+		if seen.LastSeen >= timeLimit.Unix() {
+			continue
+		}
+		consumer := util.StringJoin([]string{seen.DomainProject, seen.ConsumerId}, "/")
+		if _, ok := stale[consumer]; !ok {
+			consumers = append(consumers, consumer)
+		}
+		stale[consumer] = append(stale[consumer], seen)
+	}
+
+	var cleared []*proto.DependencySeen
+	for _, consumer := range consumers {
+		relations := stale[consumer]
+		if err := clearConsumerDependencies(ctx, relations); err != nil {
+			log.Error(fmt.Sprintf("clear consumer[%s]'s stale dependencies failed", consumer), err)
+			continue
+		}
+		for _, seen := range relations {
+			dependencySeenCache.Delete(dependencySeenKey(seen.DomainProject, seen.ConsumerId, seen.Provider))
+		}
+		cleared = append(cleared, relations...)
+	}
+	return cleared, nil
+}
+
+func clearConsumerDependencies(ctx context.Context, relations []*proto.DependencySeen) error {
+	domainProject, consumerID := relations[0].DomainProject, relations[0].ConsumerId
+	domain, project := util.FromDomainProject(domainProject)
+	ctx = util.SetDomainProject(ctx, domain, project)
+
+	resp, err := Instance().GetService(ctx, &pb.GetServiceRequest{ServiceId: consumerID})
+	if err != nil {
+		return err
+	}
+	// the stamps of the deleted consumer are removed only
+	if resp.Service != nil {
+		rules, err := Instance().ListProviderRules(ctx, resp.Service)
+		if err != nil {
+			return err
+		}
+		providers := make([]*pb.MicroServiceKey, 0, len(rules))
+		for _, rule := range rules {
+			if !isStaleRule(seenProvider(rule, resp.Service.Environment), relations) {
+				providers = append(providers, rule)
+			}
+		}
+		if len(providers) < len(rules) {
+			respDep, err := Instance().AddOrUpdateDependencies(ctx, []*pb.ConsumerDependency{{
+				Consumer:  pb.MicroServiceToKey(domainProject, resp.Service),
+				Providers: providers,
+			}}, true)
+			if err != nil {
+				return err
+			}
+			if !respDep.IsSucceed() {
+				return errors.New(respDep.GetMessage())
+			}
+		}
+	}
+	return Instance().DeleteDependencySeen(ctx, relations)
+}
+
+func isStaleRule(rule *pb.MicroServiceKey, relations []*proto.DependencySeen) bool {
+	for _, seen := range relations {
+		if rule.Environment == seen.Provider.Environment && rule.AppId == seen.Provider.AppId &&
+			rule.ServiceName == seen.Provider.ServiceName {
+			return true
+		}
+	}
+	return false
+}
+
+// seenProvider returns the provider in the consumer's environment if the rule does not
+// specify one, the same as the providers stamped when the consumer finds them
+func seenProvider(provider *pb.MicroServiceKey, env string) *pb.MicroServiceKey {
+	if len(provider.Environment) > 0 {
+		return provider
+	}
+	copied := *provider
+	copied.Environment = env
+	return &copied
+}
+
+func dependencySeenKey(domainProject, consumerID string, provider *pb.MicroServiceKey) string {
+	return util.StringJoin([]string{domainProject, consumerID, provider.Environment, provider.AppId,
+		provider.ServiceName}, "/")
+}
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClearStaleDependencies(t *testing.T) {
+	var (
+		consumerID string
+		providerID string
+	)
+	t.Run("create services and dependencies, should be passed", func(t *testing.T) {
+		resp, err := datasource.Instance().RegisterService(depGetContext(), &pb.CreateServiceRequest{
+			Service: &pb.MicroService{
+				AppId:       "dep_seen_group",
+				ServiceName: "dep_seen_consumer",
+				Version:     "1.0.0",
+				Level:       "FRONT",
+				Status:      pb.MS_UP,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		consumerID = resp.ServiceId
+
+		resp, err = datasource.Instance().RegisterService(depGetContext(), &pb.CreateServiceRequest{
+			Service: &pb.MicroService{
+				AppId:       "dep_seen_group",
+				ServiceName: "dep_seen_provider",
+				Version:     "1.0.0",
+				Level:       "FRONT",
+				Status:      pb.MS_UP,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		providerID = resp.ServiceId
+
+		dependencies := []*pb.ConsumerDependency{
+			{
+				Consumer: &pb.MicroServiceKey{AppId: "dep_seen_group", ServiceName: "dep_seen_consumer", Version: "1.0.0"},
+				Providers: []*pb.MicroServiceKey{
+					{AppId: "dep_seen_group", ServiceName: "dep_seen_provider", Version: "1.0.0+"},
+				},
+			},
+		}
+		respDep, err := datasource.Instance().AddOrUpdateDependencies(depGetContext(), dependencies, false)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, respDep.GetCode())
+		err = datasource.StampDependencies(depGetContext(), dependencies)
+		assert.NoError(t, err)
+
+		err = datasource.Instance().DependencyHandle(getContext())
+		assert.NoError(t, err)
+	})
+
+	t.Run("list the stamps, should contain the dependency", func(t *testing.T) {
+		seens, err := datasource.Instance().ListDependencySeen(depGetContext())
+		assert.NoError(t, err)
+		found := false
+		for _, seen := range seens {
+			if seen.ConsumerId == consumerID && seen.Provider.ServiceName == "dep_seen_provider" {
+				found = true
+				assert.True(t, seen.LastSeen > 0)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("stamp the unseen dependency, should stamp it again", func(t *testing.T) {
+		seens, err := datasource.Instance().ListDependencySeen(depGetContext())
+		assert.NoError(t, err)
+		var removed []*proto.DependencySeen
+		for _, seen := range seens {
+			if seen.ConsumerId == consumerID {
+				removed = append(removed, seen)
+			}
+		}
+		assert.NoError(t, datasource.Instance().DeleteDependencySeen(depGetContext(), removed))
+
+		stamped, err := datasource.StampUnseenDependencies(depGetContext())
+		assert.NoError(t, err)
+		assert.True(t, stamped > 0)
+
+		seens, err = datasource.Instance().ListDependencySeen(depGetContext())
+		assert.NoError(t, err)
+		found := false
+		for _, seen := range seens {
+			if seen.ConsumerId == consumerID && seen.Provider.ServiceName == "dep_seen_provider" {
+				found = true
+				assert.True(t, seen.LastSeen > 0)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("clear with ttl not reached, should keep the dependency", func(t *testing.T) {
+		cleared, err := datasource.ClearStaleDependencies(depGetContext(), time.Hour)
+		assert.NoError(t, err)
+		for _, seen := range cleared {
+			assert.NotEqual(t, consumerID, seen.ConsumerId)
+		}
+
+		resp, err := datasource.Instance().SearchConsumerDependency(depGetContext(), &pb.GetDependenciesRequest{
+			ServiceId: consumerID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(resp.Providers))
+		assert.Equal(t, providerID, resp.Providers[0].ServiceId)
+	})
+
+	t.Run("clear with ttl reached, should remove the dependency", func(t *testing.T) {
+		cleared, err := datasource.ClearStaleDependencies(depGetContext(), -time.Hour)
+		assert.NoError(t, err)
+		found := false
+		for _, seen := range cleared {
+			if seen.ConsumerId == consumerID {
+				found = true
+			}
+		}
+		assert.True(t, found)
+
+		err = datasource.Instance().DependencyHandle(getContext())
+		assert.NoError(t, err)
+
+		resp, err := datasource.Instance().SearchConsumerDependency(depGetContext(), &pb.GetDependenciesRequest{
+			ServiceId: consumerID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(resp.Providers))
+
+		seens, err := datasource.Instance().ListDependencySeen(depGetContext())
+		assert.NoError(t, err)
+		for _, seen := range seens {
+			assert.NotEqual(t, consumerID, seen.ConsumerId)
+		}
+	})
+
+	t.Run("delete services, should be passed", func(t *testing.T) {
+		for _, serviceID := range []string{consumerID, providerID} {
+			resp, err := datasource.Instance().UnregisterService(depGetContext(), &pb.DeleteServiceRequest{
+				ServiceId: serviceID, Force: true,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		}
+	})
+}
@@ -0,0 +1,371 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+)
+
+func (ds *DataSource) GetParticipant(ctx context.Context, domainProject, appID,
+	serviceName string) (*brokerpb.Participant, error) {
+	key := path.GenerateBrokerParticipantKey(domainProject, appID, serviceName)
+	participant := &brokerpb.Participant{}
+	ok, err := getBrokerData(ctx, kv.Store().BrokerParticipant(), key, participant)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return participant, nil
+}
+
+func (ds *DataSource) ListParticipants(ctx context.Context, domainProject string) ([]*brokerpb.Participant, error) {
+	key := util.StringJoin([]string{path.GetBrokerParticipantKey(domainProject), ""}, path.SPLIT)
+	var participants []*brokerpb.Participant
+	err := listBrokerData(ctx, kv.Store().BrokerParticipant(), key, func(data []byte) error {
+		participant := &brokerpb.Participant{}
+		if err := json.Unmarshal(data, participant); err != nil {
+			return err
+		}
+		participants = append(participants, participant)
+		return nil
+	})
+	return participants, err
+}
+
+func (ds *DataSource) CreateParticipant(ctx context.Context, domainProject string,
+	participant *brokerpb.Participant) error {
+	key := path.GenerateBrokerParticipantKey(domainProject, participant.AppId, participant.ServiceName)
+	return putBrokerData(ctx, key, participant, datasource.BrokerParticipant, participant.Id)
+}
+
+func (ds *DataSource) GetVersion(ctx context.Context, domainProject, number string,
+	participantID int32) (*brokerpb.Version, error) {
+	key := path.GenerateBrokerVersionKey(domainProject, number, participantID)
+	version := &brokerpb.Version{}
+	ok, err := getBrokerData(ctx, kv.Store().BrokerVersion(), key, version)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return version, nil
+}
+
+func (ds *DataSource) ListVersions(ctx context.Context, domainProject string) ([]*brokerpb.Version, error) {
+	key := util.StringJoin([]string{path.GetBrokerVersionKey(domainProject), ""}, path.SPLIT)
+	var versions []*brokerpb.Version
+	err := listBrokerData(ctx, kv.Store().BrokerVersion(), key, func(data []byte) error {
+		version := &brokerpb.Version{}
+		if err := json.Unmarshal(data, version); err != nil {
+			return err
+		}
+		versions = append(versions, version)
+		return nil
+	})
+	return versions, err
+}
+
+func (ds *DataSource) CreateVersion(ctx context.Context, domainProject string, version *brokerpb.Version) error {
+	key := path.GenerateBrokerVersionKey(domainProject, version.Number, version.ParticipantId)
+	return putBrokerData(ctx, key, version, datasource.BrokerVersion, version.Id)
+}
+
+func (ds *DataSource) GetPact(ctx context.Context, domainProject string, consumerParticipantID,
+	providerParticipantID int32, sha []byte) (*brokerpb.Pact, error) {
+	key := path.GenerateBrokerPactKey(domainProject, consumerParticipantID, providerParticipantID, sha)
+	pact := &brokerpb.Pact{}
+	ok, err := getBrokerData(ctx, kv.Store().BrokerPact(), key, pact)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return pact, nil
+}
+
+func (ds *DataSource) ListPacts(ctx context.Context, domainProject string) ([]*brokerpb.Pact, error) {
+	key := util.StringJoin([]string{path.GetBrokerPactKey(domainProject), ""}, path.SPLIT)
+	var pacts []*brokerpb.Pact
+	err := listBrokerData(ctx, kv.Store().BrokerPact(), key, func(data []byte) error {
+		pact := &brokerpb.Pact{}
+		if err := json.Unmarshal(data, pact); err != nil {
+			return err
+		}
+		pacts = append(pacts, pact)
+		return nil
+	})
+	return pacts, err
+}
+
+func (ds *DataSource) CreatePact(ctx context.Context, domainProject string, pact *brokerpb.Pact) error {
+	key := path.GenerateBrokerPactKey(domainProject, pact.ConsumerParticipantId, pact.ProviderParticipantId, pact.Sha)
+	return putBrokerData(ctx, key, pact, datasource.BrokerPact, pact.Id)
+}
+
+func (ds *DataSource) GetPactVersion(ctx context.Context, domainProject string, versionID,
+	pactID int32) (*brokerpb.PactVersion, error) {
+	key := path.GenerateBrokerPactVersionKey(domainProject, versionID, pactID)
+	pactVersion := &brokerpb.PactVersion{}
+	ok, err := getBrokerData(ctx, kv.Store().BrokerPactVersion(), key, pactVersion)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return pactVersion, nil
+}
+
+func (ds *DataSource) ListPactVersions(ctx context.Context, domainProject string) ([]*brokerpb.PactVersion, error) {
+	key := util.StringJoin([]string{path.GetBrokerPactVersionKey(domainProject), ""}, path.SPLIT)
+	var pactVersions []*brokerpb.PactVersion
+	err := listBrokerData(ctx, kv.Store().BrokerPactVersion(), key, func(data []byte) error {
+		pactVersion := &brokerpb.PactVersion{}
+		if err := json.Unmarshal(data, pactVersion); err != nil {
+			return err
+		}
+		pactVersions = append(pactVersions, pactVersion)
+		return nil
+	})
+	return pactVersions, err
+}
+
+func (ds *DataSource) CreatePactVersion(ctx context.Context, domainProject string,
+	pactVersion *brokerpb.PactVersion) error {
+	key := path.GenerateBrokerPactVersionKey(domainProject, pactVersion.VersionId, pactVersion.PactId)
+	return putBrokerData(ctx, key, pactVersion, datasource.BrokerPactVersion, pactVersion.Id)
+}
+
+func (ds *DataSource) ListVerifications(ctx context.Context, domainProject string,
+	pactVersionID int32) ([]*brokerpb.Verification, error) {
+	key := util.StringJoin([]string{path.GetBrokerVerificationKey(domainProject),
+		strconv.Itoa(int(pactVersionID)), ""}, path.SPLIT)
+	var verifications []*brokerpb.Verification
+	err := listBrokerData(ctx, kv.Store().BrokerVerification(), key, func(data []byte) error {
+		verification := &brokerpb.Verification{}
+		if err := json.Unmarshal(data, verification); err != nil {
+			return err
+		}
+		verifications = append(verifications, verification)
+		return nil
+	})
+	return verifications, err
+}
+
+func (ds *DataSource) CreateVerification(ctx context.Context, domainProject string,
+	verification *brokerpb.Verification) error {
+	key := path.GenerateBrokerVerificationKey(domainProject, verification.PactVersionId, verification.Number)
+	return putBrokerData(ctx, key, verification, datasource.BrokerVerification, verification.Id)
+}
+
+func (ds *DataSource) ListTags(ctx context.Context, domainProject string) ([]*brokerpb.Tag, error) {
+	key := util.StringJoin([]string{path.GetBrokerTagKey(domainProject), ""}, path.SPLIT)
+	var tags []*brokerpb.Tag
+	err := listBrokerData(ctx, kv.Store().BrokerPactTag(), key, func(data []byte) error {
+		tag := &brokerpb.Tag{}
+		if err := json.Unmarshal(data, tag); err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+		return nil
+	})
+	return tags, err
+}
+
+func (ds *DataSource) CreateTag(ctx context.Context, domainProject string, tag *brokerpb.Tag) error {
+	body, err := json.Marshal(tag)
+	if err != nil {
+		return err
+	}
+	return client.Put(ctx, path.GenerateBrokerTagKey(domainProject, tag.VersionId, tag.Name), string(body))
+}
+
+func (ds *DataSource) DeleteTag(ctx context.Context, domainProject string, versionID int32, name string) error {
+	_, err := client.Delete(ctx, path.GenerateBrokerTagKey(domainProject, versionID, name))
+	return err
+}
+
+func (ds *DataSource) ListDeployments(ctx context.Context, domainProject string) ([]*brokerpb.Deployment, error) {
+	key := util.StringJoin([]string{path.GetBrokerDeploymentKey(domainProject), ""}, path.SPLIT)
+	var deployments []*brokerpb.Deployment
+	err := listBrokerData(ctx, kv.Store().BrokerDeployment(), key, func(data []byte) error {
+		deployment := &brokerpb.Deployment{}
+		if err := json.Unmarshal(data, deployment); err != nil {
+			return err
+		}
+		deployments = append(deployments, deployment)
+		return nil
+	})
+	return deployments, err
+}
+
+func (ds *DataSource) PutDeployment(ctx context.Context, domainProject string, deployment *brokerpb.Deployment) error {
+	body, err := json.Marshal(deployment)
+	if err != nil {
+		return err
+	}
+	key := path.GenerateBrokerDeploymentKey(domainProject, deployment.Environment, deployment.ParticipantId)
+	return client.Put(ctx, key, string(body))
+}
+
+func (ds *DataSource) DeleteDeployment(ctx context.Context, domainProject, environment string, participantID int32) error {
+	_, err := client.Delete(ctx, path.GenerateBrokerDeploymentKey(domainProject, environment, participantID))
+	return err
+}
+
+func (ds *DataSource) GetWebhook(ctx context.Context, domainProject, webhookID string) (*brokerpb.Webhook, error) {
+	webhook := &brokerpb.Webhook{}
+	ok, err := getBrokerData(ctx, kv.Store().BrokerWebhook(), path.GenerateBrokerWebhookKey(domainProject, webhookID), webhook)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (ds *DataSource) ListWebhooks(ctx context.Context, domainProject string) ([]*brokerpb.Webhook, error) {
+	key := util.StringJoin([]string{path.GetBrokerWebhookKey(domainProject), ""}, path.SPLIT)
+	var webhooks []*brokerpb.Webhook
+	err := listBrokerData(ctx, kv.Store().BrokerWebhook(), key, func(data []byte) error {
+		webhook := &brokerpb.Webhook{}
+		if err := json.Unmarshal(data, webhook); err != nil {
+			return err
+		}
+		webhooks = append(webhooks, webhook)
+		return nil
+	})
+	return webhooks, err
+}
+
+func (ds *DataSource) PutWebhook(ctx context.Context, domainProject string, webhook *brokerpb.Webhook) error {
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+	return client.Put(ctx, path.GenerateBrokerWebhookKey(domainProject, webhook.Id), string(body))
+}
+
+func (ds *DataSource) DeleteWebhook(ctx context.Context, domainProject, webhookID string) error {
+	_, err := client.Delete(ctx, path.GenerateBrokerWebhookKey(domainProject, webhookID))
+	if err != nil {
+		return err
+	}
+	key := util.StringJoin([]string{path.GetBrokerWebhookExecutionKey(domainProject), webhookID, ""}, path.SPLIT)
+	_, err = client.Instance().Do(ctx, client.DEL, client.WithStrKey(key), client.WithPrefix())
+	return err
+}
+
+func (ds *DataSource) ListWebhookExecutions(ctx context.Context, domainProject,
+	webhookID string) ([]*brokerpb.WebhookExecution, error) {
+	key := util.StringJoin([]string{path.GetBrokerWebhookExecutionKey(domainProject), webhookID, ""}, path.SPLIT)
+	var executions []*brokerpb.WebhookExecution
+	err := listBrokerData(ctx, kv.Store().BrokerWebhookExec(), key, func(data []byte) error {
+		execution := &brokerpb.WebhookExecution{}
+		if err := json.Unmarshal(data, execution); err != nil {
+			return err
+		}
+		executions = append(executions, execution)
+		return nil
+	})
+	return executions, err
+}
+
+func (ds *DataSource) CreateWebhookExecution(ctx context.Context, domainProject string,
+	execution *brokerpb.WebhookExecution) error {
+	body, err := json.Marshal(execution)
+	if err != nil {
+		return err
+	}
+	key := path.GenerateBrokerWebhookExecutionKey(domainProject, execution.WebhookId, execution.Id)
+	return client.Put(ctx, key, string(body))
+}
+
+func (ds *DataSource) DeleteWebhookExecution(ctx context.Context, domainProject, webhookID, executionID string) error {
+	_, err := client.Delete(ctx, path.GenerateBrokerWebhookExecutionKey(domainProject, webhookID, executionID))
+	return err
+}
+
+func (ds *DataSource) GetLatestBrokerID(ctx context.Context, kind string) (int32, error) {
+	resp, err := kv.Store().BrokerPactLatest().Search(ctx, client.WithStrKey(path.GetBrokerLatestIDKey(kind)))
+	if err != nil {
+		return -1, err
+	}
+	if len(resp.Kvs) == 0 {
+		return -1, nil
+	}
+	id, err := strconv.Atoi(string(resp.Kvs[0].Value.([]byte)))
+	if err != nil {
+		return -1, err
+	}
+	return int32(id), nil
+}
+
+func (ds *DataSource) DeleteBrokerData(ctx context.Context) error {
+	_, err := client.Instance().Do(ctx, client.DEL,
+		client.WithStrKey(path.GetBrokerRootKey()), client.WithPrefix())
+	return err
+}
+
+func getBrokerData(ctx context.Context, indexer sd.Indexer, key string, data interface{}) (bool, error) {
+	resp, err := indexer.Search(ctx, client.WithStrKey(key))
+	if err != nil {
+		return false, err
+	}
+	if len(resp.Kvs) == 0 {
+		return false, nil
+	}
+	err = json.Unmarshal(resp.Kvs[0].Value.([]byte), data)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func listBrokerData(ctx context.Context, indexer sd.Indexer, prefix string, decode func(data []byte) error) error {
+	resp, err := indexer.Search(ctx, client.WithStrKey(prefix), client.WithPrefix())
+	if err != nil {
+		return err
+	}
+	for _, keyValue := range resp.Kvs {
+		if err := decode(keyValue.Value.([]byte)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putBrokerData saves the data and records its id as the latest one of the kind
+func putBrokerData(ctx context.Context, key string, data interface{}, kind string, id int32) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = client.Instance().Do(ctx, client.PUT, client.WithStrKey(key), client.WithValue(body))
+	if err != nil {
+		log.Errorf(err, "put broker data[%s] failed", key)
+		return err
+	}
+	err = client.Put(ctx, path.GetBrokerLatestIDKey(kind), strconv.Itoa(int(id)))
+	if err != nil {
+		log.Errorf(err, "put the latest %s id[%d] failed", kind, id)
+		return err
+	}
+	return nil
+}
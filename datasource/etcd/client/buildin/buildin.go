@@ -65,6 +65,9 @@ func (ec *Registry) LeaseRenew(ctx context.Context, leaseID int64) (TTL int64, e
 func (ec *Registry) LeaseRevoke(ctx context.Context, leaseID int64) error {
 	return nil
 }
+func (ec *Registry) LeaseTimeToLive(ctx context.Context, leaseID int64) (TTL int64, grantedTTL int64, err error) {
+	return 0, 0, nil
+}
 func (ec *Registry) Watch(ctx context.Context, opts ...client.PluginOpOption) error {
 	return nil
 }
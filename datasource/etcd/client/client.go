@@ -44,6 +44,9 @@ type Registry interface {
 	LeaseGrant(ctx context.Context, TTL int64) (leaseID int64, err error)
 	LeaseRenew(ctx context.Context, leaseID int64) (TTL int64, err error)
 	LeaseRevoke(ctx context.Context, leaseID int64) error
+	// LeaseTimeToLive returns the remaining and granted TTL of the lease,
+	// the remaining TTL is -1 if the lease is expired or does not exist
+	LeaseTimeToLive(ctx context.Context, leaseID int64) (TTL int64, grantedTTL int64, err error)
 	// this function block util:
 	// 1. connection error
 	// 2. call send function failed
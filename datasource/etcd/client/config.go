@@ -35,6 +35,10 @@ type Config struct {
 	AutoSyncInterval  time.Duration    `json:"autoSyncInterval"`
 	CompactIndexDelta int64            `json:"-"`
 	CompactInterval   time.Duration    `json:"-"`
+	// self preservation thresholds of instance DELETE events
+	SelfPreservationPercentage float64       `json:"selfPreservationPercentage"`
+	SelfPreservationMaxTTL     time.Duration `json:"selfPreservationMaxTTL"`
+	SelfPreservationInitCount  int           `json:"selfPreservationInitCount"`
 }
 
 //InitClusterInfo re-org address info with node name
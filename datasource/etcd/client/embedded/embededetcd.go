@@ -413,6 +413,21 @@ func (s *EtcdEmbed) LeaseRevoke(ctx context.Context, leaseID int64) error {
 	return nil
 }
 
+func (s *EtcdEmbed) LeaseTimeToLive(ctx context.Context, leaseID int64) (int64, int64, error) {
+	otCtx, cancel := etcd.WithTimeout(ctx)
+	defer cancel()
+	resp, err := s.Embed.Server.LeaseTimeToLive(otCtx, &etcdserverpb.LeaseTimeToLiveRequest{
+		ID: leaseID,
+	})
+	if err != nil {
+		if err.Error() == rpctypes.ErrLeaseNotFound.Error() {
+			return -1, 0, nil
+		}
+		return 0, 0, errorsEx.Internal(err)
+	}
+	return resp.TTL, resp.GrantedTTL, nil
+}
+
 func (s *EtcdEmbed) Watch(ctx context.Context, opts ...client.PluginOpOption) (err error) {
 	op := client.OpGet(opts...)
 
@@ -445,6 +460,10 @@ func (s *EtcdEmbed) Watch(ctx context.Context, opts ...client.PluginOpOption) (e
 					err = errors.New("channel is closed")
 					return
 				}
+				if resp.CompactRevision != 0 {
+					err = rpctypes.ErrCompacted
+					return
+				}
 
 				err = dispatch(resp.Events, op.WatchCallback)
 				if err != nil {
@@ -38,10 +38,11 @@ const (
 )
 
 const (
-	OperationCompact     = "COMPACT"
-	OperationTxn         = "TXN"
-	OperationLeaseGrant  = "LEASE_GRANT"
-	OperationLeaseRenew  = "LEASE_RENEW"
-	OperationLeaseRevoke = "LEASE_REVOKE"
-	OperationSyncMembers = "SYNC"
+	OperationCompact         = "COMPACT"
+	OperationTxn             = "TXN"
+	OperationLeaseGrant      = "LEASE_GRANT"
+	OperationLeaseRenew      = "LEASE_RENEW"
+	OperationLeaseRevoke     = "LEASE_REVOKE"
+	OperationLeaseTimeToLive = "LEASE_TTL"
+	OperationSyncMembers     = "SYNC"
 )
@@ -686,6 +686,30 @@ func (c *Client) LeaseRevoke(ctx context.Context, leaseID int64) error {
 	return nil
 }
 
+func (c *Client) LeaseTimeToLive(ctx context.Context, leaseID int64) (int64, int64, error) {
+	var err error
+
+	start := time.Now()
+	span := TracingBegin(ctx, "etcd:timetolive",
+		client.PluginOp{Action: client.ActionGet, Key: util.StringToBytesWithNoCopy(strconv.FormatInt(leaseID, 10))})
+	otCtx, cancel := etcd.WithTimeout(ctx)
+	defer func() {
+		client.ReportBackendOperationCompleted(OperationLeaseTimeToLive, err, start)
+		TracingEnd(span, err)
+		cancel()
+	}()
+
+	etcdResp, err := c.Client.TimeToLive(otCtx, clientv3.LeaseID(leaseID))
+	if err != nil {
+		if err.Error() == rpctypes.ErrLeaseNotFound.Error() {
+			return -1, 0, nil
+		}
+		return 0, 0, errorsEx.Internal(err)
+	}
+	log.NilOrWarnf(start, "registry client get lease %d time to live", leaseID)
+	return etcdResp.TTL, etcdResp.GrantedTTL, nil
+}
+
 func (c *Client) Watch(ctx context.Context, opts ...client.PluginOpOption) (err error) {
 	op := client.OpGet(opts...)
 
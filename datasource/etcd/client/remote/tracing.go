@@ -37,6 +37,10 @@ func (eo *EtcdOptions) URL() string {
 	return "/?" + eo.PluginOp.FormatURLParams()
 }
 
+func (eo *EtcdOptions) System() string {
+	return "etcd"
+}
+
 func TracingBegin(ctx context.Context, operationName string, op client.PluginOp) tracing.Span {
 	r := &tracing.Operation{
 		Ctx:      ctx,
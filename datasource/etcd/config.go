@@ -43,6 +43,9 @@ func Configuration() *client.Config {
 		defaultRegistryConfig.AutoSyncInterval = config.GetDuration("registry.etcd.autoSyncInterval", 30*time.Second, config.WithStandby("auto_sync_interval"))
 		defaultRegistryConfig.CompactIndexDelta = config.GetInt64("registry.etcd.compact.indexDelta", 100, config.WithStandby("compact_index_delta"))
 		defaultRegistryConfig.CompactInterval = config.GetDuration("registry.etcd.compact.interval", 12*time.Hour, config.WithStandby("compact_interval"))
+		defaultRegistryConfig.SelfPreservationPercentage = config.GetFloat64("registry.instance.selfPreservation.percentage", 0.8)
+		defaultRegistryConfig.SelfPreservationMaxTTL = config.GetDuration("registry.instance.selfPreservation.maxTTL", 10*time.Minute)
+		defaultRegistryConfig.SelfPreservationInitCount = config.GetInt("registry.instance.selfPreservation.initCount", 5)
 	})
 	return &defaultRegistryConfig
 }
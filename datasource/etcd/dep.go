@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
@@ -30,6 +31,7 @@ import (
 	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
 	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	pb "github.com/go-chassis/cari/discovery"
 )
@@ -99,6 +101,62 @@ func (ds *DataSource) SearchConsumerDependency(ctx context.Context, request *pb.
 	}, nil
 }
 
+func (ds *DataSource) ListProviderRules(ctx context.Context, consumer *pb.MicroService) ([]*pb.MicroServiceKey, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	key := path.GenerateConsumerDependencyRuleKey(domainProject, pb.MicroServiceToKey(domainProject, consumer))
+	dependency, err := serviceUtil.TransferToMicroServiceDependency(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return dependency.Dependency, nil
+}
+
+func (ds *DataSource) UpdateDependencySeen(ctx context.Context, consumerID string, providers []*pb.MicroServiceKey) error {
+	domainProject := util.ParseDomainProject(ctx)
+	now := time.Now().Unix()
+	opts := make([]client.PluginOp, 0, len(providers))
+	for _, provider := range providers {
+		data, err := json.Marshal(&proto.DependencySeen{
+			DomainProject: domainProject,
+			ConsumerId:    consumerID,
+			Provider:      provider,
+			LastSeen:      now,
+		})
+		if err != nil {
+			return err
+		}
+		key := path.GenerateServiceDependencySeenKey(domainProject, consumerID, provider)
+		opts = append(opts, client.OpPut(client.WithStrKey(key), client.WithValue(data)))
+	}
+	return client.BatchCommit(ctx, opts)
+}
+
+func (ds *DataSource) ListDependencySeen(ctx context.Context) ([]*proto.DependencySeen, error) {
+	kvs, _, err := client.List(ctx, path.GetServiceDependencySeenRootKey(""))
+	if err != nil {
+		return nil, err
+	}
+	seens := make([]*proto.DependencySeen, 0, len(kvs))
+	for _, kv := range kvs {
+		seen := &proto.DependencySeen{}
+		if err := json.Unmarshal(kv.Value, seen); err != nil {
+			log.Errorf(err, "unmarshal dependency last seen[%s] failed", kv.Key)
+			continue
+		}
+		seens = append(seens, seen)
+	}
+	return seens, nil
+}
+
+func (ds *DataSource) DeleteDependencySeen(ctx context.Context, seens []*proto.DependencySeen) error {
+	opts := make([]client.PluginOp, 0, len(seens))
+	for _, seen := range seens {
+		key := path.GenerateServiceDependencySeenKey(seen.DomainProject, seen.ConsumerId, seen.Provider)
+		opts = append(opts, client.OpDel(client.WithStrKey(key)))
+	}
+	return client.BatchCommit(ctx, opts)
+}
+
 func (ds *DataSource) DeleteDependency() {
 	panic("implement me")
 }
@@ -47,6 +47,8 @@ type DataSource struct {
 	SchemaEditable bool
 	// InstanceTTL options
 	InstanceTTL int64
+	// SchemaHistorySize is the max revisions of a schema kept in history
+	SchemaHistorySize int
 
 	lockMux sync.Mutex
 	locks   map[string]*etcdsync.DLock
@@ -57,9 +59,10 @@ func NewDataSource(opts datasource.Options) (datasource.DataSource, error) {
 	log.Warnf("data source enable etcd mode")
 
 	inst := &DataSource{
-		SchemaEditable: opts.SchemaEditable,
-		InstanceTTL:    opts.InstanceTTL,
-		locks:          make(map[string]*etcdsync.DLock),
+		SchemaEditable:    opts.SchemaEditable,
+		InstanceTTL:       opts.InstanceTTL,
+		SchemaHistorySize: opts.SchemaHistorySize,
+		locks:             make(map[string]*etcdsync.DLock),
 	}
 
 	registryAddresses := strings.Join(Configuration().RegistryAddresses(), ",")
@@ -77,12 +80,15 @@ func (ds *DataSource) initialize(opts datasource.Options) error {
 	ds.initPlugins(opts)
 	// Add events handlers
 	event.Initialize()
+	// Self preservation of instance DELETE events
+	ds.initSelfPreservation()
 	// Wait for kv store ready
 	ds.initKvStore()
 	// Compact
 	ds.autoCompact()
 	// Jobs
 	job.ClearNoInstanceServices()
+	job.ClearStaleDependencies()
 	return nil
 }
 
@@ -109,6 +115,12 @@ func (ds *DataSource) initPlugins(opts datasource.Options) {
 	}
 }
 
+func (ds *DataSource) initSelfPreservation() {
+	cfg := Configuration()
+	kv.InstanceDeferHandler().Configure(cfg.SelfPreservationPercentage,
+		int32(cfg.SelfPreservationMaxTTL/time.Second), cfg.SelfPreservationInitCount)
+}
+
 func (ds *DataSource) initKvStore() {
 	kv.Store().Run()
 	<-kv.Store().Ready()
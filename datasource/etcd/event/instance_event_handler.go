@@ -34,6 +34,7 @@ import (
 	"github.com/apache/servicecomb-service-center/server/event"
 	"github.com/apache/servicecomb-service-center/server/metrics"
 	"github.com/apache/servicecomb-service-center/server/syncernotify"
+	"github.com/apache/servicecomb-service-center/server/webhook"
 	pb "github.com/go-chassis/cari/discovery"
 )
 
@@ -49,6 +50,7 @@ const (
 // 2. recover the instance quota
 // 3. publish the instance events to the subscribers
 // 4. reset the find instance cache
+// 5. send the instance events to the webhooks
 type InstanceEventHandler struct {
 }
 
@@ -97,6 +99,14 @@ func (h *InstanceEventHandler) OnEvent(evt sd.KvEvent) {
 		return
 	}
 
+	webhook.Fire(&webhook.Event{
+		ID:            webhookEventID(evt),
+		Action:        action,
+		DomainProject: domainProject,
+		Service:       ms,
+		Instance:      instance,
+	})
+
 	if !syncernotify.GetSyncerNotifyCenter().Closed() {
 		NotifySyncerInstanceEvent(evt, domainProject, ms)
 	}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package event
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+)
+
+// ToRegistryEvent converts the changed kv to the event, returns nil if the kv
+// is not a registry resource of the domain project
+func ToRegistryEvent(domainProject string, action client.ActionType, kv *mvccpb.KeyValue) *datasource.RegistryEvent {
+	resource, dp, serviceID, id := ParseRegistryKey(kv.Key)
+	if len(resource) == 0 || dp != domainProject {
+		return nil
+	}
+	evt := &datasource.RegistryEvent{
+		Revision:  kv.ModRevision,
+		Action:    pb.EVT_UPDATE,
+		Resource:  resource,
+		ServiceID: serviceID,
+		ID:        id,
+	}
+	switch {
+	case action == client.ActionDelete:
+		evt.Action = pb.EVT_DELETE
+		return evt
+	case kv.Version == 1:
+		evt.Action = pb.EVT_CREATE
+	}
+	evt.Value = kv.Value
+	if resource == datasource.ResourceSchema {
+		// the schema content is stored as it is
+		evt.Value, _ = json.Marshal(&pb.Schema{SchemaId: id, Schema: string(kv.Value)})
+	}
+	return evt
+}
+
+// ParseRegistryKey returns the registry resource info of the key, e.g.
+// /cse-sr/ms/files/{domain}/{project}/{serviceId}
+// /cse-sr/inst/files/{domain}/{project}/{serviceId}/{instanceId}
+// /cse-sr/ms/dep-rules/{domain}/{project}/{c|p}/{env}/{appId}/{serviceName}/{version}
+func ParseRegistryKey(key []byte) (resource, domainProject, serviceID, id string) {
+	keys := path.ToResponse(key)
+	if len(keys) < 7 || keys[0] != "" || keys[1] != path.RegistryRootKey {
+		return
+	}
+	domainProject = keys[4] + path.SPLIT + keys[5]
+	rest := keys[6:]
+	switch keys[2] + path.SPLIT + keys[3] {
+	case path.RegistryServiceKey + path.SPLIT + path.RegistryFile:
+		if len(rest) == 1 {
+			return datasource.ResourceService, domainProject, rest[0], ""
+		}
+	case path.RegistryServiceKey + path.SPLIT + path.RegistryTagKey:
+		if len(rest) == 1 {
+			return datasource.ResourceTag, domainProject, rest[0], ""
+		}
+	case path.RegistryServiceKey + path.SPLIT + path.RegistrySchemaKey:
+		if len(rest) == 2 {
+			return datasource.ResourceSchema, domainProject, rest[0], rest[1]
+		}
+	case path.RegistryServiceKey + path.SPLIT + path.RegistryRuleKey:
+		if len(rest) == 2 {
+			return datasource.ResourceRule, domainProject, rest[0], rest[1]
+		}
+	case path.RegistryInstanceKey + path.SPLIT + path.RegistryFile:
+		if len(rest) == 2 {
+			return datasource.ResourceInstance, domainProject, rest[0], rest[1]
+		}
+	case path.RegistryServiceKey + path.SPLIT + path.RegistryDepsRuleKey:
+		if len(rest) > 1 {
+			return datasource.ResourceDependency, domainProject, "", strings.Join(rest, path.SPLIT)
+		}
+	}
+	return "", "", "", ""
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package event
+
+import (
+	"testing"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+)
+
+func TestParseRegistryKey(t *testing.T) {
+	cases := []struct {
+		key                                    string
+		resource, domainProject, serviceID, id string
+	}{
+		{path.GenerateServiceKey("a/b", "s1"), datasource.ResourceService, "a/b", "s1", ""},
+		{path.GenerateInstanceKey("a/b", "s1", "i1"), datasource.ResourceInstance, "a/b", "s1", "i1"},
+		{path.GenerateServiceSchemaKey("a/b", "s1", "sc1"), datasource.ResourceSchema, "a/b", "s1", "sc1"},
+		{path.GenerateServiceTagKey("a/b", "s1"), datasource.ResourceTag, "a/b", "s1", ""},
+		{path.GenerateServiceRuleKey("a/b", "s1", "r1"), datasource.ResourceRule, "a/b", "s1", "r1"},
+		{path.GenerateConsumerDependencyRuleKey("a/b", &pb.MicroServiceKey{
+			Environment: "prod", AppId: "app", ServiceName: "svc", Version: "1.0.0"}),
+			datasource.ResourceDependency, "a/b", "", "c/prod/app/svc/1.0.0"},
+		// not the registry resources
+		{path.GenerateServiceSchemaSummaryKey("a/b", "s1", "sc1"), "", "", "", ""},
+		{path.GenerateWebhookKey("a/b", "w1"), "", "", "", ""},
+		{path.GenerateWebhookDeliveryKey("a/b", "w1", "d1"), "", "", "", ""},
+		{path.GenerateInstanceLeaseKey("a/b", "s1", "i1"), "", "", "", ""},
+		{path.GenerateServiceIndexKey(&pb.MicroServiceKey{Tenant: "a/b", AppId: "app"}), "", "", "", ""},
+		{path.GenerateProjectKey("a", "b"), "", "", "", ""},
+		{path.GetServiceRootKey("a/b"), "", "", "", ""},
+	}
+	for _, c := range cases {
+		resource, domainProject, serviceID, id := ParseRegistryKey([]byte(c.key))
+		assert.Equal(t, c.resource, resource, c.key)
+		assert.Equal(t, c.domainProject, domainProject, c.key)
+		assert.Equal(t, c.serviceID, serviceID, c.key)
+		assert.Equal(t, c.id, id, c.key)
+	}
+}
+
+func TestToRegistryEvent(t *testing.T) {
+	key := []byte(path.GenerateInstanceKey("a/b", "s1", "i1"))
+
+	t.Run("a kv of the other project should be ignored", func(t *testing.T) {
+		assert.Nil(t, ToRegistryEvent("a/c", client.ActionPut, &mvccpb.KeyValue{Key: key, Version: 1}))
+	})
+
+	t.Run("the first version should be a create event", func(t *testing.T) {
+		evt := ToRegistryEvent("a/b", client.ActionPut,
+			&mvccpb.KeyValue{Key: key, Value: []byte(`{"instanceId":"i1"}`), ModRevision: 10, Version: 1})
+		assert.Equal(t, &datasource.RegistryEvent{Revision: 10, Action: pb.EVT_CREATE, Resource: datasource.ResourceInstance,
+			ServiceID: "s1", ID: "i1", Value: []byte(`{"instanceId":"i1"}`)}, evt)
+	})
+
+	t.Run("the later version should be an update event", func(t *testing.T) {
+		evt := ToRegistryEvent("a/b", client.ActionPut, &mvccpb.KeyValue{Key: key, ModRevision: 11, Version: 2})
+		assert.Equal(t, pb.EVT_UPDATE, evt.Action)
+		assert.Equal(t, int64(11), evt.Revision)
+	})
+
+	t.Run("a delete event should have no value", func(t *testing.T) {
+		evt := ToRegistryEvent("a/b", client.ActionDelete, &mvccpb.KeyValue{Key: key, ModRevision: 12})
+		assert.Equal(t, pb.EVT_DELETE, evt.Action)
+		assert.Empty(t, evt.Value)
+	})
+
+	t.Run("the schema content should be wrapped", func(t *testing.T) {
+		evt := ToRegistryEvent("a/b", client.ActionPut, &mvccpb.KeyValue{
+			Key: []byte(path.GenerateServiceSchemaKey("a/b", "s1", "sc1")), Value: []byte("swagger: '2.0'"), Version: 1})
+		assert.JSONEq(t, `{"schemaId":"sc1","schema":"swagger: '2.0'"}`, string(evt.Value))
+	})
+}
@@ -18,6 +18,7 @@
 package event
 
 import (
+	"context"
 	"strings"
 
 	pb "github.com/go-chassis/cari/discovery"
@@ -25,10 +26,14 @@ import (
 	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
 	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
 	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
+	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/metrics"
+	"github.com/apache/servicecomb-service-center/server/webhook"
 )
 
-// SchemaSummaryEventHandler report schema metrics
+// SchemaSummaryEventHandler report schema metrics and
+// send the schema events to the webhooks
 type SchemaSummaryEventHandler struct {
 }
 
@@ -50,6 +55,26 @@ func (h *SchemaSummaryEventHandler) OnEvent(evt sd.KvEvent) {
 		}
 	default:
 	}
+
+	if action == pb.EVT_INIT {
+		return
+	}
+
+	domainProject, serviceID, schemaID := path.GetInfoFromSchemaSummaryKV(evt.KV.Key)
+	summary, _ := evt.KV.Value.(string)
+	ctx := util.WithGlobal(util.WithCacheOnly(context.Background()))
+	ms, err := serviceUtil.GetService(ctx, domainProject, serviceID)
+	if err != nil {
+		// the service may be deleted with the schema
+		ms = &pb.MicroService{ServiceId: serviceID}
+	}
+	webhook.Fire(&webhook.Event{
+		ID:            webhookEventID(evt),
+		Action:        action,
+		DomainProject: domainProject,
+		Service:       ms,
+		Schema:        &pb.Schema{SchemaId: schemaID, Summary: summary},
+	})
 }
 
 func NewSchemaSummaryEventHandler() *SchemaSummaryEventHandler {
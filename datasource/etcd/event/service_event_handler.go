@@ -30,12 +30,14 @@ import (
 	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/server/metrics"
+	"github.com/apache/servicecomb-service-center/server/webhook"
 )
 
 // ServiceEventHandler is the handler to handle:
 // 1. report service metrics
 // 2. save the new domain & project mapping
 // 3. reset the find instance cache
+// 4. send the service events to the webhooks
 type ServiceEventHandler struct {
 }
 
@@ -71,6 +73,13 @@ func (h *ServiceEventHandler) OnEvent(evt sd.KvEvent) {
 	log.Infof("caught [%s] service[%s][%s/%s/%s/%s] event",
 		evt.Type, ms.ServiceId, ms.Environment, ms.AppId, ms.ServiceName, ms.Version)
 
+	webhook.Fire(&webhook.Event{
+		ID:            webhookEventID(evt),
+		Action:        evt.Type,
+		DomainProject: domainProject,
+		Service:       ms,
+	})
+
 	// cache
 	providerKey := pb.MicroServiceToKey(domainProject, ms)
 	cache.FindInstances.Remove(providerKey)
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"testing"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+)
+
+func TestWebhookEventID(t *testing.T) {
+	key := path.GenerateInstanceKey("a/b", "s1", "i1")
+	evt := sd.KvEvent{Type: pb.EVT_UPDATE, Revision: 12, KV: &sd.KeyValue{Key: []byte(key), ModRevision: 10}}
+	assert.Equal(t, key+"/10/UPDATE", webhookEventID(evt))
+
+	// the revision of the watch response is not the same in the cluster
+	evt.Revision = 13
+	assert.Equal(t, key+"/10/UPDATE", webhookEventID(evt))
+
+	evt.Type = pb.EVT_DELETE
+	assert.Equal(t, key+"/10/DELETE", webhookEventID(evt))
+}
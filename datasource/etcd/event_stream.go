@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/event"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+func (ds *DataSource) WatchRegistryEvents(ctx context.Context, domainProject string, rev int64,
+	f datasource.RegistryEventFunc) error {
+	opts := []client.PluginOpOption{
+		client.WithStrKey(path.GetRootKey() + path.SPLIT),
+		client.WithPrefix(),
+		client.WithWatchCallback(func(_ string, resp *client.PluginResponse) error {
+			for _, kv := range resp.Kvs {
+				evt := event.ToRegistryEvent(domainProject, resp.Action, kv)
+				if evt == nil {
+					continue
+				}
+				if err := f(evt); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+	if rev > 0 {
+		opts = append(opts, client.WithRev(rev+1))
+	}
+	err := client.Instance().Watch(ctx, opts...)
+	if err == rpctypes.ErrCompacted {
+		log.Warnf("the revision %d of domain project[%s] has been compacted", rev, domainProject)
+		return datasource.ErrRevisionCompacted
+	}
+	return err
+}
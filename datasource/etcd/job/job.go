@@ -61,3 +61,17 @@ func ClearNoInstanceServices() {
 		}
 	})
 }
+
+// clear the dependencies which the consumers have not found the providers for TTL
+func ClearStaleDependencies() {
+	if !config.GetRegistry().DependencyClearEnabled {
+		return
+	}
+	ttl := config.GetRegistry().DependencyTTL
+	interval := config.GetRegistry().DependencyClearInterval
+	log.Infof("dependency clear enabled, interval: %s, dependency TTL: %s", interval, ttl)
+
+	gopool.Go(func(ctx context.Context) {
+		datasource.RunClearStaleDependencies(ctx, interval, ttl)
+	})
+}
@@ -25,9 +25,12 @@ import (
 	"github.com/go-chassis/cari/discovery"
 
 	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/alarm"
+	"github.com/apache/servicecomb-service-center/server/metrics"
 )
 
 type deferItem struct {
@@ -37,9 +40,14 @@ type deferItem struct {
 
 type InstanceEventDeferHandler struct {
 	Percent float64
+	// MaxTTL is the max seconds a DELETE event can be deferred
+	MaxTTL int32
+	// InitCount is the min instances count to enable self preservation
+	InitCount int
 
 	cache    sd.CacheReader
 	once     sync.Once
+	lock     sync.RWMutex
 	enabled  bool
 	items    map[string]*deferItem
 	evts     chan []sd.KvEvent
@@ -91,13 +99,15 @@ func (iedh *InstanceEventDeferHandler) recoverOrDefer(evt sd.KvEvent) {
 			return
 		}
 		ttl := instance.HealthCheck.Interval * (instance.HealthCheck.Times + 1)
-		if ttl <= 0 || ttl > selfPreservationMaxTTL {
-			ttl = selfPreservationMaxTTL
+		if ttl <= 0 || ttl > iedh.MaxTTL {
+			ttl = iedh.MaxTTL
 		}
+		iedh.lock.Lock()
 		iedh.items[key] = &deferItem{
 			ReplayAfter: ttl,
 			event:       evt,
 		}
+		iedh.lock.Unlock()
 	}
 }
 
@@ -120,6 +130,7 @@ func (iedh *InstanceEventDeferHandler) check(ctx context.Context) {
 			}
 
 			del := len(iedh.items)
+			metrics.ReportSelfPreservation(iedh.enabled, del)
 			if del == 0 {
 				continue
 			}
@@ -129,10 +140,15 @@ func (iedh *InstanceEventDeferHandler) check(ctx context.Context) {
 			}
 
 			total := iedh.cache.GetAll(nil)
-			if total > selfPreservationInitCount && float64(del) >= float64(total)*iedh.Percent {
-				iedh.enabled = true
+			if total > iedh.InitCount && float64(del) >= float64(total)*iedh.Percent {
+				iedh.setEnabled(true)
 				log.Warnf("self preservation is enabled, caught %d/%d(>=%.0f%%) DELETE events",
 					del, total, iedh.Percent*100)
+				metrics.ReportSelfPreservation(true, del)
+				if err := alarm.Raise(alarm.IDSelfPreservationEnabled,
+					alarm.AdditionalContext("caught %d/%d(>=%.0f%%) DELETE events", del, total, iedh.Percent*100)); err != nil {
+					log.Error("", err)
+				}
 			}
 
 			if !n {
@@ -144,16 +160,17 @@ func (iedh *InstanceEventDeferHandler) check(ctx context.Context) {
 			t.Reset(deferCheckWindow)
 
 			if !iedh.enabled {
-				for _, item := range iedh.items {
+				for _, item := range iedh.expiredItems(0) {
 					iedh.replayEvent(item.event)
 				}
+				metrics.ReportSelfPreservation(false, len(iedh.items))
 				continue
 			}
 
 			iedh.ReplayEvents()
 		case <-iedh.resetCh:
 			iedh.ReplayEvents()
-			iedh.enabled = false
+			iedh.stop()
 			util.ResetTimer(t, deferCheckWindow)
 		}
 	}
@@ -161,26 +178,77 @@ func (iedh *InstanceEventDeferHandler) check(ctx context.Context) {
 
 func (iedh *InstanceEventDeferHandler) ReplayEvents() {
 	interval := int32(deferCheckWindow / time.Second)
-	for key, item := range iedh.items {
-		item.ReplayAfter -= interval
-		if item.ReplayAfter > 0 {
-			continue
-		}
-		log.Warnf("replay delete event, remove key: %s", key)
+	for _, item := range iedh.expiredItems(interval) {
+		log.Warnf("replay delete event, remove key: %s", item.event.KV.Key)
 		iedh.replayEvent(item.event)
 	}
 	if len(iedh.items) == 0 {
-		iedh.enabled = false
+		iedh.stop()
 		log.Warnf("self preservation stopped")
 	}
+	metrics.ReportSelfPreservation(iedh.enabled, len(iedh.items))
+}
+
+// expiredItems counts down the deferred items by elapsed seconds and
+// returns the ones should be replayed
+func (iedh *InstanceEventDeferHandler) expiredItems(elapsed int32) (items []*deferItem) {
+	iedh.lock.Lock()
+	defer iedh.lock.Unlock()
+	for _, item := range iedh.items {
+		item.ReplayAfter -= elapsed
+		if elapsed > 0 && item.ReplayAfter > 0 {
+			continue
+		}
+		items = append(items, item)
+	}
+	return
+}
+
+func (iedh *InstanceEventDeferHandler) setEnabled(enabled bool) {
+	iedh.lock.Lock()
+	iedh.enabled = enabled
+	iedh.lock.Unlock()
+}
+
+func (iedh *InstanceEventDeferHandler) stop() {
+	if !iedh.enabled {
+		return
+	}
+	iedh.setEnabled(false)
+	if err := alarm.Clear(alarm.IDSelfPreservationEnabled); err != nil {
+		log.Error("", err)
+	}
 }
 
 func (iedh *InstanceEventDeferHandler) replayEvent(evt sd.KvEvent) {
 	key := util.BytesToStringWithNoCopy(evt.KV.Key)
+	iedh.lock.Lock()
 	delete(iedh.items, key)
+	iedh.lock.Unlock()
 	iedh.replayCh <- evt
 }
 
+// Dump return the self preservation status and the deferred DELETE events
+func (iedh *InstanceEventDeferHandler) Dump() *dump.SelfPreservation {
+	iedh.lock.RLock()
+	defer iedh.lock.RUnlock()
+	sp := &dump.SelfPreservation{
+		Enabled:    iedh.enabled,
+		Percentage: iedh.Percent,
+		MaxTTL:     iedh.MaxTTL,
+		InitCount:  iedh.InitCount,
+	}
+	now := time.Now()
+	for key, item := range iedh.items {
+		sp.Deferred = append(sp.Deferred, &dump.DeferredEvent{
+			Key:         key,
+			ReplayAfter: item.ReplayAfter,
+			ReplayAt:    now.Add(time.Duration(item.ReplayAfter) * time.Second).Unix(),
+		})
+	}
+	return sp
+}
+
 func (iedh *InstanceEventDeferHandler) Reset() bool {
 	if iedh.enabled || len(iedh.items) != 0 {
 		log.Warnf("self preservation is reset")
@@ -190,6 +258,27 @@ func (iedh *InstanceEventDeferHandler) Reset() bool {
 	return false
 }
 
+// Configure set the self preservation thresholds, it must be called before
+// the INSTANCE cacher running
+func (iedh *InstanceEventDeferHandler) Configure(percent float64, maxTTL int32, initCount int) {
+	if percent < 0 || percent > 1 {
+		log.Warnf("invalid self preservation percentage %v, use default %v", percent, selfPreservationPercentage)
+		percent = selfPreservationPercentage
+	}
+	if maxTTL <= 0 {
+		maxTTL = selfPreservationMaxTTL
+	}
+	if initCount < 0 {
+		initCount = selfPreservationInitCount
+	}
+	iedh.Percent, iedh.MaxTTL, iedh.InitCount = percent, maxTTL, initCount
+	metrics.ReportSelfPreservationThresholds(percent, maxTTL, initCount)
+}
+
 func NewInstanceEventDeferHandler() *InstanceEventDeferHandler {
-	return &InstanceEventDeferHandler{Percent: selfPreservationPercentage}
+	return &InstanceEventDeferHandler{
+		Percent:   selfPreservationPercentage,
+		MaxTTL:    selfPreservationMaxTTL,
+		InitCount: selfPreservationInitCount,
+	}
 }
@@ -59,6 +59,25 @@ func TestInstanceEventDeferHandler_OnCondition(t *testing.T) {
 	}
 }
 
+func TestInstanceEventDeferHandler_Configure(t *testing.T) {
+	iedh := NewInstanceEventDeferHandler()
+	iedh.Configure(0.5, 60, 10)
+	if iedh.Percent != 0.5 || iedh.MaxTTL != 60 || iedh.InitCount != 10 {
+		t.Fatalf(`TestInstanceEventDeferHandler_Configure failed`)
+	}
+
+	iedh.Configure(2, 0, -1)
+	if iedh.Percent != selfPreservationPercentage || iedh.MaxTTL != selfPreservationMaxTTL ||
+		iedh.InitCount != selfPreservationInitCount {
+		t.Fatalf(`TestInstanceEventDeferHandler_Configure with invalid values failed`)
+	}
+
+	sp := iedh.Dump()
+	if sp.Enabled || len(sp.Deferred) != 0 || sp.Percentage != selfPreservationPercentage {
+		t.Fatalf(`TestInstanceEventDeferHandler_Dump failed`)
+	}
+}
+
 func TestInstanceEventDeferHandler_HandleChan(t *testing.T) {
 	b := &pb.MicroServiceInstance{
 		HealthCheck: &pb.HealthCheck{
@@ -101,7 +120,9 @@ func TestInstanceEventDeferHandler_HandleChan(t *testing.T) {
 	}
 
 	iedh := &InstanceEventDeferHandler{
-		Percent: 1,
+		Percent:   1,
+		MaxTTL:    selfPreservationMaxTTL,
+		InitCount: selfPreservationInitCount,
 	}
 	iedh.OnCondition(c, evts0)
 	select {
@@ -191,6 +191,19 @@ func (s *TypeStore) DependencyQueue() sd.Adaptor    { return s.Adaptors(Dependen
 func (s *TypeStore) Domain() sd.Adaptor             { return s.Adaptors(DOMAIN) }
 func (s *TypeStore) Project() sd.Adaptor            { return s.Adaptors(PROJECT) }
 
+func (s *TypeStore) BrokerParticipant() sd.Adaptor  { return s.Adaptors(BrokerParticipant) }
+func (s *TypeStore) BrokerVersion() sd.Adaptor      { return s.Adaptors(BrokerVersion) }
+func (s *TypeStore) BrokerPact() sd.Adaptor         { return s.Adaptors(BrokerPact) }
+func (s *TypeStore) BrokerPactVersion() sd.Adaptor  { return s.Adaptors(BrokerPactVersion) }
+func (s *TypeStore) BrokerVerification() sd.Adaptor { return s.Adaptors(BrokerVerification) }
+func (s *TypeStore) BrokerPactLatest() sd.Adaptor   { return s.Adaptors(BrokerPactLatest) }
+func (s *TypeStore) BrokerPactTag() sd.Adaptor      { return s.Adaptors(BrokerPactTag) }
+func (s *TypeStore) BrokerDeployment() sd.Adaptor   { return s.Adaptors(BrokerDeployment) }
+func (s *TypeStore) BrokerWebhook() sd.Adaptor      { return s.Adaptors(BrokerWebhook) }
+func (s *TypeStore) BrokerWebhookExec() sd.Adaptor  { return s.Adaptors(BrokerWebhookExec) }
+func (s *TypeStore) Webhook() sd.Adaptor            { return s.Adaptors(Webhook) }
+func (s *TypeStore) WebhookDelivery() sd.Adaptor    { return s.Adaptors(WebhookDelivery) }
+
 func Store() *TypeStore {
 	return store
 }
@@ -33,6 +33,8 @@ const (
 	selfPreservationInitCount  = 5
 )
 
+var instanceDeferHandler = NewInstanceEventDeferHandler()
+
 var (
 	DOMAIN          sd.Type
 	PROJECT         sd.Type
@@ -48,6 +50,20 @@ var (
 	SchemaSummary   sd.Type
 	INSTANCE        sd.Type
 	LEASE           sd.Type
+
+	BrokerParticipant  sd.Type
+	BrokerVersion      sd.Type
+	BrokerPact         sd.Type
+	BrokerPactVersion  sd.Type
+	BrokerPactTag      sd.Type
+	BrokerVerification sd.Type
+	BrokerPactLatest   sd.Type
+	BrokerDeployment   sd.Type
+	BrokerWebhook      sd.Type
+	BrokerWebhookExec  sd.Type
+
+	Webhook         sd.Type
+	WebhookDelivery sd.Type
 )
 
 func registerInnerTypes() {
@@ -57,7 +73,7 @@ func registerInnerTypes() {
 	INSTANCE = Store().MustInstall(NewAddOn("INSTANCE",
 		sd.Configure().WithPrefix(path.GetInstanceRootKey("")).
 			WithInitSize(1000).WithParser(value.InstanceParser).
-			WithDeferHandler(NewInstanceEventDeferHandler())))
+			WithDeferHandler(instanceDeferHandler)))
 	DOMAIN = Store().MustInstall(NewAddOn("DOMAIN",
 		sd.Configure().WithPrefix(path.GetDomainRootKey()+path.SPLIT).
 			WithInitSize(100).WithParser(value.StringParser)))
@@ -94,4 +110,41 @@ func registerInnerTypes() {
 	PROJECT = Store().MustInstall(NewAddOn("PROJECT",
 		sd.Configure().WithPrefix(path.GetProjectRootKey("")).
 			WithInitSize(100).WithParser(value.StringParser)))
+	registerBrokerTypes()
+	registerWebhookTypes()
+}
+
+func registerBrokerTypes() {
+	BrokerParticipant = Store().MustInstall(NewAddOn("PARTICIPANT",
+		sd.Configure().WithPrefix(path.GetBrokerParticipantKey(""))))
+	BrokerVersion = Store().MustInstall(NewAddOn("VERSION",
+		sd.Configure().WithPrefix(path.GetBrokerVersionKey(""))))
+	BrokerPact = Store().MustInstall(NewAddOn("PACT",
+		sd.Configure().WithPrefix(path.GetBrokerPactKey(""))))
+	BrokerPactVersion = Store().MustInstall(NewAddOn("PACT_VERSION",
+		sd.Configure().WithPrefix(path.GetBrokerPactVersionKey(""))))
+	BrokerPactTag = Store().MustInstall(NewAddOn("PACT_TAG",
+		sd.Configure().WithPrefix(path.GetBrokerTagKey(""))))
+	BrokerVerification = Store().MustInstall(NewAddOn("VERIFICATION",
+		sd.Configure().WithPrefix(path.GetBrokerVerificationKey(""))))
+	BrokerPactLatest = Store().MustInstall(NewAddOn("PACT_LATEST",
+		sd.Configure().WithPrefix(path.GetBrokerLatestKey(""))))
+	BrokerDeployment = Store().MustInstall(NewAddOn("DEPLOYMENT",
+		sd.Configure().WithPrefix(path.GetBrokerDeploymentKey(""))))
+	BrokerWebhook = Store().MustInstall(NewAddOn("WEBHOOK",
+		sd.Configure().WithPrefix(path.GetBrokerWebhookKey(""))))
+	BrokerWebhookExec = Store().MustInstall(NewAddOn("WEBHOOK_EXECUTION",
+		sd.Configure().WithPrefix(path.GetBrokerWebhookExecutionKey(""))))
+}
+
+func registerWebhookTypes() {
+	Webhook = Store().MustInstall(NewAddOn("REGISTRY_WEBHOOK",
+		sd.Configure().WithPrefix(path.GetWebhookRootKey(""))))
+	WebhookDelivery = Store().MustInstall(NewAddOn("REGISTRY_WEBHOOK_DELIVERY",
+		sd.Configure().WithPrefix(path.GetWebhookDeliveryRootKey(""))))
+}
+
+// InstanceDeferHandler return the self preservation handler of INSTANCE events
+func InstanceDeferHandler() *InstanceEventDeferHandler {
+	return instanceDeferHandler
 }
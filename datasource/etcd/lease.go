@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+func (ds *DataSource) ListLeases(ctx context.Context, serviceID string) ([]*dump.Lease, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, domainProject, serviceID)
+	if err != nil {
+		log.Errorf(err, "list service[%s] leases failed", serviceID)
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	leases := make([]*dump.Lease, 0, len(instances))
+	for _, instance := range instances {
+		lease, err := getLease(ctx, domainProject, serviceID, instance.InstanceId)
+		if err != nil {
+			return nil, err
+		}
+		leases = append(leases, lease)
+	}
+	return leases, nil
+}
+
+func (ds *DataSource) ExpireLease(ctx context.Context, serviceID, instanceID string) error {
+	remoteIP := util.GetIPFromContext(ctx)
+	instanceFlag := util.StringJoin([]string{serviceID, instanceID}, "/")
+	if err := revokeInstance(ctx, util.ParseDomainProject(ctx), serviceID, instanceID); err != nil {
+		log.Errorf(err, "expire instance[%s] lease failed, operator %s", instanceFlag, remoteIP)
+		return err
+	}
+	log.Infof("expire instance[%s] lease, operator %s", instanceFlag, remoteIP)
+	return nil
+}
+
+func (ds *DataSource) ExtendLease(ctx context.Context, serviceID, instanceID string) (*dump.Lease, error) {
+	remoteIP := util.GetIPFromContext(ctx)
+	instanceFlag := util.StringJoin([]string{serviceID, instanceID}, "/")
+	leaseID, ttl, err := serviceUtil.HeartbeatUtil(ctx, util.ParseDomainProject(ctx), serviceID, instanceID)
+	if err != nil {
+		log.Errorf(err, "extend instance[%s] lease failed, operator %s", instanceFlag, remoteIP)
+		return nil, err
+	}
+	log.Infof("extend instance[%s] lease %ds, operator %s", instanceFlag, ttl, remoteIP)
+	return &dump.Lease{
+		ServiceID:     serviceID,
+		InstanceID:    instanceID,
+		LeaseID:       leaseID,
+		TTL:           ttl,
+		Remaining:     ttl,
+		LastRenewTime: time.Now().Unix(),
+	}, nil
+}
+
+func getLease(ctx context.Context, domainProject, serviceID, instanceID string) (*dump.Lease, error) {
+	leaseID, err := serviceUtil.GetLeaseID(ctx, domainProject, serviceID, instanceID)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrUnavailableBackend, err.Error())
+	}
+	lease := &dump.Lease{
+		ServiceID:  serviceID,
+		InstanceID: instanceID,
+		Remaining:  -1,
+	}
+	if leaseID == -1 {
+		return lease, nil
+	}
+	lease.LeaseID = leaseID
+	remaining, granted, err := client.Instance().LeaseTimeToLive(ctx, leaseID)
+	if err != nil {
+		return nil, pb.NewError(pb.ErrUnavailableBackend, err.Error())
+	}
+	lease.TTL, lease.Remaining = granted, remaining
+	if remaining >= 0 {
+		// etcd resets the remaining TTL to the granted TTL when renewing
+		lease.LastRenewTime = time.Now().Unix() - (granted - remaining)
+	}
+	return lease, nil
+}
@@ -994,6 +994,9 @@ func (ds *DataSource) findInstance(ctx context.Context, request *pb.FindInstance
 			}, err
 		}
 	}
+	if len(item.ServiceIds) > 0 {
+		datasource.RefreshDependencySeen(ctx, request.ConsumerServiceId, provider)
+	}
 
 	return ds.genFindResult(ctx, rev, item)
 }
@@ -2270,7 +2273,7 @@ func (ds *DataSource) modifySchemas(ctx context.Context, domainProject string, s
 }
 
 func (ds *DataSource) isSchemaEditable(service *pb.MicroService) bool {
-	return (len(service.Environment) != 0 && service.Environment != pb.ENV_PROD) || ds.SchemaEditable
+	return datasource.SchemaEnvironment(service.Environment) != pb.ENV_PROD || ds.SchemaEditable
 }
 
 func (ds *DataSource) modifySchema(ctx context.Context, serviceID string, schema *pb.Schema) *errsvc.Error {
@@ -2406,7 +2409,7 @@ func (ds *DataSource) DeleteServicePri(ctx context.Context, serviceID string, fo
 		if l := len(services); l > 1 || (l == 1 && services[0] != serviceID) {
 			log.Errorf(nil, "delete micro-service[%s] failed, other services[%d] depend on it, operator: %s",
 				serviceID, l, remoteIP)
-			return pb.CreateResponse(pb.ErrDependedOnConsumer, "Can not delete this service, other service rely it."), err
+			return pb.CreateResponse(pb.ErrDependedOnConsumer, datasource.DependedOnConsumerMessage(ctx, serviceID)), err
 		}
 
 		instancesKey := path.GenerateInstanceKey(domainProject, serviceID, "")
@@ -2466,6 +2469,9 @@ func (ds *DataSource) DeleteServicePri(ctx context.Context, serviceID string, fo
 	opts = append(opts, client.OpDel(
 		client.WithStrKey(path.GenerateServiceSchemaSummaryKey(domainProject, serviceID, "")),
 		client.WithPrefix()))
+	opts = append(opts, client.OpDel(
+		client.WithStrKey(util.StringJoin([]string{path.GetServiceSchemaRevisionRootKey(domainProject), serviceID, ""}, "/")),
+		client.WithPrefix()))
 
 	//删除tags
 	opts = append(opts, client.OpDel(
@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// BatchRegisterInstances writes all the new instances of the batch in one transaction,
+// the instances with the existing instance id are reused as RegisterInstance does
+func (ds *DataSource) BatchRegisterInstances(ctx context.Context, request *proto.BatchRegisterInstancesRequest) (
+	*proto.BatchInstancesResponse, error) {
+	remoteIP := util.GetIPFromContext(ctx)
+	domainProject := util.ParseDomainProject(ctx)
+
+	var (
+		results   = make([]*pb.InstanceHbRst, 0, len(request.Instances))
+		existFlag = make(map[string]bool, len(request.Instances))
+		services  = make(map[string]bool)
+		pending   []*pb.InstanceHbRst
+		leaseIDs  []int64
+		opts      []client.PluginOp
+		cmps      []client.CompareOp
+	)
+	for _, instance := range request.Instances {
+		result := &pb.InstanceHbRst{ServiceId: instance.ServiceId, InstanceId: instance.InstanceId}
+		results = append(results, result)
+
+		if len(instance.InstanceId) > 0 {
+			if _, ok := existFlag[instance.ServiceId+instance.InstanceId]; ok {
+				result.ErrMessage = "Duplicate instance in request."
+				continue
+			}
+			existFlag[instance.ServiceId+instance.InstanceId] = true
+
+			_, _, err := serviceUtil.HeartbeatUtil(ctx, domainProject, instance.ServiceId, instance.InstanceId)
+			if err == nil {
+				continue
+			}
+			if err.Code != pb.ErrInstanceNotExists {
+				result.ErrMessage = err.Error()
+				continue
+			}
+		}
+
+		if err := preProcessRegisterInstance(ctx, instance); err != nil {
+			result.ErrMessage = err.Error()
+			continue
+		}
+		result.InstanceId = instance.InstanceId
+
+		data, err := json.Marshal(instance)
+		if err != nil {
+			result.ErrMessage = err.Error()
+			continue
+		}
+		ttl := int64(instance.HealthCheck.Interval * (instance.HealthCheck.Times + 1))
+		if ds.InstanceTTL > 0 {
+			ttl = ds.InstanceTTL
+		}
+		leaseID, err := client.Instance().LeaseGrant(ctx, ttl)
+		if err != nil {
+			result.ErrMessage = err.Error()
+			continue
+		}
+		leaseIDs = append(leaseIDs, leaseID)
+
+		key := path.GenerateInstanceKey(domainProject, instance.ServiceId, instance.InstanceId)
+		hbKey := path.GenerateInstanceLeaseKey(domainProject, instance.ServiceId, instance.InstanceId)
+		opts = append(opts,
+			client.OpPut(client.WithStrKey(key), client.WithValue(data), client.WithLease(leaseID)),
+			client.OpPut(client.WithStrKey(hbKey), client.WithStrValue(fmt.Sprintf("%d", leaseID)),
+				client.WithLease(leaseID)))
+		if !services[instance.ServiceId] {
+			services[instance.ServiceId] = true
+			cmps = append(cmps, client.OpCmp(
+				client.CmpVer(util.StringToBytesWithNoCopy(path.GenerateServiceKey(domainProject, instance.ServiceId))),
+				client.CmpNotEqual, 0))
+		}
+		pending = append(pending, result)
+	}
+
+	if len(opts) > 0 {
+		resp, err := client.Instance().TxnWithCmp(ctx, opts, cmps, nil)
+		if err != nil || !resp.Succeeded {
+			message := "Service does not exist."
+			if err != nil {
+				message = err.Error()
+			}
+			for _, result := range pending {
+				result.ErrMessage = message
+			}
+			revokeLeases(ctx, leaseIDs)
+			log.Errorf(err, "batch register instances[%d] failed, operator %s: %s", len(pending), remoteIP, message)
+			if err != nil {
+				return &proto.BatchInstancesResponse{
+					Response:  pb.CreateResponse(pb.ErrUnavailableBackend, message),
+					Instances: results,
+				}, err
+			}
+		}
+	}
+	return datasource.ToBatchInstancesResponse(results, "register", remoteIP), nil
+}
+
+// BatchUnregisterInstances deletes all the instances of the batch in one transaction
+func (ds *DataSource) BatchUnregisterInstances(ctx context.Context, request *proto.BatchUnregisterInstancesRequest) (
+	*proto.BatchInstancesResponse, error) {
+	remoteIP := util.GetIPFromContext(ctx)
+	domainProject := util.ParseDomainProject(ctx)
+
+	var (
+		results   = make([]*pb.InstanceHbRst, 0, len(request.Instances))
+		existFlag = make(map[string]bool, len(request.Instances))
+		pending   []*pb.InstanceHbRst
+		leaseIDs  []int64
+		opts      []client.PluginOp
+	)
+	for _, element := range request.Instances {
+		result := &pb.InstanceHbRst{ServiceId: element.ServiceId, InstanceId: element.InstanceId}
+		results = append(results, result)
+
+		if _, ok := existFlag[element.ServiceId+element.InstanceId]; ok {
+			result.ErrMessage = "Duplicate instance in request."
+			continue
+		}
+		existFlag[element.ServiceId+element.InstanceId] = true
+
+		leaseID, err := serviceUtil.GetLeaseID(ctx, domainProject, element.ServiceId, element.InstanceId)
+		if err != nil {
+			result.ErrMessage = err.Error()
+			continue
+		}
+		if leaseID == -1 {
+			result.ErrMessage = "Instance's leaseId not exist."
+			continue
+		}
+		leaseIDs = append(leaseIDs, leaseID)
+		opts = append(opts,
+			client.OpDel(client.WithStrKey(path.GenerateInstanceKey(domainProject, element.ServiceId, element.InstanceId))),
+			client.OpDel(client.WithStrKey(path.GenerateInstanceLeaseKey(domainProject, element.ServiceId, element.InstanceId))))
+		pending = append(pending, result)
+	}
+
+	if len(opts) > 0 {
+		if _, err := client.Instance().Txn(ctx, opts); err != nil {
+			for _, result := range pending {
+				result.ErrMessage = err.Error()
+			}
+			log.Errorf(err, "batch unregister instances[%d] failed, operator %s", len(pending), remoteIP)
+			return &proto.BatchInstancesResponse{
+				Response:  pb.CreateResponse(pb.ErrUnavailableBackend, err.Error()),
+				Instances: results,
+			}, err
+		}
+		// the keys are deleted, release the leases
+		revokeLeases(ctx, leaseIDs)
+	}
+	return datasource.ToBatchInstancesResponse(results, "unregister", remoteIP), nil
+}
+
+func revokeLeases(ctx context.Context, leaseIDs []int64) {
+	for _, leaseID := range leaseIDs {
+		if err := client.Instance().LeaseRevoke(ctx, leaseID); err != nil {
+			log.Errorf(err, "revoke lease[%d] failed", leaseID)
+		}
+	}
+}
@@ -15,7 +15,7 @@
  * limitations under the License.
  */
 
-package broker
+package path
 
 import (
 	"strconv"
@@ -30,7 +30,10 @@ const (
 	BrokerPactKey             = "pact"
 	BrokerPactVersionKey      = "pact-version"
 	BrokerPactTagKey          = "pact-tag"
+	BrokerDeploymentKey       = "deployment"
 	BrokerPactVerificationKey = "verification"
+	BrokerWebhookKey          = "webhook"
+	BrokerWebhookExecutionKey = "webhook-execution"
 	BrokerPactLatest          = "latest"
 )
 
@@ -135,10 +138,29 @@ func GetBrokerTagKey(tenant string) string {
 }
 
 //GenerateBrokerTagKey returns the broker tag key
-func GenerateBrokerTagKey(tenant string, versionID int32) string {
+func GenerateBrokerTagKey(tenant string, versionID int32, name string) string {
 	return util.StringJoin([]string{
 		GetBrokerTagKey(tenant),
 		strconv.Itoa(int(versionID)),
+		name,
+	}, "/")
+}
+
+//GetBrokerDeploymentKey returns the deployment root key
+func GetBrokerDeploymentKey(tenant string) string {
+	return util.StringJoin([]string{
+		GetBrokerRootKey(),
+		BrokerDeploymentKey,
+		tenant,
+	}, "/")
+}
+
+//GenerateBrokerDeploymentKey returns the key of the participant deployment in the environment
+func GenerateBrokerDeploymentKey(tenant string, environment string, participantID int32) string {
+	return util.StringJoin([]string{
+		GetBrokerDeploymentKey(tenant),
+		environment,
+		strconv.Itoa(int(participantID)),
 	}, "/")
 }
 
@@ -160,42 +182,45 @@ func GenerateBrokerVerificationKey(tenant string, pactVersionID int32, number in
 	}, "/")
 }
 
-//GetBrokerLatestParticipantIDKey returns the latest participant ID
-func GetBrokerLatestParticipantIDKey() string {
+//GetBrokerWebhookKey returns the webhook root key
+func GetBrokerWebhookKey(tenant string) string {
 	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerParticipantKey,
+		GetBrokerRootKey(),
+		BrokerWebhookKey,
+		tenant,
 	}, "/")
 }
 
-//GetBrokerLatestVersionIDKey returns latest version ID
-func GetBrokerLatestVersionIDKey() string {
+//GenerateBrokerWebhookKey returns the webhook key
+func GenerateBrokerWebhookKey(tenant string, webhookID string) string {
 	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerVersionKey,
+		GetBrokerWebhookKey(tenant),
+		webhookID,
 	}, "/")
 }
 
-//GetBrokerLatestPactIDKey returns latest pact ID
-func GetBrokerLatestPactIDKey() string {
+//GetBrokerWebhookExecutionKey returns the webhook execution root key
+func GetBrokerWebhookExecutionKey(tenant string) string {
 	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerPactKey,
+		GetBrokerRootKey(),
+		BrokerWebhookExecutionKey,
+		tenant,
 	}, "/")
 }
 
-//GetBrokerLatestPactVersionIDKey returns lated pact version ID
-func GetBrokerLatestPactVersionIDKey() string {
+//GenerateBrokerWebhookExecutionKey returns the webhook execution key
+func GenerateBrokerWebhookExecutionKey(tenant string, webhookID string, executionID string) string {
 	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerPactVersionKey,
+		GetBrokerWebhookExecutionKey(tenant),
+		webhookID,
+		executionID,
 	}, "/")
 }
 
-//GetBrokerLatestVerificationIDKey returns the lastest verification ID
-func GetBrokerLatestVerificationIDKey() string {
+//GetBrokerLatestIDKey returns the latest ID key of the kind of broker data
+func GetBrokerLatestIDKey(kind string) string {
 	return util.StringJoin([]string{
 		GetBrokerLatestKey("default"),
-		BrokerPactVerificationKey,
+		kind,
 	}, "/")
 }
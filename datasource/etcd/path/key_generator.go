@@ -39,10 +39,12 @@ const (
 	RegistryTagKey           = "tags"
 	RegistrySchemaKey        = "schemas"
 	RegistrySchemaSummaryKey = "schema-sum"
+	RegistrySchemaRevKey     = "schema-rev"
 	RegistryLeaseKey         = "leases"
 	RegistryDependencyKey    = "deps"
 	RegistryDepsRuleKey      = "dep-rules"
 	RegistryDepsQueueKey     = "dep-queue"
+	RegistryDepsSeenKey      = "dep-seen"
 	RegistryMetricsKey       = "metrics"
 	DepsQueueUUID            = "0"
 	DepsConsumer             = "c"
@@ -269,6 +271,24 @@ func GetServiceSchemaSummaryRootKey(domainProject string) string {
 	}, SPLIT)
 }
 
+func GenerateServiceSchemaRevisionKey(domainProject string, serviceID string, schemaID string, revision string) string {
+	return util.StringJoin([]string{
+		GetServiceSchemaRevisionRootKey(domainProject),
+		serviceID,
+		schemaID,
+		revision,
+	}, SPLIT)
+}
+
+func GetServiceSchemaRevisionRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryServiceKey,
+		RegistrySchemaRevKey,
+		domainProject,
+	}, SPLIT)
+}
+
 func GenerateInstanceKey(domainProject string, serviceID string, instanceID string) string {
 	return util.StringJoin([]string{
 		GetInstanceRootKey(domainProject),
@@ -344,6 +364,25 @@ func GenerateConsumerDependencyQueueKey(domainProject, consumerID, uuid string)
 	}, SPLIT)
 }
 
+func GetServiceDependencySeenRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryServiceKey,
+		RegistryDepsSeenKey,
+		domainProject,
+	}, SPLIT)
+}
+
+func GenerateServiceDependencySeenKey(domainProject, consumerID string, provider *discovery.MicroServiceKey) string {
+	return util.StringJoin([]string{
+		GetServiceDependencySeenRootKey(domainProject),
+		consumerID,
+		provider.Environment,
+		provider.AppId,
+		provider.ServiceName,
+	}, SPLIT)
+}
+
 func GetServiceDependencyRootKey(domainProject string) string {
 	return util.StringJoin([]string{
 		GetRootKey(),
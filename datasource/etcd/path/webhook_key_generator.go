@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package path
+
+import (
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+const (
+	RegistryWebhookKey         = "webhooks"
+	RegistryWebhookDeliveryKey = "webhook-deliveries"
+)
+
+//GetWebhookRootKey returns the webhook root key of the tenant
+func GetWebhookRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryWebhookKey,
+		domainProject,
+	}, SPLIT)
+}
+
+//GenerateWebhookKey returns the webhook key
+func GenerateWebhookKey(domainProject, webhookID string) string {
+	return util.StringJoin([]string{
+		GetWebhookRootKey(domainProject),
+		webhookID,
+	}, SPLIT)
+}
+
+//GetWebhookDeliveryRootKey returns the delivery root key of the tenant
+func GetWebhookDeliveryRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryWebhookDeliveryKey,
+		domainProject,
+	}, SPLIT)
+}
+
+//GenerateWebhookDeliveryKey returns the delivery key
+func GenerateWebhookDeliveryKey(domainProject, webhookID, deliveryID string) string {
+	return util.StringJoin([]string{
+		GetWebhookDeliveryRootKey(domainProject),
+		webhookID,
+		deliveryID,
+	}, SPLIT)
+}
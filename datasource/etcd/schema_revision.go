@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+func (ds *DataSource) AddSchemaRevision(ctx context.Context, serviceID, schemaID string,
+	revision *proto.SchemaRevision) error {
+	if ds.SchemaHistorySize <= 0 {
+		return nil
+	}
+	domainProject := util.ParseDomainProject(ctx)
+	revisions, err := listSchemaRevisions(ctx, domainProject, serviceID, schemaID)
+	if err != nil {
+		return err
+	}
+	if len(revisions) > 0 && revisions[0].Hash == revision.Hash && revisions[0].Summary == revision.Summary {
+		return nil
+	}
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[0].Revision + 1
+	}
+	data, err := json.Marshal(revision)
+	if err != nil {
+		return err
+	}
+
+	key := path.GenerateServiceSchemaRevisionKey(domainProject, serviceID, schemaID,
+		strconv.FormatInt(revision.Revision, 10))
+	opts := []client.PluginOp{client.OpPut(client.WithStrKey(key), client.WithValue(data))}
+	// prune the oldest revisions out of the history size
+	for i := ds.SchemaHistorySize - 1; i < len(revisions); i++ {
+		opts = append(opts, client.OpDel(client.WithStrKey(path.GenerateServiceSchemaRevisionKey(
+			domainProject, serviceID, schemaID, strconv.FormatInt(revisions[i].Revision, 10)))))
+	}
+	resp, err := client.Instance().TxnWithCmp(ctx, opts,
+		[]client.CompareOp{client.OpCmp(client.CmpVer(util.StringToBytesWithNoCopy(key)), client.CmpEqual, 0)},
+		nil)
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("schema[%s/%s] revision %d already exists", serviceID, schemaID, revision.Revision)
+	}
+	return nil
+}
+
+func (ds *DataSource) ListSchemaRevisions(ctx context.Context, serviceID, schemaID string) ([]*proto.SchemaRevision, error) {
+	revisions, err := listSchemaRevisions(ctx, util.ParseDomainProject(ctx), serviceID, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		revision.Schema = ""
+	}
+	return revisions, nil
+}
+
+func (ds *DataSource) GetSchemaRevision(ctx context.Context, serviceID, schemaID string,
+	revision int64) (*proto.SchemaRevision, error) {
+	key := path.GenerateServiceSchemaRevisionKey(util.ParseDomainProject(ctx), serviceID, schemaID,
+		strconv.FormatInt(revision, 10))
+	resp, err := client.Instance().Do(ctx, client.GET, client.WithStrKey(key))
+	if err != nil {
+		log.Errorf(err, "get schema[%s/%s] revision %d failed", serviceID, schemaID, revision)
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, datasource.ErrSchemaRevisionNotExists
+	}
+	var result proto.SchemaRevision
+	if err := json.Unmarshal(resp.Kvs[0].Value, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func listSchemaRevisions(ctx context.Context, domainProject, serviceID, schemaID string) ([]*proto.SchemaRevision, error) {
+	kvs, _, err := client.List(ctx, path.GenerateServiceSchemaRevisionKey(domainProject, serviceID, schemaID, ""))
+	if err != nil {
+		log.Errorf(err, "list schema[%s/%s] revisions failed", serviceID, schemaID)
+		return nil, err
+	}
+	revisions := make([]*proto.SchemaRevision, 0, len(kvs))
+	for _, kv := range kvs {
+		var revision proto.SchemaRevision
+		if err := json.Unmarshal(kv.Value, &revision); err != nil {
+			log.Errorf(err, "unmarshal schema revision %s failed", kv.Key)
+			continue
+		}
+		revisions = append(revisions, &revision)
+	}
+	datasource.SortSchemaRevisions(revisions)
+	return revisions, nil
+}
@@ -45,6 +45,10 @@ func (ds *DataSource) DumpCache(ctx context.Context) *dump.Cache {
 	return &cache
 }
 
+func (ds *DataSource) DumpSelfPreservation(ctx context.Context) *dump.SelfPreservation {
+	return kv.InstanceDeferHandler().Dump()
+}
+
 func setValue(e sd.Adaptor, setter dump.Setter) {
 	e.Cache().ForEach(func(k string, kv *sd.KeyValue) (next bool) {
 		setter.SetValue(&dump.KV{
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/pkg/validate"
 )
 
 // DependencyRelationFilterOpt contains SameDomainProject and NonSelf flag
@@ -340,7 +341,7 @@ func (dr *DependencyRelation) GetConsumerOfSameServiceNameAndAppID(provider *pb.
 			}
 
 		} else {
-			if !VersionMatchRule(providerVersion, providerVersionRule) {
+			if !validate.VersionMatchRule(providerVersion, providerVersionRule) {
 				continue
 			}
 		}
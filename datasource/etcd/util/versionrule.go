@@ -166,17 +166,3 @@ func ParseVersionRule(versionRule string) func(kvs []*sd.KeyValue) []string {
 		return nil
 	}
 }
-
-func VersionMatchRule(version string, versionRule string) bool {
-	match := ParseVersionRule(versionRule)
-	if match == nil {
-		return version == versionRule
-	}
-
-	return len(match([]*sd.KeyValue{
-		{
-			Key:   util.StringToBytesWithNoCopy("/" + version),
-			Value: "",
-		},
-	})) > 0
-}
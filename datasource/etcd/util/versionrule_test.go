@@ -287,8 +287,6 @@ func TestVersionRule(t *testing.T) {
 		assert.Equal(t, true, reflect.ValueOf(rule).IsNil())
 		rule = ParseVersionRule("abc")
 		assert.Equal(t, true, reflect.ValueOf(rule).IsNil())
-		assert.Equal(t, true, VersionMatchRule("1.0", "1.0"))
-		assert.Equal(t, false, VersionMatchRule("1.0", "1.2"))
 	})
 
 	log.Info("parse")
@@ -315,24 +313,4 @@ func TestVersionRule(t *testing.T) {
 		assert.Equal(t, "10", results[0])
 		assert.Equal(t, "6", results[4])
 	})
-
-	log.Info("version match rule")
-
-	t.Run("latest", func(t *testing.T) {
-		assert.Equal(t, true, VersionMatchRule("1.0", "latest"))
-	})
-
-	t.Run("range ver in [1.4, 1.8]", func(t *testing.T) {
-		assert.Equal(t, true, VersionMatchRule("1.4", "1.4-1.8"))
-		assert.Equal(t, true, VersionMatchRule("1.6", "1.4-1.8"))
-		assert.Equal(t, false, VersionMatchRule("1.8", "1.4-1.8"))
-		assert.Equal(t, false, VersionMatchRule("1.0", "1.4-1.8"))
-		assert.Equal(t, false, VersionMatchRule("1.9", "1.4-1.8"))
-	})
-
-	t.Run("atLess ver >= 1.6", func(t *testing.T) {
-		assert.Equal(t, true, VersionMatchRule("1.6", "1.6+"))
-		assert.Equal(t, true, VersionMatchRule("1.9", "1.6+"))
-		assert.Equal(t, false, VersionMatchRule("1.0", "1.6+"))
-	})
 }
@@ -26,8 +26,8 @@ import (
 	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
 	"github.com/apache/servicecomb-service-center/datasource/etcd/mux"
 	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
-	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/validate"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/version"
 )
@@ -92,7 +92,7 @@ func (ds *DataSource) needUpgrade(ctx context.Context) bool {
 		return false
 	}
 
-	update := !serviceUtil.VersionMatchRule(config.Server.Version,
+	update := !validate.VersionMatchRule(config.Server.Version,
 		fmt.Sprintf("%s+", version.Ver().Version))
 	if !update && version.Ver().Version != config.Server.Version {
 		log.Warnf(
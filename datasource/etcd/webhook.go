@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+func (ds *DataSource) GetWebhookSubscription(ctx context.Context, domainProject,
+	webhookID string) (*webhookpb.Webhook, error) {
+	webhook := &webhookpb.Webhook{}
+	ok, err := getBrokerData(ctx, kv.Store().Webhook(), path.GenerateWebhookKey(domainProject, webhookID), webhook)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (ds *DataSource) ListWebhookSubscriptions(ctx context.Context, domainProject string) ([]*webhookpb.Webhook, error) {
+	key := util.StringJoin([]string{path.GetWebhookRootKey(domainProject), ""}, path.SPLIT)
+	var webhooks []*webhookpb.Webhook
+	err := listBrokerData(ctx, kv.Store().Webhook(), key, func(data []byte) error {
+		webhook := &webhookpb.Webhook{}
+		if err := json.Unmarshal(data, webhook); err != nil {
+			return err
+		}
+		webhooks = append(webhooks, webhook)
+		return nil
+	})
+	return webhooks, err
+}
+
+func (ds *DataSource) PutWebhookSubscription(ctx context.Context, domainProject string,
+	webhook *webhookpb.Webhook) error {
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+	return client.Put(ctx, path.GenerateWebhookKey(domainProject, webhook.Id), string(body))
+}
+
+func (ds *DataSource) DeleteWebhookSubscription(ctx context.Context, domainProject, webhookID string) error {
+	_, err := client.Delete(ctx, path.GenerateWebhookKey(domainProject, webhookID))
+	if err != nil {
+		return err
+	}
+	key := util.StringJoin([]string{path.GetWebhookDeliveryRootKey(domainProject), webhookID, ""}, path.SPLIT)
+	_, err = client.Instance().Do(ctx, client.DEL, client.WithStrKey(key), client.WithPrefix())
+	return err
+}
+
+func (ds *DataSource) ListWebhookDeliveries(ctx context.Context, domainProject,
+	webhookID string) ([]*webhookpb.Delivery, error) {
+	key := util.StringJoin([]string{path.GetWebhookDeliveryRootKey(domainProject), webhookID, ""}, path.SPLIT)
+	var deliveries []*webhookpb.Delivery
+	err := listBrokerData(ctx, kv.Store().WebhookDelivery(), key, func(data []byte) error {
+		delivery := &webhookpb.Delivery{}
+		if err := json.Unmarshal(data, delivery); err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+		return nil
+	})
+	return deliveries, err
+}
+
+func (ds *DataSource) CreateWebhookDelivery(ctx context.Context, domainProject string,
+	delivery *webhookpb.Delivery) (bool, error) {
+	body, err := json.Marshal(delivery)
+	if err != nil {
+		return false, err
+	}
+	key := path.GenerateWebhookDeliveryKey(domainProject, delivery.WebhookId, delivery.Id)
+	return client.Instance().PutNoOverride(ctx, client.WithStrKey(key), client.WithValue(body))
+}
+
+func (ds *DataSource) PutWebhookDelivery(ctx context.Context, domainProject string,
+	delivery *webhookpb.Delivery) error {
+	body, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	key := path.GenerateWebhookDeliveryKey(domainProject, delivery.WebhookId, delivery.Id)
+	return client.PutBytes(ctx, key, body)
+}
+
+func (ds *DataSource) DeleteWebhookDelivery(ctx context.Context, domainProject, webhookID, deliveryID string) error {
+	_, err := client.Delete(ctx, path.GenerateWebhookDeliveryKey(domainProject, webhookID, deliveryID))
+	return err
+}
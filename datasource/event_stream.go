@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	pb "github.com/go-chassis/cari/discovery"
+)
+
+// the registry resources in the change feed
+const (
+	ResourceService    = "service"
+	ResourceInstance   = "instance"
+	ResourceSchema     = "schema"
+	ResourceTag        = "tag"
+	ResourceRule       = "rule"
+	ResourceDependency = "dependency"
+)
+
+// ErrRevisionCompacted means the events after the revision are no longer kept
+// by the backend, the client should list the resources again and resume from now
+var ErrRevisionCompacted = errors.New("the revision has been compacted")
+
+// RegistryEvent is a change of the registry resource
+type RegistryEvent struct {
+	// Revision increases with the changes of the backend,
+	// the changes in one transaction share the same revision
+	Revision int64        `json:"revision"`
+	Action   pb.EventType `json:"action"`
+	Resource string       `json:"resource"`
+	// ServiceID is the owner of the resource, empty for the dependencies
+	ServiceID string `json:"serviceId,omitempty"`
+	// ID identifies the resource in the service, e.g. the instance id, the schema id,
+	// the rule id, or the type and the service key of the dependency rule
+	ID string `json:"id,omitempty"`
+	// Value is the resource after the change, empty if deleted
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// RegistryEventFunc handles the event, stops watching if it returns an error
+type RegistryEventFunc func(evt *RegistryEvent) error
+
+// EventStreamManager contains the APIs of the registry change feed
+type EventStreamManager interface {
+	// WatchRegistryEvents calls f with the events of the registry resources in the
+	// domain project after the revision rev, or from now on if rev is 0. It blocks
+	// until ctx is done, f or the watching fails, returns ErrRevisionCompacted
+	// if the events after rev can not be replayed.
+	WatchRegistryEvents(ctx context.Context, domainProject string, rev int64, f RegistryEventFunc) error
+}
@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/validate"
+	pb "github.com/go-chassis/cari/discovery"
+)
+
+const impactDescriptionLimit = 10
+
+// SearchImpact returns the direct and transitive consumers of the services
+// specified by request, used to evaluate the impact of retiring them
+func SearchImpact(ctx context.Context, request *proto.ImpactRequest) (*proto.ImpactResponse, error) {
+	roots, err := impactRoots(ctx, request)
+	if err != nil {
+		return &proto.ImpactResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+		}, err
+	}
+	if len(roots) == 0 {
+		return &proto.ImpactResponse{
+			Response: pb.CreateResponse(pb.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	visited := make(map[string]bool, len(roots))
+	serviceIDs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		visited[root.ServiceId] = true
+		serviceIDs = append(serviceIDs, root.ServiceId)
+	}
+
+	var consumers []*proto.ImpactedConsumer
+	providers := roots
+	for depth := 1; len(providers) > 0; depth++ {
+		var next []*pb.MicroService
+		for _, provider := range providers {
+			resp, err := Instance().SearchProviderDependency(ctx, &pb.GetDependenciesRequest{
+				ServiceId: provider.ServiceId,
+				NoSelf:    true,
+			})
+			if err != nil {
+				log.Error(fmt.Sprintf("search provider[%s]'s consumers failed", provider.ServiceId), err)
+				return &proto.ImpactResponse{
+					Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+				}, err
+			}
+			for _, consumer := range resp.Consumers {
+				if visited[consumer.ServiceId] {
+					continue
+				}
+				visited[consumer.ServiceId] = true
+				impacted, err := toImpactedConsumer(ctx, consumer, provider, depth)
+				if err != nil {
+					return &proto.ImpactResponse{
+						Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+					}, err
+				}
+				consumers = append(consumers, impacted)
+				next = append(next, consumer)
+			}
+		}
+		providers = next
+	}
+
+	return &proto.ImpactResponse{
+		Response:   pb.CreateResponse(pb.ResponseSuccess, "Get impacted consumers successfully."),
+		ServiceIds: serviceIDs,
+		Consumers:  consumers,
+	}, nil
+}
+
+// DependedOnConsumerMessage returns the message of refusing to delete the
+// service, with the consumers relying on it
+func DependedOnConsumerMessage(ctx context.Context, serviceID string) string {
+	msg := "Can not delete this service, other service rely it."
+	resp, err := SearchImpact(ctx, &proto.ImpactRequest{ServiceId: serviceID})
+	if err != nil || len(resp.Consumers) == 0 {
+		return msg
+	}
+	return msg + " Impacted consumers: " + impactDescription(resp.Consumers, impactDescriptionLimit)
+}
+
+func impactDescription(consumers []*proto.ImpactedConsumer, limit int) string {
+	descriptions := make([]string, 0, limit+1)
+	for i, consumer := range consumers {
+		if i == limit {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(consumers)-limit))
+			break
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s/%s/%s(rule: %s, up instances: %d)",
+			consumer.AppId, consumer.ServiceName, consumer.Version, consumer.VersionRule, consumer.UpInstances))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func impactRoots(ctx context.Context, request *proto.ImpactRequest) ([]*pb.MicroService, error) {
+	if len(request.ServiceId) > 0 {
+		resp, err := Instance().GetService(ctx, &pb.GetServiceRequest{ServiceId: request.ServiceId})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Service == nil {
+			return nil, nil
+		}
+		return []*pb.MicroService{resp.Service}, nil
+	}
+
+	resp, err := Instance().GetServices(ctx, &pb.GetServicesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		roots  []*pb.MicroService
+		latest *pb.MicroService
+	)
+	for _, service := range resp.Services {
+		if service.Environment != request.Environment || service.AppId != request.AppId ||
+			service.ServiceName != request.ServiceName {
+			continue
+		}
+		if request.VersionRule != "latest" {
+			if validate.VersionMatchRule(service.Version, request.VersionRule) {
+				roots = append(roots, service)
+			}
+			continue
+		}
+		if latest == nil || versionGreater(service.Version, latest.Version) {
+			latest = service
+		}
+	}
+	if latest != nil {
+		roots = append(roots, latest)
+	}
+	return roots, nil
+}
+
+func toImpactedConsumer(ctx context.Context, consumer, provider *pb.MicroService, depth int) (*proto.ImpactedConsumer, error) {
+	impacted := &proto.ImpactedConsumer{
+		ServiceId:   consumer.ServiceId,
+		Environment: consumer.Environment,
+		AppId:       consumer.AppId,
+		ServiceName: consumer.ServiceName,
+		Version:     consumer.Version,
+		ProviderId:  provider.ServiceId,
+		Depth:       depth,
+	}
+
+	rules, err := Instance().ListProviderRules(ctx, consumer)
+	if err != nil {
+		log.Error(fmt.Sprintf("get consumer[%s]'s provider rules failed", consumer.ServiceId), err)
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.ServiceName == "*" ||
+			(rule.AppId == provider.AppId && rule.ServiceName == provider.ServiceName) {
+			impacted.VersionRule = rule.Version
+			break
+		}
+	}
+
+	resp, err := Instance().GetInstances(ctx, &pb.GetInstancesRequest{ProviderServiceId: consumer.ServiceId})
+	if err != nil {
+		log.Error(fmt.Sprintf("get consumer[%s]'s instances failed", consumer.ServiceId), err)
+		return nil, err
+	}
+	for _, instance := range resp.Instances {
+		if instance.Status == pb.MSI_UP {
+			impacted.UpInstances++
+		}
+	}
+	return impacted, nil
+}
+
+// versionGreater returns true if a is greater than b, the invalid version is the least
+func versionGreater(a, b string) bool {
+	x, err := validate.VersionToInt64(a)
+	if err != nil {
+		return false
+	}
+	y, err := validate.VersionToInt64(b)
+	if err != nil {
+		return true
+	}
+	return x > y
+}
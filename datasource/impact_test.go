@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchImpact(t *testing.T) {
+	var (
+		providerID  string
+		consumerID1 string
+		consumerID2 string
+	)
+	register := func(name string) string {
+		resp, err := datasource.Instance().RegisterService(depGetContext(), &pb.CreateServiceRequest{
+			Service: &pb.MicroService{
+				AppId:       "impact_group",
+				ServiceName: name,
+				Version:     "1.0.0",
+				Level:       "FRONT",
+				Status:      pb.MS_UP,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		return resp.ServiceId
+	}
+
+	t.Run("create services and dependencies, should be passed", func(t *testing.T) {
+		providerID = register("impact_provider")
+		consumerID1 = register("impact_consumer")
+		consumerID2 = register("impact_consumer_of_consumer")
+
+		respInst, err := datasource.Instance().RegisterInstance(depGetContext(), &pb.RegisterInstanceRequest{
+			Instance: &pb.MicroServiceInstance{
+				ServiceId: consumerID1,
+				Endpoints: []string{"impact:127.0.0.1:8080"},
+				HostName:  "UT-HOST",
+				Status:    pb.MSI_UP,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, respInst.Response.GetCode())
+
+		resp, err := datasource.Instance().AddOrUpdateDependencies(depGetContext(), []*pb.ConsumerDependency{
+			{
+				Consumer: &pb.MicroServiceKey{AppId: "impact_group", ServiceName: "impact_consumer", Version: "1.0.0"},
+				Providers: []*pb.MicroServiceKey{
+					{AppId: "impact_group", ServiceName: "impact_provider", Version: "latest"},
+				},
+			},
+			{
+				Consumer: &pb.MicroServiceKey{AppId: "impact_group", ServiceName: "impact_consumer_of_consumer", Version: "1.0.0"},
+				Providers: []*pb.MicroServiceKey{
+					{AppId: "impact_group", ServiceName: "impact_consumer", Version: "1.0.0+"},
+				},
+			},
+		}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.GetCode())
+
+		err = datasource.Instance().DependencyHandle(getContext())
+		assert.NoError(t, err)
+	})
+
+	t.Run("search impact by service id, should return transitive consumers", func(t *testing.T) {
+		resp, err := datasource.SearchImpact(depGetContext(), &proto.ImpactRequest{ServiceId: providerID})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		assert.Equal(t, []string{providerID}, resp.ServiceIds)
+		assert.Equal(t, 2, len(resp.Consumers))
+
+		assert.Equal(t, consumerID1, resp.Consumers[0].ServiceId)
+		assert.Equal(t, providerID, resp.Consumers[0].ProviderId)
+		assert.Equal(t, 1, resp.Consumers[0].Depth)
+		assert.Equal(t, "latest", resp.Consumers[0].VersionRule)
+		assert.Equal(t, 1, resp.Consumers[0].UpInstances)
+
+		assert.Equal(t, consumerID2, resp.Consumers[1].ServiceId)
+		assert.Equal(t, consumerID1, resp.Consumers[1].ProviderId)
+		assert.Equal(t, 2, resp.Consumers[1].Depth)
+		assert.Equal(t, "1.0.0+", resp.Consumers[1].VersionRule)
+		assert.Equal(t, 0, resp.Consumers[1].UpInstances)
+	})
+
+	t.Run("search impact by version rule, should return transitive consumers", func(t *testing.T) {
+		resp, err := datasource.SearchImpact(depGetContext(), &proto.ImpactRequest{
+			AppId:       "impact_group",
+			ServiceName: "impact_provider",
+			VersionRule: "1.0.0-2.0.0",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		assert.Equal(t, []string{providerID}, resp.ServiceIds)
+		assert.Equal(t, 2, len(resp.Consumers))
+
+		resp, err = datasource.SearchImpact(depGetContext(), &proto.ImpactRequest{
+			AppId:       "impact_group",
+			ServiceName: "impact_provider",
+			VersionRule: "2.0.0+",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ErrServiceNotExists, resp.Response.GetCode())
+	})
+
+	t.Run("delete provider without force, should report the impacted consumers", func(t *testing.T) {
+		resp, err := datasource.Instance().UnregisterService(depGetContext(), &pb.DeleteServiceRequest{
+			ServiceId: providerID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ErrDependedOnConsumer, resp.Response.GetCode())
+		assert.Contains(t, resp.Response.GetMessage(), "impact_group/impact_consumer/1.0.0(rule: latest, up instances: 1)")
+	})
+
+	t.Run("delete services, should be passed", func(t *testing.T) {
+		for _, serviceID := range []string{consumerID2, consumerID1, providerID} {
+			resp, err := datasource.Instance().UnregisterService(depGetContext(), &pb.DeleteServiceRequest{
+				ServiceId: serviceID, Force: true,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+		}
+	})
+}
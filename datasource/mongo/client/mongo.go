@@ -197,7 +197,8 @@ func (mc *MongoClient) newClient(ctx context.Context) (err error) {
 		log.Info("cipher fallback: " + err.Error())
 		uri = mc.dbconfig.URI
 	}
-	clientOptions := []*options.ClientOptions{options.Client().ApplyURI(uri)}
+	slowThreshold := config.GetDuration("registry.mongo.slowThreshold", DefaultSlowThreshold)
+	clientOptions := []*options.ClientOptions{options.Client().ApplyURI(uri).SetMonitor(NewCommandMonitor(slowThreshold))}
 	if mc.dbconfig.SSLEnabled {
 		if mc.dbconfig.RootCA == "" {
 			err = ErrRootCAMissing
@@ -299,6 +300,11 @@ func (mc *MongoClient) Watch(ctx context.Context, Table string, pipeline interfa
 	return mc.db.Collection(Table).Watch(ctx, pipeline, opts...)
 }
 
+// WatchDB opens a change stream on all the collections of the database
+func (mc *MongoClient) WatchDB(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return mc.db.Watch(ctx, pipeline, opts...)
+}
+
 func (mc *MongoClient) StartSession(ctx context.Context) (mongo.Session, error) {
 	return mc.client.StartSession()
 }
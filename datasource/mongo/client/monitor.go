@@ -0,0 +1,185 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/metrics"
+	"github.com/apache/servicecomb-service-center/server/plugin/tracing"
+)
+
+const (
+	DefaultSlowThreshold = time.Second
+	maxFilterSummary     = 512
+	redacted             = "?"
+)
+
+// filterFields are the fields of the commands which contain the query conditions
+var filterFields = map[string]string{
+	"find":          "filter",
+	"count":         "query",
+	"distinct":      "query",
+	"findAndModify": "query",
+	"delete":        "deletes",
+	"update":        "updates",
+	"aggregate":     "pipeline",
+}
+
+// command is a running command recorded when it started
+type command struct {
+	span       tracing.Span
+	collection string
+	filter     bson.RawValue
+}
+
+// CommandMonitor records the client spans, the latency metrics of the mongo
+// commands and logs the commands slower than the threshold
+type CommandMonitor struct {
+	// SlowThreshold is the min duration of the slow commands, 0 disables the slow log
+	SlowThreshold time.Duration
+
+	commands sync.Map // request id -> *command
+}
+
+func NewCommandMonitor(slowThreshold time.Duration) *event.CommandMonitor {
+	m := &CommandMonitor{SlowThreshold: slowThreshold}
+	return &event.CommandMonitor{
+		Started:   m.Started,
+		Succeeded: m.Succeeded,
+		Failed:    m.Failed,
+	}
+}
+
+func (m *CommandMonitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
+	cmd := &command{
+		span:       TracingBegin(ctx, evt),
+		collection: collectionOf(evt.CommandName, evt.Command),
+	}
+	if m.SlowThreshold > 0 {
+		if field, ok := filterFields[evt.CommandName]; ok {
+			if v, err := evt.Command.LookupErr(field); err == nil {
+				// the command document is reused by the driver after the callback
+				cmd.filter = bson.RawValue{Type: v.Type, Value: append([]byte(nil), v.Value...)}
+			}
+		}
+	}
+	m.commands.Store(evt.RequestID, cmd)
+}
+
+func (m *CommandMonitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
+	m.finish(ctx, &evt.CommandFinishedEvent, "")
+}
+
+func (m *CommandMonitor) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
+	m.finish(ctx, &evt.CommandFinishedEvent, evt.Failure)
+}
+
+func (m *CommandMonitor) finish(ctx context.Context, evt *event.CommandFinishedEvent, failure string) {
+	v, ok := m.commands.Load(evt.RequestID)
+	if !ok {
+		return
+	}
+	m.commands.Delete(evt.RequestID)
+	cmd := v.(*command)
+	if cmd.span != nil {
+		TracingEnd(cmd.span, failure)
+	}
+
+	elapsed := time.Duration(evt.DurationNanos)
+	metrics.ReportMongoCommand(cmd.collection, evt.CommandName, len(failure) > 0, elapsed)
+
+	if m.SlowThreshold <= 0 || elapsed < m.SlowThreshold {
+		return
+	}
+	log.WithContext(ctx).Warnf("[%s]slow mongo command %s on collection[%s], filter: %s, failure: %s",
+		elapsed, evt.CommandName, cmd.collection, FilterSummary(cmd.filter), failure)
+}
+
+// collectionOf returns the collection the command operates on, the name is
+// the value of the first element in most commands, e.g. {find: "microservice", ...}
+func collectionOf(name string, cmd bson.Raw) string {
+	if name == "getMore" {
+		c, _ := cmd.Lookup("collection").StringValueOK()
+		return c
+	}
+	elem, err := cmd.IndexErr(0)
+	if err != nil {
+		return ""
+	}
+	c, _ := elem.Value().StringValueOK()
+	return c
+}
+
+// FilterSummary returns the filter with all the values redacted and the keys
+// and the operators kept, e.g. {"domain":?,"instance.status":{"$in":?}}
+func FilterSummary(filter bson.RawValue) string {
+	if len(filter.Value) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	writeRedacted(&b, filter)
+	if b.Len() > maxFilterSummary {
+		return b.String()[:maxFilterSummary] + "..."
+	}
+	return b.String()
+}
+
+func writeRedacted(b *strings.Builder, v bson.RawValue) {
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		elems, err := v.Document().Elements()
+		if err != nil {
+			b.WriteString(redacted)
+			return
+		}
+		b.WriteString("{")
+		for i, elem := range elems {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(`"` + elem.Key() + `":`)
+			writeRedacted(b, elem.Value())
+		}
+		b.WriteString("}")
+	case bsontype.Array:
+		values, err := v.Array().Values()
+		if err != nil {
+			b.WriteString(redacted)
+			return
+		}
+		b.WriteString("[")
+		for i, value := range values {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			writeRedacted(b, value)
+		}
+		b.WriteString("]")
+	default:
+		b.WriteString(redacted)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	_ "github.com/apache/servicecomb-service-center/server/plugin/tracing/pzipkin"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestFilterSummary(t *testing.T) {
+	cmd, err := bson.Marshal(bson.D{
+		{Key: "find", Value: "instance"},
+		{Key: "filter", Value: bson.D{
+			{Key: "domain", Value: "default"},
+			{Key: "instance.status", Value: bson.M{"$in": bson.A{"UP", "DOWN"}}},
+		}},
+	})
+	assert.NoError(t, err)
+	raw := bson.Raw(cmd)
+
+	assert.Equal(t, "instance", collectionOf("find", raw))
+	assert.Equal(t, `{"domain":?,"instance.status":{"$in":[?,?]}}`, FilterSummary(raw.Lookup("filter")))
+	assert.Equal(t, "", FilterSummary(bson.RawValue{}))
+
+	ping, _ := bson.Marshal(bson.D{{Key: "ping", Value: 1}})
+	assert.Equal(t, "", collectionOf("ping", ping))
+	getMore, _ := bson.Marshal(bson.D{{Key: "getMore", Value: int64(1)}, {Key: "collection", Value: "service"}})
+	assert.Equal(t, "service", collectionOf("getMore", getMore))
+}
+
+func TestCommandMonitor(t *testing.T) {
+	cmd, _ := bson.Marshal(bson.D{
+		{Key: "find", Value: "instance"},
+		{Key: "filter", Value: bson.D{{Key: "domain", Value: "default"}}},
+	})
+	m := &CommandMonitor{SlowThreshold: time.Millisecond}
+	m.Started(context.Background(), &event.CommandStartedEvent{
+		Command: cmd, CommandName: "find", RequestID: 1,
+	})
+	// the driver reuses the command document
+	for i := range cmd {
+		cmd[i] = 0
+	}
+	v, ok := m.commands.Load(int64(1))
+	assert.True(t, ok)
+	assert.Equal(t, "instance", v.(*command).collection)
+	assert.Equal(t, `{"domain":?}`, FilterSummary(v.(*command).filter))
+
+	m.Failed(context.Background(), &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			CommandName: "find", RequestID: 1, DurationNanos: int64(time.Second),
+		},
+		Failure: "timeout",
+	})
+	_, ok = m.commands.Load(int64(1))
+	assert.False(t, ok)
+}
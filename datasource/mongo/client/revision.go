@@ -0,0 +1,30 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ToRevision packs the cluster time of the change into an increasing revision,
+// the seconds are in the high 32 bits and the ordinal is in the low 32 bits
+func ToRevision(t primitive.Timestamp) int64 {
+	return int64(t.T)<<32 | int64(t.I)
+}
+
+// ToTimestamp is the reverse of ToRevision
+func ToTimestamp(rev int64) primitive.Timestamp {
+	return primitive.Timestamp{T: uint32(rev >> 32), I: uint32(rev)}
+}
@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestToRevision(t *testing.T) {
+	ts := primitive.Timestamp{T: 1634600000, I: 3}
+	rev := ToRevision(ts)
+	assert.Equal(t, ts, ToTimestamp(rev))
+	assert.True(t, rev < ToRevision(primitive.Timestamp{T: 1634600000, I: 4}))
+	assert.True(t, rev < ToRevision(primitive.Timestamp{T: 1634600001}))
+
+	t.Run("the next revision of the last ordinal should be the next second", func(t *testing.T) {
+		last := ToRevision(primitive.Timestamp{T: 1634600000, I: 1<<32 - 1})
+		assert.Equal(t, primitive.Timestamp{T: 1634600001}, ToTimestamp(last+1))
+	})
+}
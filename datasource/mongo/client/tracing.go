@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/apache/servicecomb-service-center/server/plugin/tracing"
+)
+
+type MongoOptions struct {
+	Database string
+	Command  string
+}
+
+func (mo *MongoOptions) Method() string {
+	return mo.Command
+}
+
+func (mo *MongoOptions) URL() string {
+	return "/" + mo.Database
+}
+
+func (mo *MongoOptions) System() string {
+	return "mongodb"
+}
+
+func TracingBegin(ctx context.Context, evt *event.CommandStartedEvent) tracing.Span {
+	return tracing.ClientBegin("mongo:"+evt.CommandName, &tracing.Operation{
+		Ctx:      ctx,
+		Endpoint: "mongodb://" + evt.ConnectionID,
+		Options:  &MongoOptions{Database: evt.DatabaseName, Command: evt.CommandName},
+	})
+}
+
+func TracingEnd(span tracing.Span, failure string) {
+	if len(failure) > 0 {
+		tracing.ClientEnd(span, http.StatusInternalServerError, failure)
+		return
+	}
+	tracing.ClientEnd(span, http.StatusOK, "")
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/apache/servicecomb-service-center/server/event"
 	"github.com/apache/servicecomb-service-center/server/metrics"
 	"github.com/apache/servicecomb-service-center/server/syncernotify"
+	"github.com/apache/servicecomb-service-center/server/webhook"
 )
 
 const (
@@ -45,7 +46,8 @@ const (
 )
 
 // InstanceEventHandler is the handler to handle events
-//as instance registry or instance delete, and notify syncer
+// as instance registry or instance delete, and notify syncer,
+// the instance events are sent to the webhooks too
 type InstanceEventHandler struct {
 }
 
@@ -55,9 +57,6 @@ func (h InstanceEventHandler) Type() string {
 
 func (h InstanceEventHandler) OnEvent(evt sd.MongoEvent) {
 	action := evt.Type
-	if evt.Type == discovery.EVT_UPDATE {
-		return
-	}
 	instance := evt.Value.(model.Instance)
 	providerID := instance.Instance.ServiceId
 	providerInstanceID := instance.Instance.InstanceId
@@ -77,6 +76,15 @@ func (h InstanceEventHandler) OnEvent(evt sd.MongoEvent) {
 		return
 	}
 	microService := res.Service
+	if action != discovery.EVT_INIT {
+		webhook.Fire(&webhook.Event{
+			ID:            webhookEventID(evt),
+			Action:        action,
+			DomainProject: domainProject,
+			Service:       microService,
+			Instance:      instance.Instance,
+		})
+	}
 	switch action {
 	case discovery.EVT_INIT:
 		metrics.ReportInstances(instance.Domain, increaseOne)
@@ -87,6 +95,9 @@ func (h InstanceEventHandler) OnEvent(evt sd.MongoEvent) {
 		metrics.ReportInstances(instance.Domain, increaseOne)
 	case discovery.EVT_DELETE:
 		metrics.ReportInstances(instance.Domain, decreaseOne)
+	case discovery.EVT_UPDATE:
+		// only the webhooks care about the updates
+		return
 	}
 	if !syncernotify.GetSyncerNotifyCenter().Closed() {
 		NotifySyncerInstanceEvent(evt, microService)
@@ -20,11 +20,15 @@ package event
 import (
 	pb "github.com/go-chassis/cari/discovery"
 
+	"github.com/apache/servicecomb-service-center/datasource/cache"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/sd"
 	"github.com/apache/servicecomb-service-center/server/metrics"
+	"github.com/apache/servicecomb-service-center/server/webhook"
 )
 
+// SchemaSummaryEventHandler report schema metrics and
+// send the schema events to the webhooks
 type SchemaSummaryEventHandler struct {
 }
 
@@ -46,4 +50,21 @@ func (h *SchemaSummaryEventHandler) OnEvent(evt sd.MongoEvent) {
 		metrics.ReportSchemas(schema.Domain, decreaseOne)
 	default:
 	}
+
+	if action == pb.EVT_INIT {
+		return
+	}
+
+	ms := &pb.MicroService{ServiceId: schema.ServiceID}
+	// the service may be deleted with the schema
+	if res, ok := cache.GetServiceByID(schema.ServiceID); ok {
+		ms = res.Service
+	}
+	webhook.Fire(&webhook.Event{
+		ID:            webhookEventID(evt),
+		Action:        action,
+		DomainProject: schema.Domain + "/" + schema.Project,
+		Service:       ms,
+		Schema:        &pb.Schema{SchemaId: schema.SchemaID, Summary: schema.SchemaSummary},
+	})
 }
@@ -28,6 +28,7 @@ import (
 	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/server/metrics"
+	"github.com/apache/servicecomb-service-center/server/webhook"
 )
 
 type ServiceEventHandler struct {
@@ -65,6 +66,13 @@ func (h *ServiceEventHandler) OnEvent(evt sd.MongoEvent) {
 
 	log.Infof("caught [%s] service[%s][%s/%s/%s/%s] event",
 		evt.Type, ms.Service.ServiceId, ms.Service.Environment, ms.Service.AppId, ms.Service.ServiceName, ms.Service.Version)
+
+	webhook.Fire(&webhook.Event{
+		ID:            webhookEventID(evt),
+		Action:        evt.Type,
+		DomainProject: ms.Domain + "/" + ms.Project,
+		Service:       ms.Service,
+	})
 }
 
 func getFramework(ms *pb.MicroService) (string, string) {
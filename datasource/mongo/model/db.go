@@ -21,18 +21,42 @@ import (
 	"time"
 
 	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+const (
+	CollectionAccount        = "account"
+	CollectionService        = "service"
+	CollectionSchema         = "schema"
+	CollectionSchemaRevision = "schema_revision"
+	CollectionRule           = "rule"
+	CollectionInstance       = "instance"
+	CollectionDep            = "dependency"
+	CollectionDepSeen        = "dependency_seen"
+	CollectionRole           = "role"
+	CollectionDomain         = "domain"
+	CollectionProject        = "project"
+	CollectionLock           = "lock"
 )
 
 const (
-	CollectionAccount  = "account"
-	CollectionService  = "service"
-	CollectionSchema   = "schema"
-	CollectionRule     = "rule"
-	CollectionInstance = "instance"
-	CollectionDep      = "dependency"
-	CollectionRole     = "role"
-	CollectionDomain   = "domain"
-	CollectionProject  = "project"
+	CollectionBrokerParticipant  = "broker_participant"
+	CollectionBrokerVersion      = "broker_version"
+	CollectionBrokerPact         = "broker_pact"
+	CollectionBrokerPactVersion  = "broker_pact_version"
+	CollectionBrokerVerification = "broker_verification"
+	CollectionBrokerTag          = "broker_tag"
+	CollectionBrokerDeployment   = "broker_deployment"
+	CollectionBrokerWebhook      = "broker_webhook"
+	CollectionBrokerWebhookExec  = "broker_webhook_execution"
+)
+
+const (
+	CollectionWebhook         = "webhook"
+	CollectionWebhookDelivery = "webhook_delivery"
 )
 
 const (
@@ -57,6 +81,7 @@ const (
 	ColumnRuleType            = "rule_type"
 	ColumnSchema              = "schema"
 	ColumnSchemaSummary       = "schema_summary"
+	ColumnRevision            = "revision"
 	ColumnDep                 = "dep"
 	ColumnDependency          = "dependency"
 	ColumnRule                = "rule"
@@ -75,6 +100,36 @@ const (
 	ColumnCurrentPassword     = "current_password"
 	ColumnStatus              = "status"
 	ColumnRefreshTime         = "refresh_time"
+	ColumnConsumerID          = "consumer_id"
+	ColumnLockID              = "lock_id"
+	ColumnOwner               = "owner"
+	ColumnExpireAt            = "expire_at"
+)
+
+// the columns of the pact broker data, the nested ones are named by the lower case field names
+const (
+	ColumnParticipant           = "participant"
+	ColumnPact                  = "pact"
+	ColumnPactVersion           = "pact_version"
+	ColumnVerification          = "verification"
+	ColumnBrokerAppID           = "appid"
+	ColumnBrokerServiceName     = "servicename"
+	ColumnNumber                = "number"
+	ColumnParticipantID         = "participantid"
+	ColumnConsumerParticipantID = "consumerparticipantid"
+	ColumnProviderParticipantID = "providerparticipantid"
+	ColumnSha                   = "sha"
+	ColumnVersionID             = "versionid"
+	ColumnPactID                = "pactid"
+	ColumnPactVersionID         = "pactversionid"
+	ColumnBrokerTag             = "tag"
+	ColumnTagName               = "name"
+	ColumnDeployment            = "deployment"
+	ColumnEnvironment           = "environment"
+	ColumnWebhook               = "webhook"
+	ColumnWebhookExecution      = "execution"
+	ColumnWebhookID             = "webhookid"
+	ColumnDelivery              = "delivery"
 )
 
 type Service struct {
@@ -93,6 +148,14 @@ type Schema struct {
 	SchemaSummary string `json:"schemaSummary,omitempty" bson:"schema_summary"`
 }
 
+type SchemaRevision struct {
+	Domain    string                `json:"domain,omitempty"`
+	Project   string                `json:"project,omitempty"`
+	ServiceID string                `json:"serviceID,omitempty" bson:"service_id"`
+	SchemaID  string                `json:"schemaID,omitempty" bson:"schema_id"`
+	Revision  *proto.SchemaRevision `json:"revision,omitempty"`
+}
+
 type Rule struct {
 	Domain    string          `json:"domain,omitempty"`
 	Project   string          `json:"project,omitempty"`
@@ -123,11 +186,95 @@ type DependencyRule struct {
 	Dep        *pb.MicroServiceDependency `json:"dep,omitempty"`
 }
 
+type DependencySeen struct {
+	Domain      string              `json:"domain,omitempty"`
+	Project     string              `json:"project,omitempty"`
+	ConsumerID  string              `json:"consumerID,omitempty" bson:"consumer_id"`
+	Environment string              `json:"environment,omitempty" bson:"env"`
+	AppID       string              `json:"appID,omitempty" bson:"app"`
+	ServiceName string              `json:"serviceName,omitempty" bson:"service_name"`
+	Provider    *pb.MicroServiceKey `json:"provider,omitempty"`
+	LastSeen    int64               `json:"lastSeen,omitempty" bson:"last_seen"`
+}
+
+// Lock is the dlock held by a service center until ExpireAt
+type Lock struct {
+	LockID   string `json:"lockID,omitempty" bson:"lock_id"`
+	Owner    string `json:"owner,omitempty"`
+	ExpireAt int64  `json:"expireAt,omitempty" bson:"expire_at"`
+}
+
 type DelDepCacheKey struct {
 	Key  *pb.MicroServiceKey
 	Type string
 }
 
+type BrokerParticipant struct {
+	Domain      string                `json:"domain,omitempty"`
+	Project     string                `json:"project,omitempty"`
+	Participant *brokerpb.Participant `json:"participant,omitempty"`
+}
+
+type BrokerVersion struct {
+	Domain  string            `json:"domain,omitempty"`
+	Project string            `json:"project,omitempty"`
+	Version *brokerpb.Version `json:"version,omitempty"`
+}
+
+type BrokerPact struct {
+	Domain  string         `json:"domain,omitempty"`
+	Project string         `json:"project,omitempty"`
+	Pact    *brokerpb.Pact `json:"pact,omitempty"`
+}
+
+type BrokerPactVersion struct {
+	Domain      string                `json:"domain,omitempty"`
+	Project     string                `json:"project,omitempty"`
+	PactVersion *brokerpb.PactVersion `json:"pactVersion,omitempty" bson:"pact_version"`
+}
+
+type BrokerVerification struct {
+	Domain       string                 `json:"domain,omitempty"`
+	Project      string                 `json:"project,omitempty"`
+	Verification *brokerpb.Verification `json:"verification,omitempty"`
+}
+
+type BrokerTag struct {
+	Domain  string        `json:"domain,omitempty"`
+	Project string        `json:"project,omitempty"`
+	Tag     *brokerpb.Tag `json:"tag,omitempty"`
+}
+
+type BrokerDeployment struct {
+	Domain     string               `json:"domain,omitempty"`
+	Project    string               `json:"project,omitempty"`
+	Deployment *brokerpb.Deployment `json:"deployment,omitempty"`
+}
+
+type BrokerWebhook struct {
+	Domain  string            `json:"domain,omitempty"`
+	Project string            `json:"project,omitempty"`
+	Webhook *brokerpb.Webhook `json:"webhook,omitempty"`
+}
+
+type BrokerWebhookExecution struct {
+	Domain    string                     `json:"domain,omitempty"`
+	Project   string                     `json:"project,omitempty"`
+	Execution *brokerpb.WebhookExecution `json:"execution,omitempty"`
+}
+
+type Webhook struct {
+	Domain  string             `json:"domain,omitempty"`
+	Project string             `json:"project,omitempty"`
+	Webhook *webhookpb.Webhook `json:"webhook,omitempty"`
+}
+
+type WebhookDelivery struct {
+	Domain   string              `json:"domain,omitempty"`
+	Project  string              `json:"project,omitempty"`
+	Delivery *webhookpb.Delivery `json:"delivery,omitempty"`
+}
+
 type Domain struct {
 	Domain string `json:"domain,omitempty"`
 }
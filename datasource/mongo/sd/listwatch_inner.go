@@ -132,9 +132,12 @@ func (lw *mongoListWatch) doParseWatchRspToResource(wRsp *MongoWatchResponse) (r
 	case deleteOp:
 		//delete operation has no fullDocumentValue
 		resource.Key = wRsp.DocumentKey.ID.Hex()
+		resource.ModRevision = client.ToRevision(wRsp.ClusterTime)
 		return
 	case insertOp, updateOp, replaceOp:
-		return lw.parseFunc(wRsp.FullDocument)
+		resource = lw.parseFunc(wRsp.FullDocument)
+		resource.ModRevision = client.ToRevision(wRsp.ClusterTime)
+		return
 	default:
 		log.Warn(fmt.Sprintf("unrecognized operation:%s", wRsp.OperationType))
 	}
@@ -22,6 +22,7 @@ package sd
 import (
 	"testing"
 
+	"github.com/go-chassis/cari/discovery"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -91,6 +92,15 @@ func TestDoParseWatchRspToMongoInfo(t *testing.T) {
 	info = ilw.doParseWatchRspToResource(mockWatchRsp)
 	assert.Equal(t, documentID.Hex(), info.Key)
 
+	// case the cluster time of the change
+	raw, _ := bson.Marshal(bson.M{"operationType": updateOp, "fullDocument": mockDocument,
+		"documentKey": bson.M{"_id": documentID}, "clusterTime": primitive.Timestamp{T: 1608552622, I: 2}})
+	mockWatchRsp = &MongoWatchResponse{}
+	assert.NoError(t, bson.Unmarshal(raw, mockWatchRsp))
+	info = ilw.doParseWatchRspToResource(mockWatchRsp)
+	assert.Equal(t, int64(1608552622)<<32|2, info.ModRevision)
+	assert.Equal(t, int64(1608552622)<<32|2, NewMongoEventByResource(&info, discovery.EVT_UPDATE).Revision)
+
 	// case service insertOp
 	mockWatchRsp = &MongoWatchResponse{OperationType: insertOp,
 		FullDocument: mockServiceDocument,
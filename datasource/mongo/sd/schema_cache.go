@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sd
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+	"github.com/apache/servicecomb-service-center/datasource/sdcommon"
+)
+
+// schemaStore caches the schema summaries, the schema contents are not kept in memory
+type schemaStore struct {
+	dirty      bool
+	d          *DocStore
+	indexCache *IndexCache
+}
+
+func init() {
+	RegisterCacher(schema, newSchemaStore)
+}
+
+func newSchemaStore() *MongoCacher {
+	options := DefaultOptions().SetTable(schema)
+	cache := &schemaStore{
+		dirty:      false,
+		d:          NewDocStore(),
+		indexCache: NewIndexCache(),
+	}
+	schemaUnmarshal := func(doc bson.Raw) (resource sdcommon.Resource) {
+		docID := MongoDocument{}
+		err := bson.Unmarshal(doc, &docID)
+		if err != nil {
+			return
+		}
+		schema := model.Schema{}
+		err = bson.Unmarshal(doc, &schema)
+		if err != nil {
+			return
+		}
+		schema.Schema = ""
+		resource.Value = schema
+		resource.Key = docID.ID.Hex()
+		return
+	}
+	return NewMongoCacher(options, cache, schemaUnmarshal)
+}
+
+func (s *schemaStore) Name() string {
+	return schema
+}
+
+func (s *schemaStore) Size() int {
+	return s.d.Size()
+}
+
+func (s *schemaStore) Get(key string) interface{} {
+	return s.d.Get(key)
+}
+
+func (s *schemaStore) ForEach(iter func(k string, v interface{}) (next bool)) {
+	s.d.ForEach(iter)
+}
+
+func (s *schemaStore) GetValue(index string) []interface{} {
+	docs := s.indexCache.Get(index)
+	res := make([]interface{}, 0, len(docs))
+	for _, v := range docs {
+		res = append(res, s.d.Get(v))
+	}
+	return res
+}
+
+func (s *schemaStore) Dirty() bool {
+	return s.dirty
+}
+
+func (s *schemaStore) MarkDirty() {
+	s.dirty = true
+}
+
+func (s *schemaStore) Clear() {
+	s.dirty = false
+	s.d.store.Flush()
+}
+
+func (s *schemaStore) ProcessUpdate(event MongoEvent) {
+	schema, ok := event.Value.(model.Schema)
+	if !ok {
+		return
+	}
+	s.d.Put(event.DocumentID, event.Value)
+	s.indexCache.Put(genSchemaServiceID(schema), event.DocumentID)
+}
+
+func (s *schemaStore) ProcessDelete(event MongoEvent) {
+	schema, ok := s.d.Get(event.DocumentID).(model.Schema)
+	if !ok {
+		return
+	}
+	s.d.DeleteDoc(event.DocumentID)
+	s.indexCache.Delete(genSchemaServiceID(schema), event.DocumentID)
+}
+
+func (s *schemaStore) isValueNotUpdated(value interface{}, newValue interface{}) bool {
+	oldSchema, ok := value.(model.Schema)
+	if !ok {
+		return false
+	}
+	newSchema, ok := newValue.(model.Schema)
+	if !ok {
+		return false
+	}
+	return oldSchema.SchemaSummary == newSchema.SchemaSummary
+}
+
+func genSchemaServiceID(schema model.Schema) string {
+	return schema.ServiceID
+}
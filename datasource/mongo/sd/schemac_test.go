@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package sd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+)
+
+func TestSchemaCacheBasicFunc(t *testing.T) {
+	schemaCache := newSchemaStore()
+	t.Run("init schemaCache,should pass", func(t *testing.T) {
+		assert.NotNil(t, schemaCache)
+		assert.Equal(t, schema, schemaCache.cache.Name())
+	})
+	schema1 := model.Schema{
+		Domain:        "default",
+		Project:       "default",
+		ServiceID:     "123456789",
+		SchemaID:      "schema1",
+		SchemaSummary: "summary1",
+	}
+	schema2 := model.Schema{
+		Domain:        "default",
+		Project:       "default",
+		ServiceID:     "123456789",
+		SchemaID:      "schema2",
+		SchemaSummary: "summary2",
+	}
+	event1 := MongoEvent{
+		DocumentID: "id1",
+		Value:      schema1,
+	}
+	event2 := MongoEvent{
+		DocumentID: "id2",
+		Value:      schema2,
+	}
+	t.Run("update&&delete schemaCache, should pass", func(t *testing.T) {
+		schemaCache.cache.ProcessUpdate(event1)
+		assert.Equal(t, 1, schemaCache.cache.Size())
+		assert.Nil(t, schemaCache.cache.Get("id_not_exist"))
+		assert.Equal(t, schema1.SchemaID, schemaCache.cache.Get("id1").(model.Schema).SchemaID)
+		schemaCache.cache.ProcessUpdate(event2)
+		assert.Equal(t, 2, schemaCache.cache.Size())
+		assert.Len(t, schemaCache.cache.GetValue("123456789"), 2)
+		schemaCache.cache.ProcessDelete(event1)
+		assert.Nil(t, schemaCache.cache.Get("id1"))
+		assert.Len(t, schemaCache.cache.GetValue("123456789"), 1)
+		schemaCache.cache.ProcessDelete(event2)
+		assert.Nil(t, schemaCache.cache.Get("id2"))
+		assert.Len(t, schemaCache.cache.GetValue("123456789"), 0)
+	})
+	t.Run("compare schema summaries, should pass", func(t *testing.T) {
+		assert.True(t, schemaCache.cache.isValueNotUpdated(schema1, schema1))
+		assert.False(t, schemaCache.cache.isValueNotUpdated(schema1, schema2))
+	})
+}
@@ -32,6 +32,7 @@ const (
 	instance = "instance"
 	rule     = "rule"
 	dep      = "dependency"
+	schema   = "schema"
 )
 
 type cacherRegisterInitiallizer func() (cacher *MongoCacher)
@@ -52,6 +53,8 @@ type MongoEvent struct {
 	DocumentID string
 	Value      interface{}
 	Type       discovery.EventType
+	// Revision is the cluster time of the change, 0 if the event is not from the change stream
+	Revision int64
 }
 
 type MongoEventFunc func(evt MongoEvent)
@@ -66,6 +69,7 @@ func NewMongoEventByResource(resource *sdcommon.Resource, action discovery.Event
 		Type:       action,
 		Value:      resource.Value,
 		DocumentID: resource.Key,
+		Revision:   resource.ModRevision,
 	}
 }
 
@@ -81,6 +85,7 @@ type MongoWatchResponse struct {
 	OperationType string
 	FullDocument  bson.Raw
 	DocumentKey   MongoDocument
+	ClusterTime   primitive.Timestamp
 }
 
 type MongoDocument struct {
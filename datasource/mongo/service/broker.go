@@ -0,0 +1,481 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+)
+
+// brokerCollections maps the kinds of broker data to the collections and the columns storing them
+var brokerCollections = map[string][2]string{
+	datasource.BrokerParticipant:  {model.CollectionBrokerParticipant, model.ColumnParticipant},
+	datasource.BrokerVersion:      {model.CollectionBrokerVersion, model.ColumnVersion},
+	datasource.BrokerPact:         {model.CollectionBrokerPact, model.ColumnPact},
+	datasource.BrokerPactVersion:  {model.CollectionBrokerPactVersion, model.ColumnPactVersion},
+	datasource.BrokerVerification: {model.CollectionBrokerVerification, model.ColumnVerification},
+}
+
+func (ds *DataSource) GetParticipant(ctx context.Context, domainProject, appID,
+	serviceName string) (*brokerpb.Participant, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnParticipant, bson.M{
+		model.ColumnBrokerAppID:       appID,
+		model.ColumnBrokerServiceName: serviceName,
+	})
+	doc := &model.BrokerParticipant{}
+	ok, err := findBrokerData(ctx, model.CollectionBrokerParticipant, filter, doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Participant, nil
+}
+
+func (ds *DataSource) ListParticipants(ctx context.Context, domainProject string) ([]*brokerpb.Participant, error) {
+	var participants []*brokerpb.Participant
+	err := listBrokerData(ctx, model.CollectionBrokerParticipant, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerParticipant{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			participants = append(participants, doc.Participant)
+			return nil
+		})
+	return participants, err
+}
+
+func (ds *DataSource) CreateParticipant(ctx context.Context, domainProject string,
+	participant *brokerpb.Participant) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerParticipant, &model.BrokerParticipant{
+		Domain:      domain,
+		Project:     project,
+		Participant: participant,
+	})
+}
+
+func (ds *DataSource) GetVersion(ctx context.Context, domainProject, number string,
+	participantID int32) (*brokerpb.Version, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnVersion, bson.M{
+		model.ColumnNumber:        number,
+		model.ColumnParticipantID: participantID,
+	})
+	doc := &model.BrokerVersion{}
+	ok, err := findBrokerData(ctx, model.CollectionBrokerVersion, filter, doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Version, nil
+}
+
+func (ds *DataSource) ListVersions(ctx context.Context, domainProject string) ([]*brokerpb.Version, error) {
+	var versions []*brokerpb.Version
+	err := listBrokerData(ctx, model.CollectionBrokerVersion, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerVersion{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			versions = append(versions, doc.Version)
+			return nil
+		})
+	return versions, err
+}
+
+func (ds *DataSource) CreateVersion(ctx context.Context, domainProject string, version *brokerpb.Version) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerVersion, &model.BrokerVersion{
+		Domain:  domain,
+		Project: project,
+		Version: version,
+	})
+}
+
+func (ds *DataSource) GetPact(ctx context.Context, domainProject string, consumerParticipantID,
+	providerParticipantID int32, sha []byte) (*brokerpb.Pact, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnPact, bson.M{
+		model.ColumnConsumerParticipantID: consumerParticipantID,
+		model.ColumnProviderParticipantID: providerParticipantID,
+		model.ColumnSha:                   sha,
+	})
+	doc := &model.BrokerPact{}
+	ok, err := findBrokerData(ctx, model.CollectionBrokerPact, filter, doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Pact, nil
+}
+
+func (ds *DataSource) ListPacts(ctx context.Context, domainProject string) ([]*brokerpb.Pact, error) {
+	var pacts []*brokerpb.Pact
+	err := listBrokerData(ctx, model.CollectionBrokerPact, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerPact{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			pacts = append(pacts, doc.Pact)
+			return nil
+		})
+	return pacts, err
+}
+
+func (ds *DataSource) CreatePact(ctx context.Context, domainProject string, pact *brokerpb.Pact) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerPact, &model.BrokerPact{
+		Domain:  domain,
+		Project: project,
+		Pact:    pact,
+	})
+}
+
+func (ds *DataSource) GetPactVersion(ctx context.Context, domainProject string, versionID,
+	pactID int32) (*brokerpb.PactVersion, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnPactVersion, bson.M{
+		model.ColumnVersionID: versionID,
+		model.ColumnPactID:    pactID,
+	})
+	doc := &model.BrokerPactVersion{}
+	ok, err := findBrokerData(ctx, model.CollectionBrokerPactVersion, filter, doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.PactVersion, nil
+}
+
+func (ds *DataSource) ListPactVersions(ctx context.Context, domainProject string) ([]*brokerpb.PactVersion, error) {
+	var pactVersions []*brokerpb.PactVersion
+	err := listBrokerData(ctx, model.CollectionBrokerPactVersion, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerPactVersion{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			pactVersions = append(pactVersions, doc.PactVersion)
+			return nil
+		})
+	return pactVersions, err
+}
+
+func (ds *DataSource) CreatePactVersion(ctx context.Context, domainProject string,
+	pactVersion *brokerpb.PactVersion) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerPactVersion, &model.BrokerPactVersion{
+		Domain:      domain,
+		Project:     project,
+		PactVersion: pactVersion,
+	})
+}
+
+func (ds *DataSource) ListVerifications(ctx context.Context, domainProject string,
+	pactVersionID int32) ([]*brokerpb.Verification, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnVerification, bson.M{
+		model.ColumnPactVersionID: pactVersionID,
+	})
+	var verifications []*brokerpb.Verification
+	err := listBrokerData(ctx, model.CollectionBrokerVerification, filter,
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerVerification{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			verifications = append(verifications, doc.Verification)
+			return nil
+		})
+	return verifications, err
+}
+
+func (ds *DataSource) CreateVerification(ctx context.Context, domainProject string,
+	verification *brokerpb.Verification) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerVerification, &model.BrokerVerification{
+		Domain:       domain,
+		Project:      project,
+		Verification: verification,
+	})
+}
+
+func (ds *DataSource) ListTags(ctx context.Context, domainProject string) ([]*brokerpb.Tag, error) {
+	var tags []*brokerpb.Tag
+	err := listBrokerData(ctx, model.CollectionBrokerTag, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerTag{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			tags = append(tags, doc.Tag)
+			return nil
+		})
+	return tags, err
+}
+
+func (ds *DataSource) CreateTag(ctx context.Context, domainProject string, tag *brokerpb.Tag) error {
+	domain, project := util.FromDomainProject(domainProject)
+	filter := newBrokerFilter(domainProject, model.ColumnBrokerTag, bson.M{
+		model.ColumnVersionID: tag.VersionId,
+		model.ColumnTagName:   tag.Name,
+	})
+	return upsertBrokerData(ctx, model.CollectionBrokerTag, filter, &model.BrokerTag{
+		Domain:  domain,
+		Project: project,
+		Tag:     tag,
+	})
+}
+
+func (ds *DataSource) DeleteTag(ctx context.Context, domainProject string, versionID int32, name string) error {
+	filter := newBrokerFilter(domainProject, model.ColumnBrokerTag, bson.M{
+		model.ColumnVersionID: versionID,
+		model.ColumnTagName:   name,
+	})
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionBrokerTag, filter)
+	return err
+}
+
+func (ds *DataSource) ListDeployments(ctx context.Context, domainProject string) ([]*brokerpb.Deployment, error) {
+	var deployments []*brokerpb.Deployment
+	err := listBrokerData(ctx, model.CollectionBrokerDeployment, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerDeployment{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			deployments = append(deployments, doc.Deployment)
+			return nil
+		})
+	return deployments, err
+}
+
+func (ds *DataSource) PutDeployment(ctx context.Context, domainProject string, deployment *brokerpb.Deployment) error {
+	domain, project := util.FromDomainProject(domainProject)
+	filter := newBrokerFilter(domainProject, model.ColumnDeployment, bson.M{
+		model.ColumnEnvironment:   deployment.Environment,
+		model.ColumnParticipantID: deployment.ParticipantId,
+	})
+	return upsertBrokerData(ctx, model.CollectionBrokerDeployment, filter, &model.BrokerDeployment{
+		Domain:     domain,
+		Project:    project,
+		Deployment: deployment,
+	})
+}
+
+func (ds *DataSource) DeleteDeployment(ctx context.Context, domainProject, environment string, participantID int32) error {
+	filter := newBrokerFilter(domainProject, model.ColumnDeployment, bson.M{
+		model.ColumnEnvironment:   environment,
+		model.ColumnParticipantID: participantID,
+	})
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionBrokerDeployment, filter)
+	return err
+}
+
+// GetWebhook returns the pact broker webhook, it returns nil if not exist
+func (ds *DataSource) GetWebhook(ctx context.Context, domainProject, webhookID string) (*brokerpb.Webhook, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnWebhook, bson.M{model.ColumnID: webhookID})
+	doc := &model.BrokerWebhook{}
+	ok, err := findBrokerData(ctx, model.CollectionBrokerWebhook, filter, doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Webhook, nil
+}
+
+func (ds *DataSource) ListWebhooks(ctx context.Context, domainProject string) ([]*brokerpb.Webhook, error) {
+	var webhooks []*brokerpb.Webhook
+	err := listBrokerData(ctx, model.CollectionBrokerWebhook, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerWebhook{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			webhooks = append(webhooks, doc.Webhook)
+			return nil
+		})
+	return webhooks, err
+}
+
+func (ds *DataSource) PutWebhook(ctx context.Context, domainProject string, webhook *brokerpb.Webhook) error {
+	domain, project := util.FromDomainProject(domainProject)
+	filter := newBrokerFilter(domainProject, model.ColumnWebhook, bson.M{model.ColumnID: webhook.Id})
+	return upsertBrokerData(ctx, model.CollectionBrokerWebhook, filter, &model.BrokerWebhook{
+		Domain:  domain,
+		Project: project,
+		Webhook: webhook,
+	})
+}
+
+func (ds *DataSource) DeleteWebhook(ctx context.Context, domainProject, webhookID string) error {
+	filter := newBrokerFilter(domainProject, model.ColumnWebhook, bson.M{model.ColumnID: webhookID})
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionBrokerWebhook, filter)
+	if err != nil {
+		return err
+	}
+	filter = newBrokerFilter(domainProject, model.ColumnWebhookExecution, bson.M{model.ColumnWebhookID: webhookID})
+	_, err = client.GetMongoClient().Delete(ctx, model.CollectionBrokerWebhookExec, filter)
+	return err
+}
+
+func (ds *DataSource) ListWebhookExecutions(ctx context.Context, domainProject,
+	webhookID string) ([]*brokerpb.WebhookExecution, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnWebhookExecution, bson.M{model.ColumnWebhookID: webhookID})
+	var executions []*brokerpb.WebhookExecution
+	err := listBrokerData(ctx, model.CollectionBrokerWebhookExec, filter,
+		func(decode func(interface{}) error) error {
+			doc := &model.BrokerWebhookExecution{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			executions = append(executions, doc.Execution)
+			return nil
+		})
+	return executions, err
+}
+
+func (ds *DataSource) CreateWebhookExecution(ctx context.Context, domainProject string,
+	execution *brokerpb.WebhookExecution) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerWebhookExec, &model.BrokerWebhookExecution{
+		Domain:    domain,
+		Project:   project,
+		Execution: execution,
+	})
+}
+
+func (ds *DataSource) DeleteWebhookExecution(ctx context.Context, domainProject, webhookID, executionID string) error {
+	filter := newBrokerFilter(domainProject, model.ColumnWebhookExecution, bson.M{
+		model.ColumnWebhookID: webhookID,
+		model.ColumnID:        executionID,
+	})
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionBrokerWebhookExec, filter)
+	return err
+}
+
+// GetLatestBrokerID returns the max id of the kind of broker data
+func (ds *DataSource) GetLatestBrokerID(ctx context.Context, kind string) (int32, error) {
+	collection, ok := brokerCollections[kind]
+	if !ok {
+		return -1, mutil.ErrInvalidParam
+	}
+	column := mutil.ConnectWithDot([]string{collection[1], model.ColumnID})
+	result, err := client.GetMongoClient().FindOne(ctx, collection[0], bson.M{},
+		options.FindOne().SetSort(bson.M{column: -1}))
+	if err != nil {
+		return -1, err
+	}
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return -1, nil
+		}
+		return -1, result.Err()
+	}
+	var doc bson.M
+	if err := result.Decode(&doc); err != nil {
+		return -1, err
+	}
+	data, ok := doc[collection[1]].(bson.M)
+	if !ok {
+		return -1, nil
+	}
+	id, _ := data[model.ColumnID].(int32)
+	return id, nil
+}
+
+func (ds *DataSource) DeleteBrokerData(ctx context.Context) error {
+	collections := []string{model.CollectionBrokerTag, model.CollectionBrokerDeployment,
+		model.CollectionBrokerWebhook, model.CollectionBrokerWebhookExec}
+	for _, collection := range brokerCollections {
+		collections = append(collections, collection[0])
+	}
+	for _, collection := range collections {
+		_, err := client.GetMongoClient().Delete(ctx, collection, bson.M{})
+		if err != nil {
+			log.Errorf(err, "delete broker data in %s failed", collection)
+			return err
+		}
+	}
+	return nil
+}
+
+// newBrokerFilter returns the filter of the domain project and the nested conditions of the column
+func newBrokerFilter(domainProject string, column string, conditions bson.M) bson.M {
+	domain, project := util.FromDomainProject(domainProject)
+	filter := mutil.NewDomainProjectFilter(domain, project)
+	for key, value := range conditions {
+		filter[mutil.ConnectWithDot([]string{column, key})] = value
+	}
+	return filter
+}
+
+func findBrokerData(ctx context.Context, collection string, filter bson.M, doc interface{}) (bool, error) {
+	result, err := client.GetMongoClient().FindOne(ctx, collection, filter)
+	if err != nil {
+		return false, err
+	}
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, result.Err()
+	}
+	if err := result.Decode(doc); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func listBrokerData(ctx context.Context, collection string, filter bson.M,
+	handle func(decode func(interface{}) error) error) error {
+	cursor, err := client.GetMongoClient().Find(ctx, collection, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		if err := handle(cursor.Decode); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func insertBrokerData(ctx context.Context, collection string, doc interface{}) error {
+	_, err := client.GetMongoClient().Insert(ctx, collection, doc)
+	if err != nil {
+		log.Errorf(err, "insert broker data into %s failed", collection)
+	}
+	return err
+}
+
+func upsertBrokerData(ctx context.Context, collection string, filter bson.M, doc interface{}) error {
+	_, err := client.GetMongoClient().Update(ctx, collection, filter, bson.M{"$set": doc},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Errorf(err, "upsert broker data into %s failed", collection)
+	}
+	return err
+}
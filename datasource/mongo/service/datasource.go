@@ -31,6 +31,7 @@ import (
 	"github.com/apache/servicecomb-service-center/datasource/mongo/sd"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/service/heartbeat"
 	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/config"
@@ -45,6 +46,8 @@ func init() {
 type DataSource struct {
 	// SchemaEditable determines whether schema modification is allowed for
 	SchemaEditable bool
+	// SchemaHistorySize is the max revisions of a schema kept in history
+	SchemaHistorySize int
 	// TTL options
 	ttlFromEnv int64
 }
@@ -53,8 +56,9 @@ func NewDataSource(opts datasource.Options) (datasource.DataSource, error) {
 	// TODO: construct a reasonable DataSource instance
 
 	inst := &DataSource{
-		SchemaEditable: opts.SchemaEditable,
-		ttlFromEnv:     opts.InstanceTTL,
+		SchemaEditable:    opts.SchemaEditable,
+		SchemaHistorySize: opts.SchemaHistorySize,
+		ttlFromEnv:        opts.InstanceTTL,
 	}
 	// TODO: deal with exception
 	if err := inst.initialize(); err != nil {
@@ -83,9 +87,25 @@ func (ds *DataSource) initialize() error {
 
 	// init cache
 	ds.initStore()
+	// Jobs
+	clearStaleDependencies()
 	return nil
 }
 
+// clear the dependencies which the consumers have not found the providers for TTL
+func clearStaleDependencies() {
+	if !config.GetRegistry().DependencyClearEnabled {
+		return
+	}
+	ttl := config.GetRegistry().DependencyTTL
+	interval := config.GetRegistry().DependencyClearInterval
+	log.Infof("dependency clear enabled, interval: %s, dependency TTL: %s", interval, ttl)
+
+	gopool.Go(func(ctx context.Context) {
+		datasource.RunClearStaleDependencies(ctx, interval, ttl)
+	})
+}
+
 func (ds *DataSource) initPlugins() error {
 	kind := config.GetString("heartbeat.kind", "cache")
 	err := heartbeat.Init(heartbeat.Options{PluginImplName: heartbeat.ImplName(kind)})
@@ -118,7 +138,11 @@ func EnsureDB() {
 	EnsureInstance()
 	EnsureRule()
 	EnsureSchema()
+	EnsureSchemaRevision()
 	EnsureDep()
+	EnsureBroker()
+	EnsureWebhook()
+	EnsureLock()
 }
 
 func EnsureService() {
@@ -177,6 +201,25 @@ func EnsureSchema() {
 	wrapCreateIndexesError(err)
 }
 
+func EnsureSchemaRevision() {
+	err := client.GetMongoClient().GetDB().CreateCollection(context.Background(), model.CollectionSchemaRevision, options.CreateCollection().SetValidator(nil))
+	wrapCreateCollectionError(err)
+
+	revisionIndex := mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		model.ColumnServiceID,
+		model.ColumnSchemaID,
+		mutil.ConnectWithDot([]string{model.ColumnRevision, model.ColumnRevision}))
+	revisionIndex.Options = options.Index().SetUnique(true)
+
+	var revisionIndexs []mongo.IndexModel
+	revisionIndexs = append(revisionIndexs, revisionIndex)
+
+	err = client.GetMongoClient().CreateIndexes(context.Background(), model.CollectionSchemaRevision, revisionIndexs)
+	wrapCreateIndexesError(err)
+}
+
 func EnsureRule() {
 	err := client.GetMongoClient().GetDB().CreateCollection(context.Background(), model.CollectionRule, options.CreateCollection().SetValidator(nil))
 	wrapCreateCollectionError(err)
@@ -210,6 +253,20 @@ func EnsureDep() {
 		log.Fatal("failed to create dep collection indexs", err)
 		return
 	}
+	err = client.GetMongoClient().GetDB().CreateCollection(context.Background(), model.CollectionDepSeen, options.CreateCollection().SetValidator(nil))
+	wrapCreateCollectionError(err)
+
+	depSeenIndex := mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		model.ColumnConsumerID,
+		model.ColumnEnv,
+		model.ColumnAppID,
+		model.ColumnServiceName)
+	depSeenIndex.Options = options.Index().SetUnique(true)
+
+	err = client.GetMongoClient().CreateIndexes(context.Background(), model.CollectionDepSeen, []mongo.IndexModel{depSeenIndex})
+	wrapCreateIndexesError(err)
 }
 
 func wrapCreateCollectionError(err error) {
@@ -249,3 +306,32 @@ func initFastRegister() {
 		NewRegisterTimeTask().Start()
 	}
 }
+
+func EnsureBroker() {
+	uniqueIndexes := map[string][]string{
+		model.CollectionBrokerParticipant: {model.ColumnParticipant, model.ColumnBrokerAppID, model.ColumnBrokerServiceName},
+		model.CollectionBrokerVersion:     {model.ColumnVersion, model.ColumnNumber, model.ColumnParticipantID},
+		model.CollectionBrokerPact: {model.ColumnPact, model.ColumnConsumerParticipantID,
+			model.ColumnProviderParticipantID, model.ColumnSha},
+		model.CollectionBrokerPactVersion:  {model.ColumnPactVersion, model.ColumnVersionID, model.ColumnPactID},
+		model.CollectionBrokerVerification: {model.ColumnVerification, model.ColumnPactVersionID, model.ColumnNumber},
+		model.CollectionBrokerTag:          {model.ColumnBrokerTag, model.ColumnVersionID, model.ColumnTagName},
+		model.CollectionBrokerDeployment:   {model.ColumnDeployment, model.ColumnEnvironment, model.ColumnParticipantID},
+		model.CollectionBrokerWebhook:      {model.ColumnWebhook, model.ColumnID},
+		model.CollectionBrokerWebhookExec:  {model.ColumnWebhookExecution, model.ColumnWebhookID, model.ColumnID},
+	}
+	for collection, columns := range uniqueIndexes {
+		err := client.GetMongoClient().GetDB().CreateCollection(context.Background(), collection, options.CreateCollection().SetValidator(nil))
+		wrapCreateCollectionError(err)
+
+		keys := []string{model.ColumnDomain, model.ColumnProject}
+		for _, column := range columns[1:] {
+			keys = append(keys, mutil.ConnectWithDot([]string{columns[0], column}))
+		}
+		uniqueIndex := mutil.BuildIndexDoc(keys...)
+		uniqueIndex.Options = options.Index().SetUnique(true)
+
+		err = client.GetMongoClient().CreateIndexes(context.Background(), collection, []mongo.IndexModel{uniqueIndex})
+		wrapCreateIndexesError(err)
+	}
+}
@@ -22,16 +22,19 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-chassis/cari/discovery"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
 	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
 	"github.com/apache/servicecomb-service-center/datasource/util/path"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/pkg/validate"
 )
@@ -217,7 +220,7 @@ func (dr *DependencyRelation) GetConsumerOfSameServiceNameAndAppID(provider *dis
 				continue
 			}
 		} else {
-			if !versionMatchRule(providerVersion, providerVersionRule) {
+			if !validate.VersionMatchRule(providerVersion, providerVersionRule) {
 				continue
 			}
 		}
@@ -372,6 +375,11 @@ func (ds *DataSource) SearchConsumerDependency(ctx context.Context, request *dis
 	}, nil
 }
 
+func (ds *DataSource) ListProviderRules(ctx context.Context, consumer *discovery.MicroService) ([]*discovery.MicroServiceKey, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	return NewConsumerDependencyRelation(ctx, domainProject, consumer).getProviderKeys()
+}
+
 func (ds *DataSource) AddOrUpdateDependencies(ctx context.Context, dependencys []*discovery.ConsumerDependency, override bool) (*discovery.Response, error) {
 	domainProject := util.ParseDomainProject(ctx)
 	for _, dependency := range dependencys {
@@ -430,6 +438,43 @@ func (ds *DataSource) AddOrUpdateDependencies(ctx context.Context, dependencys [
 	return discovery.CreateResponse(discovery.ResponseSuccess, "Create dependency successfully."), nil
 }
 
+func (ds *DataSource) UpdateDependencySeen(ctx context.Context, consumerID string, providers []*discovery.MicroServiceKey) error {
+	now := time.Now().Unix()
+	for _, provider := range providers {
+		err := upsertDepSeen(ctx, &model.DependencySeen{
+			Domain:      util.ParseDomain(ctx),
+			Project:     util.ParseProject(ctx),
+			ConsumerID:  consumerID,
+			Environment: provider.Environment,
+			AppID:       provider.AppId,
+			ServiceName: provider.ServiceName,
+			Provider:    provider,
+			LastSeen:    now,
+		})
+		if err != nil {
+			log.Error(fmt.Sprintf("stamp consumer[%s] -> provider[%s/%s] last seen time failed",
+				consumerID, provider.AppId, provider.ServiceName), err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (ds *DataSource) ListDependencySeen(ctx context.Context) ([]*proto.DependencySeen, error) {
+	return findDepSeens(ctx, bson.M{})
+}
+
+func (ds *DataSource) DeleteDependencySeen(ctx context.Context, seens []*proto.DependencySeen) error {
+	for _, seen := range seens {
+		_, err := client.GetMongoClient().DeleteOne(ctx, model.CollectionDepSeen,
+			depSeenFilter(seen.DomainProject, seen.ConsumerId, seen.Provider))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ds *DataSource) DeleteDependency() {
 	panic("implement me")
 }
@@ -802,26 +847,6 @@ func existDependencyRuleByProviderConsumer(ctx context.Context, provider *discov
 	return existDependencyRule(ctx, providerKey, consumer)
 }
 
-// not prepare for latest scene, should merge it with find serviceids func.
-func versionMatchRule(version, versionRule string) bool {
-	if len(versionRule) == 0 {
-		return false
-	}
-	rangeIdx := strings.Index(versionRule, "-")
-	versionInt, _ := validate.VersionToInt64(version)
-	switch {
-	case versionRule[len(versionRule)-1:] == "+":
-		start, _ := validate.VersionToInt64(versionRule[:len(versionRule)-1])
-		return versionInt >= start
-	case rangeIdx > 0:
-		start, _ := validate.VersionToInt64(versionRule[:rangeIdx])
-		end, _ := validate.VersionToInt64(versionRule[rangeIdx+1:])
-		return versionInt >= start && versionInt < end
-	default:
-		return version == versionRule
-	}
-}
-
 func dependencyFilterOptions(in *discovery.GetDependenciesRequest) (opts []DependencyRelationFilterOption) {
 	if in.SameDomain {
 		opts = append(opts, withSameDomainProject())
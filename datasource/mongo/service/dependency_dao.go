@@ -23,12 +23,15 @@ import (
 	"context"
 
 	"github.com/go-chassis/cari/discovery"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
 )
 
 func insertDep(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) error {
@@ -140,3 +143,44 @@ func getDepRules(ctx context.Context, filter interface{}, opts ...*options.FindO
 	}
 	return depRules, nil
 }
+
+func depSeenFilter(domainProject, consumerID string, provider *discovery.MicroServiceKey) bson.M {
+	domain, project := util.FromDomainProject(domainProject)
+	return bson.M{
+		model.ColumnDomain:      domain,
+		model.ColumnProject:     project,
+		model.ColumnConsumerID:  consumerID,
+		model.ColumnEnv:         provider.Environment,
+		model.ColumnAppID:       provider.AppId,
+		model.ColumnServiceName: provider.ServiceName,
+	}
+}
+
+func upsertDepSeen(ctx context.Context, seen *model.DependencySeen) error {
+	filter := depSeenFilter(util.ToDomainProject(seen.Domain, seen.Project), seen.ConsumerID, seen.Provider)
+	_, err := client.GetMongoClient().Update(ctx, model.CollectionDepSeen, filter, bson.M{"$set": seen},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+func findDepSeens(ctx context.Context, filter interface{}) ([]*proto.DependencySeen, error) {
+	cursor, err := client.GetMongoClient().Find(ctx, model.CollectionDepSeen, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var seens []*proto.DependencySeen
+	for cursor.Next(ctx) {
+		var tmp model.DependencySeen
+		if err := cursor.Decode(&tmp); err != nil {
+			return nil, err
+		}
+		seens = append(seens, &proto.DependencySeen{
+			DomainProject: util.ToDomainProject(tmp.Domain, tmp.Project),
+			ConsumerId:    tmp.ConsumerID,
+			Provider:      tmp.Provider,
+			LastSeen:      tmp.LastSeen,
+		})
+	}
+	return seens, cursor.Err()
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// dlockTTL is the lease of the dlock, the lock held by a crashed
+// service center is released after it expires
+const dlockTTL = 10 * time.Minute
+
+// lockOwner identifies the dlocks held by this service center
+var lockOwner = util.GenerateUUID()
+
+func EnsureLock() {
+	err := client.GetMongoClient().GetDB().CreateCollection(context.Background(), model.CollectionLock, options.CreateCollection().SetValidator(nil))
+	wrapCreateCollectionError(err)
+
+	lockIndex := mutil.BuildIndexDoc(model.ColumnLockID)
+	lockIndex.Options = options.Index().SetUnique(true)
+	err = client.GetMongoClient().CreateIndexes(context.Background(), model.CollectionLock, []mongo.IndexModel{lockIndex})
+	wrapCreateIndexesError(err)
+}
+
+func (ds *DataSource) DLock(ctx context.Context, request *datasource.DLockRequest) error {
+	for retries := 0; ; retries++ {
+		err := tryLock(ctx, request.ID)
+		if err == nil || !request.Wait {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff.GetBackoff().Delay(retries)):
+		}
+	}
+}
+
+// tryLock holds the lock if it is not held or expired, the unique index of
+// the lock id fails the upsert if another service center holds it
+func tryLock(ctx context.Context, id string) error {
+	now := time.Now()
+	filter := bson.M{
+		model.ColumnLockID: id,
+		"$or": bson.A{
+			bson.M{model.ColumnOwner: lockOwner},
+			bson.M{model.ColumnExpireAt: bson.M{"$lt": now.Unix()}},
+		},
+	}
+	update := bson.M{"$set": &model.Lock{
+		LockID:   id,
+		Owner:    lockOwner,
+		ExpireAt: now.Add(dlockTTL).Unix(),
+	}}
+	_, err := client.GetMongoClient().Update(ctx, model.CollectionLock, filter, update, options.Update().SetUpsert(true))
+	if err != nil && mutil.IsDuplicateKey(err) {
+		return fmt.Errorf("lock %s is held by another service center", id)
+	}
+	return err
+}
+
+func (ds *DataSource) DUnlock(ctx context.Context, request *datasource.DUnlockRequest) error {
+	result, err := client.GetMongoClient().DeleteOne(ctx, model.CollectionLock, bson.M{
+		model.ColumnLockID: request.ID,
+		model.ColumnOwner:  lockOwner,
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return datasource.ErrDLockNotFound
+	}
+	return nil
+}
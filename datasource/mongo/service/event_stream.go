@@ -0,0 +1,309 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+const (
+	opInsert  = "insert"
+	opUpdate  = "update"
+	opReplace = "replace"
+	opDelete  = "delete"
+)
+
+var registryCollections = []string{
+	model.CollectionService,
+	model.CollectionInstance,
+	model.CollectionSchema,
+	model.CollectionRule,
+	model.CollectionDep,
+}
+
+type changeEvent struct {
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	Ns            struct {
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey       bson.Raw `bson:"documentKey"`
+	FullDocument      bson.Raw `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.Raw `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+// registryWatcher converts the changes of the domain project to the registry events,
+// the delete changes only contain the document id, so it remembers the resources
+// of the documents to resolve the deleted ones
+type registryWatcher struct {
+	domain  string
+	project string
+	// collection/document id -> the resource without value
+	resources map[string]*datasource.RegistryEvent
+}
+
+func (ds *DataSource) WatchRegistryEvents(ctx context.Context, domainProject string, rev int64,
+	f datasource.RegistryEventFunc) error {
+	domain, project := util.FromDomainProject(domainProject)
+	w := &registryWatcher{
+		domain:    domain,
+		project:   project,
+		resources: make(map[string]*datasource.RegistryEvent),
+	}
+
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if rev > 0 {
+		next := client.ToTimestamp(rev + 1)
+		opts.SetStartAtOperationTime(&next)
+	}
+	stream, err := client.GetMongoClient().WatchDB(ctx, w.pipeline(), opts)
+	if err != nil {
+		return toWatchError(err, rev, domainProject)
+	}
+	defer stream.Close(context.Background())
+
+	// load the existing documents after the stream opened, the ones created later
+	// are remembered from the insert changes
+	if err := w.load(ctx); err != nil {
+		return err
+	}
+
+	for stream.Next(ctx) {
+		var change changeEvent
+		if err := bson.Unmarshal(stream.Current, &change); err != nil {
+			log.Error("failed to decode the change event", err)
+			continue
+		}
+		for _, evt := range w.toRegistryEvents(&change) {
+			if err := f(evt); err != nil {
+				return err
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return toWatchError(stream.Err(), rev, domainProject)
+}
+
+func toWatchError(err error, rev int64, domainProject string) error {
+	if mutil.IsHistoryLost(err) {
+		log.Warnf("the revision %d of domain project[%s] has been lost in oplog", rev, domainProject)
+		return datasource.ErrRevisionCompacted
+	}
+	return err
+}
+
+func (w *registryWatcher) pipeline() mongo.Pipeline {
+	return mongo.Pipeline{{{Key: "$match", Value: bson.D{
+		{Key: "ns.coll", Value: bson.D{{Key: "$in", Value: registryCollections}}},
+		{Key: "$or", Value: bson.A{
+			bson.D{
+				{Key: "fullDocument." + model.ColumnDomain, Value: w.domain},
+				{Key: "fullDocument." + model.ColumnProject, Value: w.project},
+			},
+			bson.D{{Key: "operationType", Value: opDelete}},
+		}},
+		// ignore the heartbeats
+		{Key: "updateDescription.updatedFields." + model.ColumnRefreshTime, Value: bson.D{{Key: "$exists", Value: false}}},
+	}}}}
+}
+
+func (w *registryWatcher) load(ctx context.Context) error {
+	filter := bson.M{model.ColumnDomain: w.domain, model.ColumnProject: w.project}
+	for _, coll := range registryCollections {
+		opts := options.Find()
+		if coll == model.CollectionSchema {
+			opts.SetProjection(bson.M{model.ColumnSchema: 0, model.ColumnSchemaSummary: 0})
+		}
+		cursor, err := client.GetMongoClient().Find(ctx, coll, filter, opts)
+		if err != nil {
+			log.Error("failed to load "+coll, err)
+			return err
+		}
+		for cursor.Next(ctx) {
+			w.remember(coll, cursor.Current.Lookup("_id"), cursor.Current)
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *registryWatcher) remember(coll string, id bson.RawValue, doc bson.Raw) *datasource.RegistryEvent {
+	evt := toRegistryEvent(coll, doc)
+	if evt == nil {
+		return nil
+	}
+	w.resources[coll+"/"+string(id.Value)] = &datasource.RegistryEvent{
+		Resource:  evt.Resource,
+		ServiceID: evt.ServiceID,
+		ID:        evt.ID,
+	}
+	return evt
+}
+
+func (w *registryWatcher) toRegistryEvents(change *changeEvent) []*datasource.RegistryEvent {
+	coll, rev := change.Ns.Coll, client.ToRevision(change.ClusterTime)
+	id := change.DocumentKey.Lookup("_id")
+	if change.OperationType == opDelete {
+		key := coll + "/" + string(id.Value)
+		resource, ok := w.resources[key]
+		if !ok {
+			return nil
+		}
+		delete(w.resources, key)
+		evt := *resource
+		evt.Revision, evt.Action = rev, pb.EVT_DELETE
+		return []*datasource.RegistryEvent{&evt}
+	}
+	if len(change.FullDocument) == 0 {
+		// deleted before looking up
+		return nil
+	}
+
+	evt := w.remember(coll, id, change.FullDocument)
+	if evt == nil {
+		return nil
+	}
+	evt.Revision, evt.Action = rev, pb.EVT_UPDATE
+	if change.OperationType == opInsert {
+		evt.Action = pb.EVT_CREATE
+	}
+	if coll != model.CollectionService {
+		return []*datasource.RegistryEvent{evt}
+	}
+
+	// the tags are stored in the service document
+	serviceChanged, tagChanged, tagRemoved := change.OperationType != opUpdate, false, false
+	fields, _ := change.UpdateDescription.UpdatedFields.Elements()
+	for _, field := range fields {
+		if isTagField(field.Key()) {
+			tagChanged = true
+			continue
+		}
+		serviceChanged = true
+	}
+	for _, field := range change.UpdateDescription.RemovedFields {
+		if isTagField(field) {
+			tagChanged, tagRemoved = true, field == model.ColumnTag
+			continue
+		}
+		serviceChanged = true
+	}
+	var evts []*datasource.RegistryEvent
+	if serviceChanged {
+		evts = append(evts, evt)
+	}
+	if tags := change.FullDocument.Lookup(model.ColumnTag); tagChanged || (change.OperationType == opInsert && len(tags.Value) > 0) {
+		tagEvt := &datasource.RegistryEvent{
+			Revision:  rev,
+			Action:    evt.Action,
+			Resource:  datasource.ResourceTag,
+			ServiceID: evt.ServiceID,
+		}
+		if tagRemoved {
+			tagEvt.Action = pb.EVT_DELETE
+		} else {
+			var value map[string]string
+			_ = tags.Unmarshal(&value)
+			tagEvt.Value, _ = json.Marshal(value)
+		}
+		evts = append(evts, tagEvt)
+	}
+	return evts
+}
+
+func isTagField(field string) bool {
+	return field == model.ColumnTag || strings.HasPrefix(field, model.ColumnTag+".")
+}
+
+// toRegistryEvent decodes the document of the registry collection,
+// returns nil if the document is not a registry resource
+func toRegistryEvent(coll string, doc bson.Raw) *datasource.RegistryEvent {
+	var (
+		evt   *datasource.RegistryEvent
+		value interface{}
+	)
+	switch coll {
+	case model.CollectionService:
+		var service model.Service
+		if err := bson.Unmarshal(doc, &service); err != nil || service.Service == nil {
+			return nil
+		}
+		evt = &datasource.RegistryEvent{Resource: datasource.ResourceService, ServiceID: service.Service.ServiceId}
+		value = service.Service
+	case model.CollectionInstance:
+		var instance model.Instance
+		if err := bson.Unmarshal(doc, &instance); err != nil || instance.Instance == nil {
+			return nil
+		}
+		evt = &datasource.RegistryEvent{Resource: datasource.ResourceInstance,
+			ServiceID: instance.Instance.ServiceId, ID: instance.Instance.InstanceId}
+		value = instance.Instance
+	case model.CollectionSchema:
+		var schema model.Schema
+		if err := bson.Unmarshal(doc, &schema); err != nil {
+			return nil
+		}
+		evt = &datasource.RegistryEvent{Resource: datasource.ResourceSchema,
+			ServiceID: schema.ServiceID, ID: schema.SchemaID}
+		value = &pb.Schema{SchemaId: schema.SchemaID, Summary: schema.SchemaSummary, Schema: schema.Schema}
+	case model.CollectionRule:
+		var rule model.Rule
+		if err := bson.Unmarshal(doc, &rule); err != nil || rule.Rule == nil {
+			return nil
+		}
+		evt = &datasource.RegistryEvent{Resource: datasource.ResourceRule,
+			ServiceID: rule.ServiceID, ID: rule.Rule.RuleId}
+		value = rule.Rule
+	case model.CollectionDep:
+		var rule model.DependencyRule
+		if err := bson.Unmarshal(doc, &rule); err != nil || rule.ServiceKey == nil {
+			return nil
+		}
+		key := rule.ServiceKey
+		evt = &datasource.RegistryEvent{Resource: datasource.ResourceDependency,
+			ID: strings.Join([]string{rule.Type, key.Environment, key.AppId, key.ServiceName, key.Version}, "/")}
+		value = rule.Dep
+	default:
+		return nil
+	}
+	evt.Value, _ = json.Marshal(value)
+	return evt
+}
@@ -1022,6 +1022,7 @@ func getInstance(ctx context.Context, request *discovery.FindInstancesRequest, p
 				Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
 			}, err
 		}
+		datasource.RefreshDependencySeen(ctx, request.ConsumerServiceId, provider)
 	}
 	newRev, _ := formatRevision(request.ConsumerServiceId, instances)
 	if rev == newRev {
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+
+	"github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// BatchRegisterInstances registers the instances one by one,
+// mongo does not need the transaction because each instance is a single document
+func (ds *DataSource) BatchRegisterInstances(ctx context.Context, request *proto.BatchRegisterInstancesRequest) (
+	*proto.BatchInstancesResponse, error) {
+	results := make([]*discovery.InstanceHbRst, 0, len(request.Instances))
+	for _, instance := range request.Instances {
+		result := &discovery.InstanceHbRst{ServiceId: instance.ServiceId, InstanceId: instance.InstanceId}
+		results = append(results, result)
+		resp, err := ds.RegisterInstance(ctx, &discovery.RegisterInstanceRequest{Instance: instance})
+		switch {
+		case err != nil:
+			result.ErrMessage = err.Error()
+		case !resp.Response.IsSucceed():
+			result.ErrMessage = resp.Response.GetMessage()
+		default:
+			result.InstanceId = resp.InstanceId
+		}
+	}
+	return datasource.ToBatchInstancesResponse(results, "register", util.GetIPFromContext(ctx)), nil
+}
+
+func (ds *DataSource) BatchUnregisterInstances(ctx context.Context, request *proto.BatchUnregisterInstancesRequest) (
+	*proto.BatchInstancesResponse, error) {
+	results := make([]*discovery.InstanceHbRst, 0, len(request.Instances))
+	for _, element := range request.Instances {
+		result := &discovery.InstanceHbRst{ServiceId: element.ServiceId, InstanceId: element.InstanceId}
+		results = append(results, result)
+		resp, err := ds.UnregisterInstance(ctx, &discovery.UnregisterInstanceRequest{
+			ServiceId:  element.ServiceId,
+			InstanceId: element.InstanceId,
+		})
+		switch {
+		case err != nil:
+			result.ErrMessage = err.Error()
+		case !resp.Response.IsSucceed():
+			result.ErrMessage = resp.Response.GetMessage()
+		}
+	}
+	return datasource.ToBatchInstancesResponse(results, "unregister", util.GetIPFromContext(ctx)), nil
+}
@@ -448,7 +448,7 @@ func (ds *DataSource) modifySchema(ctx context.Context, serviceID string, schema
 }
 
 func (ds *DataSource) isSchemaEditable(service *discovery.MicroService) bool {
-	return (len(service.Environment) != 0 && service.Environment != discovery.ENV_PROD) || ds.SchemaEditable
+	return datasource.SchemaEnvironment(service.Environment) != discovery.ENV_PROD || ds.SchemaEditable
 }
 
 func schemaSummaryExist(ctx context.Context, serviceID, schemaID string) (bool, error) {
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+var columnRevisionNumber = mutil.ConnectWithDot([]string{model.ColumnRevision, model.ColumnRevision})
+
+func (ds *DataSource) AddSchemaRevision(ctx context.Context, serviceID, schemaID string,
+	revision *proto.SchemaRevision) error {
+	if ds.SchemaHistorySize <= 0 {
+		return nil
+	}
+	filter := mutil.NewBasicFilter(ctx, mutil.ServiceID(serviceID), mutil.SchemaID(schemaID))
+	latest, err := findSchemaRevision(ctx, filter,
+		options.FindOne().SetSort(bson.M{columnRevisionNumber: -1}))
+	if err != nil && !errors.Is(err, datasource.ErrSchemaRevisionNotExists) {
+		return err
+	}
+	if latest != nil && latest.Hash == revision.Hash && latest.Summary == revision.Summary {
+		return nil
+	}
+	revision.Revision = 1
+	if latest != nil {
+		revision.Revision = latest.Revision + 1
+	}
+
+	// the unique index rejects the concurrent modifications with the same revision
+	_, err = client.GetMongoClient().Insert(ctx, model.CollectionSchemaRevision, &model.SchemaRevision{
+		Domain:    util.ParseDomain(ctx),
+		Project:   util.ParseProject(ctx),
+		ServiceID: serviceID,
+		SchemaID:  schemaID,
+		Revision:  revision,
+	})
+	if err != nil {
+		log.Errorf(err, "add schema[%s/%s] revision %d failed", serviceID, schemaID, revision.Revision)
+		return err
+	}
+
+	// prune the oldest revisions out of the history size
+	filter[columnRevisionNumber] = bson.M{"$lte": revision.Revision - int64(ds.SchemaHistorySize)}
+	_, err = client.GetMongoClient().Delete(ctx, model.CollectionSchemaRevision, filter)
+	if err != nil {
+		log.Errorf(err, "prune schema[%s/%s] revisions failed", serviceID, schemaID)
+	}
+	return nil
+}
+
+func (ds *DataSource) ListSchemaRevisions(ctx context.Context, serviceID, schemaID string) ([]*proto.SchemaRevision, error) {
+	filter := mutil.NewBasicFilter(ctx, mutil.ServiceID(serviceID), mutil.SchemaID(schemaID))
+	cursor, err := client.GetMongoClient().Find(ctx, model.CollectionSchemaRevision, filter,
+		options.Find().SetSort(bson.M{columnRevisionNumber: -1}))
+	if err != nil {
+		log.Errorf(err, "list schema[%s/%s] revisions failed", serviceID, schemaID)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var revisions []*proto.SchemaRevision
+	for cursor.Next(ctx) {
+		var tmp model.SchemaRevision
+		if err := cursor.Decode(&tmp); err != nil {
+			return nil, err
+		}
+		if tmp.Revision == nil {
+			continue
+		}
+		tmp.Revision.Schema = ""
+		revisions = append(revisions, tmp.Revision)
+	}
+	return revisions, nil
+}
+
+func (ds *DataSource) GetSchemaRevision(ctx context.Context, serviceID, schemaID string,
+	revision int64) (*proto.SchemaRevision, error) {
+	filter := mutil.NewBasicFilter(ctx, mutil.ServiceID(serviceID), mutil.SchemaID(schemaID))
+	filter[columnRevisionNumber] = revision
+	return findSchemaRevision(ctx, filter)
+}
+
+func findSchemaRevision(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) (*proto.SchemaRevision, error) {
+	findRes, err := client.GetMongoClient().FindOne(ctx, model.CollectionSchemaRevision, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if findRes.Err() != nil {
+		return nil, datasource.ErrSchemaRevisionNotExists
+	}
+	var tmp model.SchemaRevision
+	if err := findRes.Decode(&tmp); err != nil {
+		return nil, err
+	}
+	if tmp.Revision == nil {
+		return nil, datasource.ErrSchemaRevisionNotExists
+	}
+	return tmp.Revision, nil
+}
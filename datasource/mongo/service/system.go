@@ -19,12 +19,15 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-chassis/cari/discovery"
 	"github.com/patrickmn/go-cache"
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/sd"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/util"
@@ -39,14 +42,77 @@ func (ds *DataSource) DumpCache(ctx context.Context) *dump.Cache {
 	return &cache
 }
 
-func (ds *DataSource) DLock(ctx context.Context, request *datasource.DLockRequest) error {
-	return nil
+// DumpSelfPreservation returns an empty status, mongo does not defer instance DELETE events
+func (ds *DataSource) DumpSelfPreservation(ctx context.Context) *dump.SelfPreservation {
+	return &dump.SelfPreservation{}
+}
+
+// ListLeases returns the heartbeat status of the instances,
+// mongo uses the refresh time of the instance as the lease
+func (ds *DataSource) ListLeases(ctx context.Context, serviceID string) ([]*dump.Lease, error) {
+	filter := mutil.NewBasicFilter(ctx, mutil.InstanceServiceID(serviceID))
+	instances, err := findInstances(ctx, filter)
+	if err != nil {
+		return nil, discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	leases := make([]*dump.Lease, 0, len(instances))
+	for _, instance := range instances {
+		leases = append(leases, toLease(instance))
+	}
+	return leases, nil
 }
 
-func (ds *DataSource) DUnlock(ctx context.Context, request *datasource.DUnlockRequest) error {
+func (ds *DataSource) ExpireLease(ctx context.Context, serviceID, instanceID string) error {
+	resp, err := ds.UnregisterInstance(ctx, &discovery.UnregisterInstanceRequest{
+		ServiceId:  serviceID,
+		InstanceId: instanceID,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Response.IsSucceed() {
+		return discovery.NewError(resp.Response.GetCode(), resp.Response.GetMessage())
+	}
 	return nil
 }
 
+func (ds *DataSource) ExtendLease(ctx context.Context, serviceID, instanceID string) (*dump.Lease, error) {
+	resp, err := ds.Heartbeat(ctx, &discovery.HeartbeatRequest{
+		ServiceId:  serviceID,
+		InstanceId: instanceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Response.IsSucceed() {
+		return nil, discovery.NewError(resp.Response.GetCode(), resp.Response.GetMessage())
+	}
+	filter := mutil.NewBasicFilter(ctx, mutil.InstanceServiceID(serviceID), mutil.InstanceInstanceID(instanceID))
+	instance, err := findInstance(ctx, filter)
+	if err != nil {
+		return nil, discovery.NewError(discovery.ErrInstanceNotExists, err.Error())
+	}
+	return toLease(instance), nil
+}
+
+func toLease(instance *model.Instance) *dump.Lease {
+	var ttl int64
+	if hc := instance.Instance.HealthCheck; hc != nil {
+		ttl = int64(hc.Interval * (hc.Times + 1))
+	}
+	remaining := ttl - int64(time.Since(instance.RefreshTime)/time.Second)
+	if remaining < 0 {
+		remaining = -1
+	}
+	return &dump.Lease{
+		ServiceID:     instance.Instance.ServiceId,
+		InstanceID:    instance.Instance.InstanceId,
+		TTL:           ttl,
+		Remaining:     remaining,
+		LastRenewTime: instance.RefreshTime.Unix(),
+	}
+}
+
 func setServiceValue(e *sd.MongoCacher, setter dump.Setter) {
 	e.Cache().ForEach(func(k string, kv interface{}) (next bool) {
 		service := kv.(cache.Item).Object.(model.Service)
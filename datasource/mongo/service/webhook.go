@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+func EnsureWebhook() {
+	uniqueIndexes := map[string][]string{
+		model.CollectionWebhook:         {model.ColumnWebhook, model.ColumnID},
+		model.CollectionWebhookDelivery: {model.ColumnDelivery, model.ColumnWebhookID, model.ColumnID},
+	}
+	for collection, columns := range uniqueIndexes {
+		err := client.GetMongoClient().GetDB().CreateCollection(context.Background(), collection, options.CreateCollection().SetValidator(nil))
+		wrapCreateCollectionError(err)
+
+		keys := []string{model.ColumnDomain, model.ColumnProject}
+		for _, column := range columns[1:] {
+			keys = append(keys, mutil.ConnectWithDot([]string{columns[0], column}))
+		}
+		uniqueIndex := mutil.BuildIndexDoc(keys...)
+		uniqueIndex.Options = options.Index().SetUnique(true)
+
+		err = client.GetMongoClient().CreateIndexes(context.Background(), collection, []mongo.IndexModel{uniqueIndex})
+		wrapCreateIndexesError(err)
+	}
+}
+
+func (ds *DataSource) GetWebhookSubscription(ctx context.Context, domainProject,
+	webhookID string) (*webhookpb.Webhook, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnWebhook, bson.M{model.ColumnID: webhookID})
+	doc := &model.Webhook{}
+	ok, err := findBrokerData(ctx, model.CollectionWebhook, filter, doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Webhook, nil
+}
+
+func (ds *DataSource) ListWebhookSubscriptions(ctx context.Context, domainProject string) ([]*webhookpb.Webhook, error) {
+	var webhooks []*webhookpb.Webhook
+	err := listBrokerData(ctx, model.CollectionWebhook, newBrokerFilter(domainProject, "", nil),
+		func(decode func(interface{}) error) error {
+			doc := &model.Webhook{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			webhooks = append(webhooks, doc.Webhook)
+			return nil
+		})
+	return webhooks, err
+}
+
+func (ds *DataSource) PutWebhookSubscription(ctx context.Context, domainProject string,
+	webhook *webhookpb.Webhook) error {
+	domain, project := util.FromDomainProject(domainProject)
+	filter := newBrokerFilter(domainProject, model.ColumnWebhook, bson.M{model.ColumnID: webhook.Id})
+	return upsertBrokerData(ctx, model.CollectionWebhook, filter, &model.Webhook{
+		Domain:  domain,
+		Project: project,
+		Webhook: webhook,
+	})
+}
+
+func (ds *DataSource) DeleteWebhookSubscription(ctx context.Context, domainProject, webhookID string) error {
+	filter := newBrokerFilter(domainProject, model.ColumnWebhook, bson.M{model.ColumnID: webhookID})
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionWebhook, filter)
+	if err != nil {
+		return err
+	}
+	filter = newBrokerFilter(domainProject, model.ColumnDelivery, bson.M{model.ColumnWebhookID: webhookID})
+	_, err = client.GetMongoClient().Delete(ctx, model.CollectionWebhookDelivery, filter)
+	return err
+}
+
+func (ds *DataSource) ListWebhookDeliveries(ctx context.Context, domainProject,
+	webhookID string) ([]*webhookpb.Delivery, error) {
+	filter := newBrokerFilter(domainProject, model.ColumnDelivery, bson.M{model.ColumnWebhookID: webhookID})
+	var deliveries []*webhookpb.Delivery
+	err := listBrokerData(ctx, model.CollectionWebhookDelivery, filter,
+		func(decode func(interface{}) error) error {
+			doc := &model.WebhookDelivery{}
+			if err := decode(doc); err != nil {
+				return err
+			}
+			deliveries = append(deliveries, doc.Delivery)
+			return nil
+		})
+	return deliveries, err
+}
+
+func (ds *DataSource) CreateWebhookDelivery(ctx context.Context, domainProject string,
+	delivery *webhookpb.Delivery) (bool, error) {
+	domain, project := util.FromDomainProject(domainProject)
+	_, err := client.GetMongoClient().Insert(ctx, model.CollectionWebhookDelivery, &model.WebhookDelivery{
+		Domain:   domain,
+		Project:  project,
+		Delivery: delivery,
+	})
+	if mutil.IsDuplicateKey(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (ds *DataSource) PutWebhookDelivery(ctx context.Context, domainProject string,
+	delivery *webhookpb.Delivery) error {
+	domain, project := util.FromDomainProject(domainProject)
+	filter := newBrokerFilter(domainProject, model.ColumnDelivery, bson.M{
+		model.ColumnWebhookID: delivery.WebhookId,
+		model.ColumnID:        delivery.Id,
+	})
+	return upsertBrokerData(ctx, model.CollectionWebhookDelivery, filter, &model.WebhookDelivery{
+		Domain:   domain,
+		Project:  project,
+		Delivery: delivery,
+	})
+}
+
+func (ds *DataSource) DeleteWebhookDelivery(ctx context.Context, domainProject, webhookID, deliveryID string) error {
+	filter := newBrokerFilter(domainProject, model.ColumnDelivery, bson.M{
+		model.ColumnWebhookID: webhookID,
+		model.ColumnID:        deliveryID,
+	})
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionWebhookDelivery, filter)
+	return err
+}
@@ -25,8 +25,10 @@ import (
 )
 
 const (
-	DuplicateKey      = "E11000"
-	CollectionsExists = 48
+	DuplicateKey            = "E11000"
+	CollectionsExists       = 48
+	ChangeStreamFatalError  = 280
+	ChangeStreamHistoryLost = 286
 )
 
 var (
@@ -57,6 +59,18 @@ func IsCollectionsExist(err error) bool {
 	return false
 }
 
+// IsHistoryLost returns true if the start point of the change stream
+// is no longer in the oplog
+func IsHistoryLost(err error) bool {
+	if err != nil {
+		cmdErr, ok := err.(mongo.CommandError)
+		if ok && (cmdErr.Code == ChangeStreamHistoryLost || cmdErr.Code == ChangeStreamFatalError) {
+			return true
+		}
+	}
+	return false
+}
+
 func IsNoneDocErr(err error) bool {
 	return err == ErrNoDocuments
 }
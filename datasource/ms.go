@@ -22,10 +22,13 @@ import (
 	"errors"
 
 	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 )
 
 var ErrServiceNotExists = errors.New("service does not exist")
 var ErrInstanceNotExists = errors.New("instance does not exist")
+var ErrSchemaRevisionNotExists = errors.New("schema revision does not exist")
 
 // Attention: request validation must be finished before the following interface being invoked!!!
 // MetadataManager contains the CRUD of cache metadata
@@ -71,6 +74,12 @@ type MetadataManager interface {
 		error)
 	Heartbeat(ctx context.Context, request *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error)
 	HeartbeatSet(ctx context.Context, request *pb.HeartbeatSetRequest) (*pb.HeartbeatSetResponse, error)
+	// BatchRegisterInstances registers the instances and returns the result of each one
+	BatchRegisterInstances(ctx context.Context, request *proto.BatchRegisterInstancesRequest) (
+		*proto.BatchInstancesResponse, error)
+	// BatchUnregisterInstances unregisters the instances and returns the result of each one
+	BatchUnregisterInstances(ctx context.Context, request *proto.BatchUnregisterInstancesRequest) (
+		*proto.BatchInstancesResponse, error)
 	BatchFind(ctx context.Context, request *pb.BatchFindInstancesRequest) (*pb.BatchFindInstancesResponse, error)
 	// GetAllInstances returns instances under the specified domain
 	GetAllInstances(ctx context.Context, request *pb.GetAllInstancesRequest) (*pb.GetAllInstancesResponse, error)
@@ -82,6 +91,13 @@ type MetadataManager interface {
 	GetSchema(ctx context.Context, request *pb.GetSchemaRequest) (*pb.GetSchemaResponse, error)
 	GetAllSchemas(ctx context.Context, request *pb.GetAllSchemaRequest) (*pb.GetAllSchemaResponse, error)
 	DeleteSchema(ctx context.Context, request *pb.DeleteSchemaRequest) (*pb.DeleteSchemaResponse, error)
+	// AddSchemaRevision appends the revision to the schema history, the revision
+	// number is assigned by datasource, it is skipped if the content is not changed
+	AddSchemaRevision(ctx context.Context, serviceID, schemaID string, revision *proto.SchemaRevision) error
+	// ListSchemaRevisions returns the schema history without content, the latest first
+	ListSchemaRevisions(ctx context.Context, serviceID, schemaID string) ([]*proto.SchemaRevision, error)
+	// GetSchemaRevision returns ErrSchemaRevisionNotExists if the revision is not in history
+	GetSchemaRevision(ctx context.Context, serviceID, schemaID string, revision int64) (*proto.SchemaRevision, error)
 
 	// Tag management
 	AddTags(ctx context.Context, request *pb.AddServiceTagsRequest) (*pb.AddServiceTagsResponse, error)
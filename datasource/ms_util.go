@@ -18,6 +18,10 @@
 package datasource
 
 import (
+	"fmt"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/core"
 	"github.com/go-chassis/cari/discovery"
@@ -102,3 +106,26 @@ func TransServiceToKey(domainProject string, service *discovery.MicroService) *d
 		Version:     service.Version,
 	}
 }
+
+// ToBatchInstancesResponse returns the batch response of the action, it fails if any instance fails
+func ToBatchInstancesResponse(results []*discovery.InstanceHbRst, action, remoteIP string) *proto.BatchInstancesResponse {
+	failed := 0
+	for _, result := range results {
+		if len(result.ErrMessage) > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		log.Errorf(nil, "batch %s instances failed, %d/%d failed, operator %s",
+			action, failed, len(results), remoteIP)
+		return &proto.BatchInstancesResponse{
+			Response:  discovery.CreateResponse(discovery.ErrInvalidParams, fmt.Sprintf("Batch %s instances failed.", action)),
+			Instances: results,
+		}
+	}
+	log.Infof("batch %s instances[%d] successfully, operator %s", action, len(results), remoteIP)
+	return &proto.BatchInstancesResponse{
+		Response:  discovery.CreateResponse(discovery.ResponseSuccess, fmt.Sprintf("Batch %s instances successfully.", action)),
+		Instances: results,
+	}
+}
@@ -22,6 +22,8 @@ type Options struct {
 	Kind           Kind
 	SslEnabled     bool
 	SchemaEditable bool
+	// SchemaHistorySize: the max revisions of a schema kept in history
+	SchemaHistorySize int
 	// InstanceTTL: the default ttl of instance lease
 	InstanceTTL int64
 	// TODO: pay attention to more net config like TLSConfig when coding
@@ -16,9 +16,29 @@
 package datasource
 
 import (
+	"sort"
+
 	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 )
 
+// SchemaEnvironment returns the environment to apply the schema policies,
+// the service without environment is treated as production
+func SchemaEnvironment(env string) string {
+	if len(env) == 0 {
+		return pb.ENV_PROD
+	}
+	return env
+}
+
+// SortSchemaRevisions sorts the revisions by the revision number, the latest first
+func SortSchemaRevisions(revisions []*proto.SchemaRevision) {
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+}
+
 // @titile SchemasAnalysis
 // @description schemasanlysis decide the schema to be deleted,updated or added
 // @param schemas []*pb.Schema "the schemas from request"
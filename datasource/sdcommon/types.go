@@ -60,7 +60,8 @@ type Resource struct {
 
 	// Index is the index of the resource
 	Index string
-	// this is only for etcd
+	// this is only for etcd, except ModRevision which is
+	// the cluster time of the change in the mongo change stream
 	CreateRevision int64
 	ModRevision    int64
 	Version        int64
@@ -26,6 +26,13 @@ import (
 // SystemManager contains the APIs of system management
 type SystemManager interface {
 	DumpCache(ctx context.Context) *dump.Cache
+	DumpSelfPreservation(ctx context.Context) *dump.SelfPreservation
+	// ListLeases returns the liveness leases of the service instances
+	ListLeases(ctx context.Context, serviceID string) ([]*dump.Lease, error)
+	// ExpireLease makes the instance lease expired immediately
+	ExpireLease(ctx context.Context, serviceID, instanceID string) error
+	// ExtendLease renews the instance lease as a heartbeat does
+	ExtendLease(ctx context.Context, serviceID, instanceID string) (*dump.Lease, error)
 	DLock(ctx context.Context, request *DLockRequest) error
 	DUnlock(ctx context.Context, request *DUnlockRequest) error
 }
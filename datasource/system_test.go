@@ -61,3 +61,64 @@ func TestDumpCache(t *testing.T) {
 		assert.NotNil(t, cache)
 	})
 }
+
+func TestLease(t *testing.T) {
+	var serviceID, instanceID string
+	t.Run("Register service && instance, should pass", func(t *testing.T) {
+		service, err := datasource.Instance().RegisterService(getContext(), &pb.CreateServiceRequest{
+			Service: &pb.MicroService{
+				ServiceName: "lease_service_test",
+				AppId:       "lease_service_appId",
+				Version:     "1.0.0",
+				Level:       "BACK",
+				Status:      pb.MS_UP,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, service.Response.GetCode())
+		serviceID = service.ServiceId
+
+		instance, err := datasource.Instance().RegisterInstance(getContext(), &pb.RegisterInstanceRequest{
+			Instance: &pb.MicroServiceInstance{
+				ServiceId: serviceID,
+				Endpoints: []string{
+					"lease:127.0.0.1:8080",
+				},
+				HostName: "HOST_TEST",
+				Status:   pb.MSI_UP,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, instance.Response.GetCode())
+		instanceID = instance.InstanceId
+	})
+
+	t.Run("list and extend the lease, should pass", func(t *testing.T) {
+		leases, err := datasource.Instance().ListLeases(getContext(), serviceID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(leases))
+		assert.Equal(t, instanceID, leases[0].InstanceID)
+		assert.True(t, leases[0].TTL > 0)
+		assert.True(t, leases[0].Remaining >= 0)
+
+		lease, err := datasource.Instance().ExtendLease(getContext(), serviceID, instanceID)
+		assert.NoError(t, err)
+		assert.Equal(t, instanceID, lease.InstanceID)
+	})
+
+	t.Run("expire the lease, should pass", func(t *testing.T) {
+		err := datasource.Instance().ExpireLease(getContext(), serviceID, instanceID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDLock(t *testing.T) {
+	t.Run("lock and unlock, should pass", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			err := datasource.Instance().DLock(getContext(), &datasource.DLockRequest{ID: "/cse-sr/lock/test-dlock"})
+			assert.NoError(t, err)
+			err = datasource.Instance().DUnlock(getContext(), &datasource.DUnlockRequest{ID: "/cse-sr/lock/test-dlock"})
+			assert.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+// WebhookManager contains the storage APIs of the registry webhooks,
+// the Get methods return nil without error if the data does not exist
+type WebhookManager interface {
+	GetWebhookSubscription(ctx context.Context, domainProject, webhookID string) (*webhookpb.Webhook, error)
+	ListWebhookSubscriptions(ctx context.Context, domainProject string) ([]*webhookpb.Webhook, error)
+	PutWebhookSubscription(ctx context.Context, domainProject string, webhook *webhookpb.Webhook) error
+	// DeleteWebhookSubscription deletes the webhook and its deliveries
+	DeleteWebhookSubscription(ctx context.Context, domainProject, webhookID string) error
+
+	ListWebhookDeliveries(ctx context.Context, domainProject, webhookID string) ([]*webhookpb.Delivery, error)
+	// CreateWebhookDelivery returns false if the delivery of the id already exists,
+	// so that only one service center of the cluster sends the event
+	CreateWebhookDelivery(ctx context.Context, domainProject string, delivery *webhookpb.Delivery) (bool, error)
+	PutWebhookDelivery(ctx context.Context, domainProject string, delivery *webhookpb.Delivery) error
+	DeleteWebhookDelivery(ctx context.Context, domainProject, webhookID, deliveryID string) error
+}
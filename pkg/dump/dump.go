@@ -221,6 +221,8 @@ type Response struct {
 	Info      *version.Set           `json:"info,omitempty"`
 	AppConfig map[string]interface{} `json:"appConf,omitempty"`
 	Cache     *Cache                 `json:"cache,omitempty"`
+	// SelfPreservation is the status of instance DELETE events self preservation
+	SelfPreservation *SelfPreservation `json:"selfPreservation,omitempty"`
 }
 
 type WatchInstanceChangedEvent struct {
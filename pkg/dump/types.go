@@ -43,3 +43,134 @@ type ClearAlarmRequest struct {
 type ClearAlarmResponse struct {
 	Response *discovery.Response `json:"-"`
 }
+
+type SelfPreservation struct {
+	Enabled    bool             `json:"enabled"`
+	Percentage float64          `json:"percentage"`
+	MaxTTL     int32            `json:"maxTTL"`
+	InitCount  int              `json:"initCount"`
+	Deferred   []*DeferredEvent `json:"deferred,omitempty"`
+}
+
+type DeferredEvent struct {
+	Key string `json:"key"`
+	// ReplayAfter is the remaining seconds before the DELETE event replay
+	ReplayAfter int32 `json:"replayAfter"`
+	// ReplayAt is the estimated unix timestamp of the replay
+	ReplayAt int64 `json:"replayAt"`
+}
+
+type UpdateWeightRequest struct {
+	ServiceID string `json:"-"`
+	// InstanceID is empty means update all instances of the service
+	InstanceID string `json:"-"`
+	Weight     int    `json:"weight"`
+}
+
+type UpdateWeightResponse struct {
+	Response *discovery.Response `json:"-"`
+	Updated  []string            `json:"updated,omitempty"`
+}
+
+type Lease struct {
+	ServiceID  string `json:"serviceId"`
+	InstanceID string `json:"instanceId"`
+	// LeaseID is the backend lease id, 0 if the backend does not use lease
+	LeaseID int64 `json:"leaseId,omitempty"`
+	// TTL is the granted seconds of the lease
+	TTL int64 `json:"ttl"`
+	// Remaining is the remaining seconds before the lease expires, -1 means expired
+	Remaining int64 `json:"remaining"`
+	// LastRenewTime is the unix timestamp of the last heartbeat
+	LastRenewTime int64 `json:"lastRenewTime"`
+}
+
+type LeasesRequest struct {
+	ServiceID string `json:"-"`
+}
+
+type LeasesResponse struct {
+	Response *discovery.Response `json:"-"`
+	Leases   []*Lease            `json:"leases,omitempty"`
+}
+
+const (
+	LeaseActionExpire = "expire"
+	LeaseActionExtend = "extend"
+)
+
+type UpdateLeaseRequest struct {
+	ServiceID  string `json:"-"`
+	InstanceID string `json:"-"`
+	// Action is one of expire and extend
+	Action string `json:"action"`
+}
+
+type SplitTrafficRequest struct {
+	Environment string `json:"environment,omitempty"`
+	AppID       string `json:"appId"`
+	ServiceName string `json:"serviceName"`
+	// Percents is the traffic percentage of each version, the sum must be 100
+	Percents map[string]int `json:"percents"`
+}
+
+type TopTalkersRequest struct {
+	// By is one of domain, consumer and ip, all of them if empty
+	By  string `json:"-"`
+	Top int    `json:"-"`
+}
+
+// Talker is the request count of a domain/project, consumer service id or source ip
+type Talker struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+type TopTalkersResponse struct {
+	Response  *discovery.Response `json:"-"`
+	Window    string              `json:"window"`
+	Domains   []*Talker           `json:"domains,omitempty"`
+	Consumers []*Talker           `json:"consumers,omitempty"`
+	IPs       []*Talker           `json:"ips,omitempty"`
+}
+
+type ReloadConfigRequest struct {
+}
+
+// ConfigCallback is the result of a subsystem handling the changed configs
+type ConfigCallback struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+type ReloadConfigResponse struct {
+	Response  *discovery.Response `json:"-"`
+	Changed   []string            `json:"changed,omitempty"`
+	Callbacks []*ConfigCallback   `json:"callbacks,omitempty"`
+}
+
+// Logger is the level of the global logger or a module logger
+type Logger struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+	// Inherited is true if the module uses the global level
+	Inherited bool `json:"inherited,omitempty"`
+	// RevertAt is the time the level reverts in RFC3339
+	RevertAt string `json:"revertAt,omitempty"`
+}
+
+type LoggersRequest struct {
+}
+
+type LoggersResponse struct {
+	Response *discovery.Response `json:"-"`
+	Loggers  []*Logger           `json:"loggers,omitempty"`
+}
+
+type UpdateLoggerRequest struct {
+	Name string `json:"-"`
+	// Level is empty to make the module inherit the global level
+	Level string `json:"level"`
+	// Duration is the time the level lasts, e.g. 10m, forever if empty
+	Duration string `json:"duration,omitempty"`
+}
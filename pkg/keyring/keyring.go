@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keyring
+
+import (
+	"crypto/aes"
+	gocipher "crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// KeyLength is the length of the AES-256 key
+	KeyLength = 32
+	// Prefix marks a value encrypted by the keyring, the format is 'aesgcm:<key id>:<base64(nonce|ciphertext)>'
+	Prefix = "aesgcm:"
+)
+
+var (
+	ErrNoKey             = errors.New("no root key is configured")
+	ErrInvalidKey        = errors.New("invalid root key")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// Keyring holds the AES-256-GCM root keys, the first key encrypts the new values
+// and all of the keys decrypt the values by the key id in the ciphertext,
+// so a key can be rotated by putting a new key in front of the old ones
+type Keyring struct {
+	active string
+	keys   map[string]gocipher.AEAD
+}
+
+func New() *Keyring {
+	return &Keyring{keys: make(map[string]gocipher.AEAD)}
+}
+
+// Parse parses the keys in format '<key id>:<base64 key>',
+// the keys are separated by line breaks or commas and the lines start with '#' are ignored
+func Parse(s string) (*Keyring, error) {
+	k := New()
+	if err := k.Parse(s); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Load loads the keys from env value and the root key file,
+// the keys in env value take precedence
+func Load(env, file string) (*Keyring, error) {
+	k := New()
+	if err := k.Parse(env); err != nil {
+		return nil, fmt.Errorf("parse root key env failed: %v", err)
+	}
+	if len(file) == 0 {
+		return k, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.Parse(string(data)); err != nil {
+		return nil, fmt.Errorf("parse root key file %s failed: %v", file, err)
+	}
+	return k, nil
+}
+
+func (k *Keyring) Parse(s string) error {
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == ',' }) {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 0 {
+			return ErrInvalidKey
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return ErrInvalidKey
+		}
+		if err := k.Add(strings.TrimSpace(line[:i]), key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add adds a key, the first added key is the active one
+func (k *Keyring) Add(id string, key []byte) error {
+	if !validKeyID(id) {
+		return fmt.Errorf("%v: invalid key id '%s'", ErrInvalidKey, id)
+	}
+	if len(key) != KeyLength {
+		return fmt.Errorf("%v: key '%s' must be %d bytes", ErrInvalidKey, id, KeyLength)
+	}
+	if _, ok := k.keys[id]; ok {
+		return fmt.Errorf("%v: duplicate key id '%s'", ErrInvalidKey, id)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := gocipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	k.keys[id] = aead
+	if len(k.active) == 0 {
+		k.active = id
+	}
+	return nil
+}
+
+func (k *Keyring) Len() int {
+	return len(k.keys)
+}
+
+// Encrypt encrypts src with the active key, the key id is the additional data
+// so that the key id in ciphertext can not be replaced
+func (k *Keyring) Encrypt(src string) (string, error) {
+	aead, ok := k.keys[k.active]
+	if !ok {
+		return "", ErrNoKey
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(src)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(src), []byte(k.active))
+	return Prefix + k.active + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the ciphertext made by Encrypt
+func (k *Keyring) Decrypt(src string) (string, error) {
+	s := strings.TrimSpace(src)
+	if !strings.HasPrefix(s, Prefix) {
+		return "", ErrInvalidCiphertext
+	}
+	s = strings.TrimPrefix(s, Prefix)
+	i := strings.Index(s, ":")
+	if i < 0 {
+		return "", ErrInvalidCiphertext
+	}
+	id := s[:i]
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("unknown root key id '%s'", id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(s[i+1:])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plain), nil
+}
+
+// IsEncrypted returns true if src is made by Keyring, the surrounding spaces are ignored
+func IsEncrypted(src string) bool {
+	return strings.HasPrefix(strings.TrimSpace(src), Prefix)
+}
+
+// GenerateKey generates a random root key in the format accepted by Parse
+func GenerateKey(id string) (string, error) {
+	if !validKeyID(id) {
+		return "", fmt.Errorf("%v: invalid key id '%s'", ErrInvalidKey, id)
+	}
+	key := make([]byte, KeyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return id + ":" + base64.StdEncoding.EncodeToString(key), nil
+}
+
+func validKeyID(id string) bool {
+	if len(id) == 0 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
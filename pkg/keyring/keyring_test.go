@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keyring
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyring(t *testing.T) {
+	k1, _ := GenerateKey("k1")
+	k2, _ := GenerateKey("k2")
+
+	old, err := Parse(k1)
+	assert.NoError(t, err)
+	encrypted, err := old.Encrypt("password")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, Prefix+"k1:"))
+	assert.True(t, IsEncrypted(encrypted))
+	plain, err := old.Decrypt(encrypted + "\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "password", plain)
+
+	t.Run("rotate the key", func(t *testing.T) {
+		rotated, err := Parse("# new key first\n" + k2 + "\n" + k1)
+		assert.NoError(t, err)
+		plain, err := rotated.Decrypt(encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, "password", plain)
+
+		reEncrypted, err := rotated.Encrypt(plain)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(reEncrypted, Prefix+"k2:"))
+		_, err = old.Decrypt(reEncrypted)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		another, _ := Parse(strings.Replace(k2, "k2:", "k1:", 1))
+		_, err := another.Decrypt(encrypted)
+		assert.Equal(t, ErrInvalidCiphertext, err)
+		_, err = old.Decrypt(encrypted[:len(encrypted)-4])
+		assert.Equal(t, ErrInvalidCiphertext, err)
+		_, err = old.Decrypt("password")
+		assert.Equal(t, ErrInvalidCiphertext, err)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		for _, s := range []string{"k1", "k1:xxx", "k1:YWJj", "k:1:" + k1, k1 + "," + k1} {
+			_, err := Parse(s)
+			assert.Error(t, err, s)
+		}
+		_, err := GenerateKey("")
+		assert.Error(t, err)
+		_, err = New().Encrypt("x")
+		assert.Equal(t, ErrNoKey, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	k1, _ := GenerateKey("k1")
+	k2, _ := GenerateKey("k2")
+	dir, err := ioutil.TempDir("", "cipher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "root.key")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(k1+"\n"), 0600))
+
+	keyring, err := Load(k2, file)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, keyring.Len())
+	encrypted, _ := keyring.Encrypt("x")
+	assert.True(t, strings.HasPrefix(encrypted, Prefix+"k2:"))
+
+	_, err = Load("", filepath.Join(dir, "not-exist"))
+	assert.Error(t, err)
+	keyring, err = Load("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, keyring.Len())
+}
@@ -16,6 +16,7 @@
 package lb
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -51,3 +52,72 @@ func BenchmarkNewRoundLB(b *testing.B) {
 		}
 	})
 }
+
+func TestNewWeightedRoundRobinLB(t *testing.T) {
+	lb := NewWeightedRoundRobinLB(nil)
+	if lb.Next() != "" {
+		t.Fatalf("TestNewWeightedRoundRobinLB failed")
+	}
+	lb = NewWeightedRoundRobinLB([]WeightedEndpoint{{"1", 5}, {"2", 1}, {"3", 1}, {"4", 0}})
+	var picks []string
+	counts := map[string]int{}
+	for i := 0; i < 7; i++ {
+		ep := lb.Next()
+		picks = append(picks, ep)
+		counts[ep]++
+	}
+	if counts["1"] != 5 || counts["2"] != 1 || counts["3"] != 1 || counts["4"] != 0 {
+		t.Fatalf("TestNewWeightedRoundRobinLB failed, %v", picks)
+	}
+	// smooth: the heavy endpoint is not picked continuously
+	if picks[0] != "1" || picks[1] != "1" || picks[2] == "1" {
+		t.Fatalf("TestNewWeightedRoundRobinLB failed, %v", picks)
+	}
+}
+
+func TestNewWeightedRandomLB(t *testing.T) {
+	lb := NewWeightedRandomLB([]WeightedEndpoint{{"1", 0}})
+	if lb.Next() != "" {
+		t.Fatalf("TestNewWeightedRandomLB failed")
+	}
+	lb = NewWeightedRandomLB([]WeightedEndpoint{{"1", 95}, {"2", 5}, {"3", 0}})
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[lb.Next()]++
+	}
+	if counts["3"] != 0 || counts["2"] == 0 || counts["2"] > 1000 || counts["1"] < 9000 {
+		t.Fatalf("TestNewWeightedRandomLB failed, %v", counts)
+	}
+}
+
+func TestParseWeight(t *testing.T) {
+	if w, err := ParseWeight(nil); err != nil || w != DefaultWeight {
+		t.Fatalf("TestParseWeight failed")
+	}
+	if w, err := ParseWeight(map[string]string{PropWeight: "5"}); err != nil || w != 5 {
+		t.Fatalf("TestParseWeight failed")
+	}
+	for _, v := range []string{"-1", "x", "10001"} {
+		if _, err := ParseWeight(map[string]string{PropWeight: v}); err == nil {
+			t.Fatalf("TestParseWeight %s failed", v)
+		}
+	}
+	// the generic property key is not reserved
+	if w, err := ParseWeight(map[string]string{"weight": "heavy"}); err != nil || w != DefaultWeight {
+		t.Fatalf("TestParseWeight failed")
+	}
+}
+
+func TestSplitWeights(t *testing.T) {
+	v1 := SplitWeights(95, []int{100, 100, 0})
+	v2 := SplitWeights(5, []int{0})
+	if !reflect.DeepEqual(v1, []int{4750, 4750, 0}) || !reflect.DeepEqual(v2, []int{500}) {
+		t.Fatalf("TestSplitWeights failed, %v %v", v1, v2)
+	}
+	if w := SplitWeights(10, []int{300, 100}); !reflect.DeepEqual(w, []int{750, 250}) {
+		t.Fatalf("TestSplitWeights failed, %v", w)
+	}
+	if w := SplitWeights(0, []int{100}); !reflect.DeepEqual(w, []int{0}) {
+		t.Fatalf("TestSplitWeights failed, %v", w)
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// PropWeight is the reserved instance property key of the traffic weight
+	PropWeight = "sc.weight"
+	// DefaultWeight is used when the instance does not specify the weight
+	DefaultWeight = 100
+	MaxWeight     = 10000
+)
+
+const (
+	StrategyRoundRobin         = "roundRobin"
+	StrategyWeightedRandom     = "weightedRandom"
+	StrategyWeightedRoundRobin = "weightedRoundRobin"
+)
+
+type WeightedEndpoint struct {
+	Endpoint string
+	Weight   int
+}
+
+// ParseWeight returns the weight in properties, DefaultWeight if not set
+func ParseWeight(properties map[string]string) (int, error) {
+	v, ok := properties[PropWeight]
+	if !ok {
+		return DefaultWeight, nil
+	}
+	w, err := strconv.Atoi(v)
+	if err != nil || w < 0 || w > MaxWeight {
+		return 0, fmt.Errorf("invalid weight '%s', must be an integer between 0 and %d", v, MaxWeight)
+	}
+	return w, nil
+}
+
+// SplitWeights returns the weights of the instances of a version which
+// receives percent of the total traffic, the instances keep the proportion
+// of their current weights, or share equally if all weights are zero
+func SplitWeights(percent int, weights []int) []int {
+	total := percent * MaxWeight / 100
+	sum := 0
+	for _, w := range weights {
+		if w > 0 {
+			sum += w
+		}
+	}
+	result := make([]int, len(weights))
+	if total <= 0 || len(weights) == 0 {
+		return result
+	}
+	for i, w := range weights {
+		switch {
+		case sum == 0:
+			result[i] = total / len(weights)
+		case w > 0:
+			result[i] = total * w / sum
+		default:
+			continue
+		}
+		if result[i] == 0 {
+			result[i] = 1
+		}
+	}
+	return result
+}
+
+// New returns the LoadBalancer of the strategy, default is round robin
+func New(strategy string, endpoints []WeightedEndpoint) LoadBalancer {
+	switch strategy {
+	case StrategyWeightedRandom:
+		return NewWeightedRandomLB(endpoints)
+	case StrategyWeightedRoundRobin:
+		return NewWeightedRoundRobinLB(endpoints)
+	default:
+		eps := make([]string, 0, len(endpoints))
+		for _, ep := range endpoints {
+			eps = append(eps, ep.Endpoint)
+		}
+		return NewRoundRobinLB(eps)
+	}
+}
+
+func positive(endpoints []WeightedEndpoint) (eps []WeightedEndpoint, total int) {
+	for _, ep := range endpoints {
+		if ep.Weight <= 0 {
+			continue
+		}
+		eps = append(eps, ep)
+		total += ep.Weight
+	}
+	return
+}
@@ -0,0 +1,56 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// WeightedRandomLB picks the endpoint randomly in proportion to the weights,
+// the endpoints with zero weight are never picked
+type WeightedRandomLB struct {
+	Endpoints []WeightedEndpoint
+	total     int
+	mux       sync.Mutex
+	rand      *rand.Rand
+}
+
+func (lb *WeightedRandomLB) Next() string {
+	if lb.total == 0 {
+		return ""
+	}
+	lb.mux.Lock()
+	n := lb.rand.Intn(lb.total)
+	lb.mux.Unlock()
+	for _, ep := range lb.Endpoints {
+		if n < ep.Weight {
+			return ep.Endpoint
+		}
+		n -= ep.Weight
+	}
+	return ""
+}
+
+func NewWeightedRandomLB(endpoints []WeightedEndpoint) *WeightedRandomLB {
+	eps, total := positive(endpoints)
+	return &WeightedRandomLB{
+		Endpoints: eps,
+		total:     total,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
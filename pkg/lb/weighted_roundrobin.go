@@ -0,0 +1,53 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import "sync"
+
+// WeightedRoundRobinLB is the smooth weighted round robin, it spreads the
+// picks of the heavy endpoint instead of picking it continuously
+type WeightedRoundRobinLB struct {
+	Endpoints []WeightedEndpoint
+	current   []int
+	total     int
+	mux       sync.Mutex
+}
+
+func (lb *WeightedRoundRobinLB) Next() string {
+	if lb.total == 0 {
+		return ""
+	}
+	lb.mux.Lock()
+	defer lb.mux.Unlock()
+	best := 0
+	for i, ep := range lb.Endpoints {
+		lb.current[i] += ep.Weight
+		if lb.current[i] > lb.current[best] {
+			best = i
+		}
+	}
+	lb.current[best] -= lb.total
+	return lb.Endpoints[best].Endpoint
+}
+
+func NewWeightedRoundRobinLB(endpoints []WeightedEndpoint) *WeightedRoundRobinLB {
+	eps, total := positive(endpoints)
+	return &WeightedRoundRobinLB{
+		Endpoints: eps,
+		current:   make([]int, len(eps)),
+		total:     total,
+	}
+}
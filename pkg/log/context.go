@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+const (
+	FieldTraceID = "traceId"
+	FieldSpanID  = "spanId"
+)
+
+// TraceFunc returns the ids of the tracing span in the context,
+// empty traceID if no span found
+type TraceFunc func(ctx context.Context) (traceID, spanID string)
+
+var traceFunc atomic.Value // TraceFunc
+
+// SetTraceFunc is called by the tracing plugin to correlate the logs with the spans
+func SetTraceFunc(f TraceFunc) {
+	traceFunc.Store(f)
+}
+
+// WithContext returns the logger of the caller with the trace ids in the context,
+// e.g. log.WithContext(ctx).Errorf(err, "create service failed")
+func WithContext(ctx context.Context) *Logger {
+	// the methods are called directly instead of the package functions
+	l := current().with(-1)
+	f, _ := traceFunc.Load().(TraceFunc)
+	if f == nil || ctx == nil {
+		return l
+	}
+	traceID, spanID := f(ctx)
+	if len(traceID) == 0 {
+		return l
+	}
+	return l.with(0, zap.String(FieldTraceID, traceID), zap.String(FieldSpanID, spanID))
+}
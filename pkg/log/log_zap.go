@@ -32,4 +32,6 @@ func SetGlobal(cfg Config) {
 	_ = zap.ReplaceGlobals(logger.zapLogger)
 	// golang log
 	_ = zap.RedirectStdLog(logger.zapLogger)
+
+	rebuildModules()
 }
@@ -27,43 +27,48 @@ func Default() *Logger {
 }
 
 func Debug(msg string) {
-	logger.Debug(msg)
+	current().Debug(msg)
 }
 
 func Debugf(format string, args ...interface{}) {
-	logger.Debugf(format, args...)
+	current().Debugf(format, args...)
 }
 
 func Info(msg string) {
-	logger.Info(msg)
+	current().Info(msg)
 }
 
 func Infof(format string, args ...interface{}) {
-	logger.Infof(format, args...)
+	current().Infof(format, args...)
 }
 
 func Warn(msg string) {
-	logger.Warn(msg)
+	current().Warn(msg)
 }
 
 func Warnf(format string, args ...interface{}) {
-	logger.Warnf(format, args...)
+	current().Warnf(format, args...)
 }
 
 func Error(msg string, err error) {
-	logger.Error(msg, err)
+	current().Error(msg, err)
 }
 
 func Errorf(err error, format string, args ...interface{}) {
-	logger.Errorf(err, format, args...)
+	current().Errorf(err, format, args...)
 }
 
 func Fatal(msg string, err error) {
-	logger.Fatal(msg, err)
+	current().Fatal(msg, err)
 }
 
 func Fatalf(err error, format string, args ...interface{}) {
-	logger.Fatalf(err, format, args...)
+	current().Fatalf(err, format, args...)
+}
+
+// SetLevel changes the global level and cancels the pending revert
+func SetLevel(level string) error {
+	return SetModuleLevel(GlobalModule, level, 0)
 }
 
 func Sync() {
@@ -75,25 +80,27 @@ func NilOrWarnf(start time.Time, format string, args ...interface{}) {
 	if cost < time.Second {
 		return
 	}
-	logger.Warnf("[%s]%s", cost, fmt.Sprintf(format, args...))
+	current().Warnf("[%s]%s", cost, fmt.Sprintf(format, args...))
 }
 
 func DebugOrWarnf(start time.Time, format string, args ...interface{}) {
+	l := current()
 	cost := time.Since(start)
 	if cost < time.Second {
-		logger.Debugf("[%s]%s", cost, fmt.Sprintf(format, args...))
+		l.Debugf("[%s]%s", cost, fmt.Sprintf(format, args...))
 		return
 	}
-	logger.Warnf("[%s]%s", cost, fmt.Sprintf(format, args...))
+	l.Warnf("[%s]%s", cost, fmt.Sprintf(format, args...))
 }
 
 func InfoOrWarnf(start time.Time, format string, args ...interface{}) {
+	l := current()
 	cost := time.Since(start)
 	if cost < time.Second {
-		logger.Infof("[%s]%s", cost, fmt.Sprintf(format, args...))
+		l.Infof("[%s]%s", cost, fmt.Sprintf(format, args...))
 		return
 	}
-	logger.Warnf("[%s]%s", cost, fmt.Sprintf(format, args...))
+	l.Warnf("[%s]%s", cost, fmt.Sprintf(format, args...))
 }
 
 // Panic is a function can only be called in defer function.
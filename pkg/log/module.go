@@ -0,0 +1,300 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// GlobalModule is the name of the global logger
+	GlobalModule = "global"
+
+	ModuleDatasource = "datasource"
+	ModuleCache      = "cache"
+	ModuleEvent      = "event"
+	ModuleRBAC       = "rbac"
+	ModuleSyncer     = "syncer"
+
+	projectPath = "github.com/apache/servicecomb-service-center/"
+)
+
+var ErrModuleNotFound = errors.New("log module not found")
+
+var (
+	moduleLock sync.Mutex
+	modules    = make(map[string]*module)
+	// the count of the modules having their own levels, the caller of
+	// the package functions is looked up only when it is not zero
+	overridden  int32
+	globalState revertState
+	pcCache     atomic.Value // *sync.Map, pc -> *module
+)
+
+func init() {
+	pcCache.Store(&sync.Map{})
+	RegisterModule(ModuleDatasource, projectPath+"datasource")
+	RegisterModule(ModuleCache, projectPath+"datasource/etcd/sd", projectPath+"datasource/mongo/sd",
+		projectPath+"datasource/sdcommon", projectPath+"datasource/etcd/cache", projectPath+"datasource/cache")
+	RegisterModule(ModuleEvent, projectPath+"pkg/event", projectPath+"server/event",
+		projectPath+"datasource/etcd/event", projectPath+"datasource/mongo/event", projectPath+"server/webhook",
+		projectPath+"pkg/webhook")
+	RegisterModule(ModuleRBAC, projectPath+"server/service/rbac", projectPath+"server/plugin/auth")
+	RegisterModule(ModuleSyncer, projectPath+"syncer", projectPath+"server/syncernotify")
+}
+
+// LevelInfo is the level of a logger
+type LevelInfo struct {
+	Name  string
+	Level string
+	// Inherited is true if the module uses the global level
+	Inherited bool
+	// RevertAt is the time the level reverts, zero if not scheduled
+	RevertAt time.Time
+}
+
+// revertState reverts the level to the origin when the timer fires
+type revertState struct {
+	timer    *time.Timer
+	revertAt time.Time
+	origin   string
+	gen      uint64
+}
+
+// schedule must be called with moduleLock held, current is the level before changing
+func (s *revertState) schedule(d time.Duration, current string, revert func(origin string)) {
+	pending := s.timer != nil
+	if pending {
+		s.timer.Stop()
+		s.timer = nil
+		s.revertAt = time.Time{}
+	}
+	s.gen++
+	if d <= 0 {
+		return
+	}
+	if !pending {
+		// keep the origin of the first temporary change
+		s.origin = current
+	}
+	gen, origin := s.gen, s.origin
+	s.revertAt = time.Now().Add(d)
+	s.timer = time.AfterFunc(d, func() {
+		moduleLock.Lock()
+		defer moduleLock.Unlock()
+		if s.gen != gen {
+			return
+		}
+		s.timer = nil
+		s.revertAt = time.Time{}
+		s.gen++
+		revert(origin)
+	})
+}
+
+// module is a named logger of a subsystem, the logs of the packages go to it
+type module struct {
+	name     string
+	packages []string
+
+	// level is used if overridden, otherwise the global level is used
+	level    zap.AtomicLevel
+	override int32
+	logger   atomic.Value // *Logger
+	state    revertState
+}
+
+func (m *module) Enabled(l zapcore.Level) bool {
+	if atomic.LoadInt32(&m.override) == 1 {
+		return m.level.Enabled(l)
+	}
+	return logger.level.Enabled(l)
+}
+
+// getLevel returns empty if the module inherits the global level
+func (m *module) getLevel() string {
+	if atomic.LoadInt32(&m.override) == 0 {
+		return ""
+	}
+	return m.level.Level().CapitalString()
+}
+
+func (m *module) setLevel(level string) error {
+	if len(level) == 0 {
+		if atomic.CompareAndSwapInt32(&m.override, 1, 0) {
+			atomic.AddInt32(&overridden, -1)
+		}
+		return nil
+	}
+	zl, ok := zapLevelMap[strings.ToUpper(level)]
+	if !ok {
+		return fmt.Errorf("invalid log level '%s'", level)
+	}
+	m.level.SetLevel(zl)
+	if atomic.CompareAndSwapInt32(&m.override, 0, 1) {
+		atomic.AddInt32(&overridden, 1)
+	}
+	return nil
+}
+
+func (m *module) build() {
+	m.logger.Store(logger.named(m.name, m))
+}
+
+// RegisterModule registers a named logger, the logs of the packages
+// with the path prefixes go to it, the longest prefix wins
+func RegisterModule(name string, packages ...string) {
+	moduleLock.Lock()
+	defer moduleLock.Unlock()
+	m, ok := modules[name]
+	if !ok {
+		m = &module{name: name, level: zap.NewAtomicLevel()}
+		m.build()
+		modules[name] = m
+	}
+	m.packages = append(m.packages, packages...)
+	pcCache.Store(&sync.Map{})
+}
+
+// rebuildModules must be called after the global logger changed
+func rebuildModules() {
+	moduleLock.Lock()
+	defer moduleLock.Unlock()
+	for _, m := range modules {
+		m.build()
+	}
+}
+
+func matchPackage(fn, pkg string) bool {
+	if !strings.HasPrefix(fn, pkg) {
+		return false
+	}
+	if len(fn) == len(pkg) {
+		return true
+	}
+	c := fn[len(pkg)]
+	return c == '.' || c == '/'
+}
+
+func moduleOf(pc uintptr) *module {
+	cache := pcCache.Load().(*sync.Map)
+	if v, ok := cache.Load(pc); ok {
+		m, _ := v.(*module)
+		return m
+	}
+	var (
+		found  *module
+		length int
+	)
+	// the frame accounts for the inlined functions
+	if f, _ := runtime.CallersFrames([]uintptr{pc}).Next(); len(f.Function) > 0 {
+		fn := f.Function
+		moduleLock.Lock()
+		for _, m := range modules {
+			for _, pkg := range m.packages {
+				if len(pkg) > length && matchPackage(fn, pkg) {
+					found, length = m, len(pkg)
+				}
+			}
+		}
+		moduleLock.Unlock()
+	}
+	if found == nil {
+		// cache the miss as a typed nil
+		cache.Store(pc, (*module)(nil))
+		return nil
+	}
+	cache.Store(pc, found)
+	return found
+}
+
+// current returns the logger of the caller of the package function
+func current() *Logger {
+	if atomic.LoadInt32(&overridden) == 0 {
+		return logger
+	}
+	var pcs [1]uintptr
+	// runtime.Callers, current, the package function, the caller
+	if runtime.Callers(3, pcs[:]) == 0 {
+		return logger
+	}
+	m := moduleOf(pcs[0])
+	if m == nil {
+		return logger
+	}
+	return m.logger.Load().(*Logger)
+}
+
+// SetModuleLevel changes the level of the global logger or a module at runtime,
+// an empty level makes the module inherit the global level,
+// the level reverts after d if d is greater than 0
+func SetModuleLevel(name, level string, d time.Duration) error {
+	moduleLock.Lock()
+	defer moduleLock.Unlock()
+	if name == GlobalModule {
+		current := logger.Level()
+		if err := logger.SetLevel(level); err != nil {
+			return err
+		}
+		globalState.schedule(d, current, func(origin string) {
+			_ = logger.SetLevel(origin)
+		})
+		return nil
+	}
+	m, ok := modules[name]
+	if !ok {
+		return ErrModuleNotFound
+	}
+	current := m.getLevel()
+	if err := m.setLevel(level); err != nil {
+		return err
+	}
+	m.state.schedule(d, current, func(origin string) {
+		_ = m.setLevel(origin)
+	})
+	return nil
+}
+
+// Levels returns the levels of the global logger and the modules
+func Levels() []LevelInfo {
+	moduleLock.Lock()
+	defer moduleLock.Unlock()
+	global := logger.Level()
+	levels := make([]LevelInfo, 0, len(modules)+1)
+	for _, m := range modules {
+		info := LevelInfo{Name: m.name, Level: m.getLevel(), RevertAt: m.state.revertAt}
+		if len(info.Level) == 0 {
+			info.Level, info.Inherited = global, true
+		}
+		levels = append(levels, info)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].Name < levels[j].Name
+	})
+	return append([]LevelInfo{{Name: GlobalModule, Level: global, RevertAt: globalState.revertAt}}, levels...)
+}
@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+)
+
+func captureGlobal(t *testing.T) *bytes.Buffer {
+	buf := bytes.NewBuffer(nil)
+	old, oldSyncer := logger, StdoutSyncer
+	StdoutSyncer = zapcore.AddSync(buf)
+	SetGlobal(Config{LoggerLevel: "INFO"})
+	t.Cleanup(func() {
+		StdoutSyncer = oldSyncer
+		logger = old
+		rebuildModules()
+	})
+	return buf
+}
+
+func TestSetModuleLevel(t *testing.T) {
+	buf := captureGlobal(t)
+	RegisterModule("test", projectPath+"pkg/log")
+
+	t.Run("inherit the global level, should not print debug log", func(t *testing.T) {
+		Debug("inherited")
+		assert.NotContains(t, buf.String(), "inherited")
+	})
+
+	t.Run("module level is debug, should print debug log with the module name", func(t *testing.T) {
+		assert.NoError(t, SetModuleLevel("test", "debug", 0))
+		Debugf("%s", "overridden")
+		assert.Contains(t, buf.String(), "overridden")
+		assert.Contains(t, buf.String(), `"logger":"test"`)
+		assert.Contains(t, buf.String(), "module_test.go")
+
+		levels := Levels()
+		assert.Equal(t, LevelInfo{Name: GlobalModule, Level: "INFO"}, levels[0])
+		assert.Contains(t, levels, LevelInfo{Name: "test", Level: "DEBUG"})
+		assert.Contains(t, levels, LevelInfo{Name: ModuleDatasource, Level: "INFO", Inherited: true})
+	})
+
+	t.Run("reset module level, should inherit the global level again", func(t *testing.T) {
+		assert.NoError(t, SetModuleLevel("test", "", 0))
+		Debug("reset")
+		assert.NotContains(t, buf.String(), "reset")
+		assert.Contains(t, Levels(), LevelInfo{Name: "test", Level: "INFO", Inherited: true})
+	})
+
+	t.Run("set level with duration, should revert to the origin level", func(t *testing.T) {
+		assert.NoError(t, SetModuleLevel("test", "WARN", time.Minute))
+		assert.NoError(t, SetModuleLevel("test", "DEBUG", 50*time.Millisecond))
+		for _, l := range Levels() {
+			if l.Name == "test" {
+				assert.Equal(t, "DEBUG", l.Level)
+				assert.False(t, l.RevertAt.IsZero())
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+		assert.Contains(t, Levels(), LevelInfo{Name: "test", Level: "INFO", Inherited: true})
+
+		assert.NoError(t, SetModuleLevel(GlobalModule, "ERROR", 50*time.Millisecond))
+		Info("before revert")
+		time.Sleep(200 * time.Millisecond)
+		Info("after revert")
+		assert.NotContains(t, buf.String(), "before revert")
+		assert.Contains(t, buf.String(), "after revert")
+	})
+
+	t.Run("invalid parameters, should be failed", func(t *testing.T) {
+		assert.Equal(t, ErrModuleNotFound, SetModuleLevel("not-exist", "DEBUG", 0))
+		assert.Error(t, SetModuleLevel("test", "x", 0))
+		assert.Error(t, SetModuleLevel(GlobalModule, "", 0))
+	})
+}
+
+func TestWithContext(t *testing.T) {
+	buf := captureGlobal(t)
+	ctx := context.WithValue(context.Background(), "span", "1")
+	SetTraceFunc(func(ctx context.Context) (string, string) {
+		if ctx.Value("span") == nil {
+			return "", ""
+		}
+		return "trace-1", "span-1"
+	})
+	defer SetTraceFunc(func(context.Context) (string, string) { return "", "" })
+
+	WithContext(ctx).Infof("with %s", "span")
+	WithContext(context.Background()).Info("without span")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[0], `"traceId":"trace-1"`)
+	assert.Contains(t, lines[0], `"spanId":"span-1"`)
+	assert.Contains(t, lines[0], "module_test.go")
+	assert.NotContains(t, lines[1], "traceId")
+	assert.Contains(t, lines[1], "module_test.go")
+}
@@ -98,16 +98,16 @@ func Configure() Config {
 	}
 }
 
-func toZapConfig(c Config) zapcore.Core {
-	// level config
-	l, ok := zapLevelMap[strings.ToUpper(c.LoggerLevel)]
+func toZapLevel(level string) zapcore.Level {
+	l, ok := zapLevelMap[strings.ToUpper(level)]
 	if !ok {
 		l = zap.DebugLevel
 	}
-	var levelEnabler zap.LevelEnablerFunc = func(level zapcore.Level) bool {
-		return level >= l
-	}
+	return l
+}
 
+// toZapConfig returns the core enabled all levels, the level is checked by levelCore
+func toZapConfig(c Config) zapcore.Core {
 	// log format
 	format := zapcore.EncoderConfig{
 		MessageKey:     "message",
@@ -128,7 +128,6 @@ func toZapConfig(c Config) zapcore.Core {
 	}
 	if c.NoLevel {
 		format.LevelKey = ""
-		levelEnabler = func(_ zapcore.Level) bool { return true }
 	}
 	if c.NoTime {
 		format.TimeKey = ""
@@ -156,12 +155,36 @@ func toZapConfig(c Config) zapcore.Core {
 	}
 
 	//zap.NewDevelopment()
-	return zapcore.NewCore(enc, syncer, levelEnabler)
+	return zapcore.NewCore(enc, syncer, zap.LevelEnablerFunc(func(_ zapcore.Level) bool { return true }))
+}
+
+// levelCore checks the level before writing to the core, so the loggers
+// sharing the same core can have different levels
+type levelCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *levelCore) Enabled(l zapcore.Level) bool {
+	return c.enabler.Enabled(l)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+func (c *levelCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(e.Level) {
+		return ce
+	}
+	return c.Core.Check(e, ce)
 }
 
 type Logger struct {
 	Config Config
 
+	level     zap.AtomicLevel
+	core      zapcore.Core // without level checking
 	zapLogger *zap.Logger
 	zapSugar  *zap.SugaredLogger
 }
@@ -251,6 +274,21 @@ func (l *Logger) Recover(r interface{}, callerSkip int) {
 	}
 }
 
+// Level returns the current level of the logger
+func (l *Logger) Level() string {
+	return l.level.Level().CapitalString()
+}
+
+// SetLevel changes the level of the logger at runtime
+func (l *Logger) SetLevel(level string) error {
+	zl, ok := zapLevelMap[strings.ToUpper(level)]
+	if !ok {
+		return fmt.Errorf("invalid log level '%s'", level)
+	}
+	l.level.SetLevel(zl)
+	return nil
+}
+
 func (l *Logger) Sync() {
 	err := l.zapLogger.Sync()
 	if err != nil {
@@ -266,15 +304,49 @@ func (l *Logger) Sync() {
 	}
 }
 
+// named returns a logger with the name sharing the core, the level is checked by enabler
+func (l *Logger) named(name string, enabler zapcore.LevelEnabler) *Logger {
+	zl := l.zapLogger.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		return &levelCore{Core: l.core, enabler: enabler}
+	})).Named(name)
+	return &Logger{
+		Config:    l.Config,
+		level:     l.level,
+		core:      l.core,
+		zapLogger: zl,
+		zapSugar:  zl.Sugar(),
+	}
+}
+
+// with returns a logger with the fields and the caller skip added
+func (l *Logger) with(skip int, fields ...zap.Field) *Logger {
+	zl := l.zapLogger.WithOptions(zap.AddCallerSkip(skip)).With(fields...)
+	return &Logger{
+		Config:    l.Config,
+		level:     l.level,
+		core:      l.core,
+		zapLogger: zl,
+		zapSugar:  zl.Sugar(),
+	}
+}
+
 func NewLogger(cfg Config) *Logger {
 	opts := make([]zap.Option, 1)
 	opts[0] = zap.ErrorOutput(StderrSyncer)
 	if !cfg.NoCaller {
 		opts = append(opts, zap.AddCaller(), zap.AddCallerSkip(cfg.CallerSkip))
 	}
-	l := zap.New(toZapConfig(cfg), opts...)
+	level := zap.NewAtomicLevelAt(toZapLevel(cfg.LoggerLevel))
+	core := toZapConfig(cfg)
+	var enabler zapcore.LevelEnabler = level
+	if cfg.NoLevel {
+		enabler = zap.LevelEnablerFunc(func(_ zapcore.Level) bool { return true })
+	}
+	l := zap.New(&levelCore{Core: core, enabler: enabler}, opts...)
 	return &Logger{
 		Config:    cfg,
+		level:     level,
+		core:      core,
 		zapLogger: l,
 		zapSugar:  l.Sugar(),
 	}
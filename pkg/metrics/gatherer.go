@@ -26,6 +26,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 func NewGatherer(opts Options) *Gather {
@@ -37,7 +38,9 @@ func NewGatherer(opts Options) *Gather {
 }
 
 type Gather struct {
-	Records  *Metrics
+	Records *Metrics
+	// Families is the raw metric families of the records, used by the push reporters
+	Families []*dto.MetricFamily
 	Interval time.Duration
 
 	lock   sync.Mutex
@@ -81,15 +84,18 @@ func (mm *Gather) Collect() error {
 	}
 
 	records := NewMetrics()
+	families := make([]*dto.MetricFamily, 0, len(mfs))
 	for _, mf := range mfs {
 		name := mf.GetName()
 		if _, ok := SysMetrics.Get(name); strings.Index(name, familyNamePrefix) == 0 || ok {
 			if d := Calculate(mf); d != nil {
 				records.put(strings.TrimPrefix(name, familyNamePrefix), d)
+				families = append(families, mf)
 			}
 		}
 	}
 	// clean the old cache here
 	mm.Records = records
+	mm.Families = families
 	return nil
 }
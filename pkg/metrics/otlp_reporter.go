@@ -0,0 +1,226 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// the field numbers of the OTLP metrics protocol messages,
+// see opentelemetry/proto/collector/metrics/v1/metrics_service.proto
+const (
+	fieldExportResourceMetrics protowire.Number = 1
+
+	fieldResourceMetricsResource     protowire.Number = 1
+	fieldResourceMetricsScopeMetrics protowire.Number = 2
+
+	fieldScopeMetricsScope   protowire.Number = 1
+	fieldScopeMetricsMetrics protowire.Number = 2
+
+	fieldMetricName        protowire.Number = 1
+	fieldMetricDescription protowire.Number = 2
+	fieldMetricGauge       protowire.Number = 5
+	fieldMetricSum         protowire.Number = 7
+	fieldMetricHistogram   protowire.Number = 9
+	fieldMetricSummary     protowire.Number = 11
+
+	fieldDataPoints             protowire.Number = 1
+	fieldAggregationTemporality protowire.Number = 2
+	fieldSumIsMonotonic         protowire.Number = 3
+
+	fieldPointStartTime protowire.Number = 2
+	fieldPointTime      protowire.Number = 3
+
+	fieldNumberPointAsDouble   protowire.Number = 4
+	fieldNumberPointAttributes protowire.Number = 7
+
+	fieldHistogramPointCount          protowire.Number = 4
+	fieldHistogramPointSum            protowire.Number = 5
+	fieldHistogramPointBucketCounts   protowire.Number = 6
+	fieldHistogramPointExplicitBounds protowire.Number = 7
+	fieldHistogramPointAttributes     protowire.Number = 9
+
+	fieldSummaryPointCount          protowire.Number = 4
+	fieldSummaryPointSum            protowire.Number = 5
+	fieldSummaryPointQuantileValues protowire.Number = 6
+	fieldSummaryPointAttributes     protowire.Number = 7
+
+	fieldQuantileValueQuantile protowire.Number = 1
+	fieldQuantileValueValue    protowire.Number = 2
+
+	aggregationTemporalityCumulative = 2
+)
+
+// OTLPReporter pushes the metrics to the OTLP collector, the gauges are mapped to
+// the Gauge, the counters to the cumulative monotonic Sum, the summaries to the Summary
+// and the histograms to the cumulative Histogram, the labels are the data point attributes
+type OTLPReporter struct {
+	exporter otlp.Exporter
+	resource *otlp.Resource
+	timeout  time.Duration
+	start    time.Time
+}
+
+func NewOTLPReporter(exporter otlp.Exporter, resource *otlp.Resource, timeout time.Duration) *OTLPReporter {
+	return &OTLPReporter{
+		exporter: exporter,
+		resource: resource,
+		timeout:  timeout,
+		start:    time.Now(),
+	}
+}
+
+func (r *OTLPReporter) Report() {
+	if Gatherer == nil || len(Gatherer.Families) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	if err := r.exporter.Export(ctx, r.Encode(Gatherer.Families, time.Now())); err != nil {
+		log.Errorf(err, "push metrics to OTLP collector failed")
+	}
+}
+
+// Encode encodes the metric families as the OTLP ExportMetricsServiceRequest message
+func (r *OTLPReporter) Encode(mfs []*dto.MetricFamily, now time.Time) []byte {
+	start, ts := uint64(r.start.UnixNano()), uint64(now.UnixNano())
+
+	var scopeMetrics []byte
+	scopeMetrics = otlp.AppendMessage(scopeMetrics, fieldScopeMetricsScope, r.resource.EncodeScope())
+	for _, mf := range mfs {
+		if m := encodeMetric(mf, start, ts); m != nil {
+			scopeMetrics = otlp.AppendMessage(scopeMetrics, fieldScopeMetricsMetrics, m)
+		}
+	}
+
+	var resourceMetrics []byte
+	resourceMetrics = otlp.AppendMessage(resourceMetrics, fieldResourceMetricsResource, r.resource.Encode())
+	resourceMetrics = otlp.AppendMessage(resourceMetrics, fieldResourceMetricsScopeMetrics, scopeMetrics)
+
+	return otlp.AppendMessage(nil, fieldExportResourceMetrics, resourceMetrics)
+}
+
+func encodeMetric(mf *dto.MetricFamily, start, ts uint64) []byte {
+	var (
+		field protowire.Number
+		data  []byte
+	)
+	for _, m := range mf.GetMetric() {
+		switch mf.GetType() {
+		case dto.MetricType_GAUGE:
+			field = fieldMetricGauge
+			data = otlp.AppendMessage(data, fieldDataPoints, encodeNumberPoint(m.GetLabel(), m.GetGauge().GetValue(), start, ts))
+		case dto.MetricType_COUNTER:
+			field = fieldMetricSum
+			data = otlp.AppendMessage(data, fieldDataPoints, encodeNumberPoint(m.GetLabel(), m.GetCounter().GetValue(), start, ts))
+		case dto.MetricType_SUMMARY:
+			field = fieldMetricSummary
+			data = otlp.AppendMessage(data, fieldDataPoints, encodeSummaryPoint(m, start, ts))
+		case dto.MetricType_HISTOGRAM:
+			field = fieldMetricHistogram
+			data = otlp.AppendMessage(data, fieldDataPoints, encodeHistogramPoint(m, start, ts))
+		default:
+			return nil
+		}
+	}
+	if data == nil {
+		return nil
+	}
+	switch field {
+	case fieldMetricSum:
+		data = otlp.AppendVarint(data, fieldAggregationTemporality, aggregationTemporalityCumulative)
+		data = otlp.AppendVarint(data, fieldSumIsMonotonic, 1)
+	case fieldMetricHistogram:
+		data = otlp.AppendVarint(data, fieldAggregationTemporality, aggregationTemporalityCumulative)
+	}
+
+	var b []byte
+	b = otlp.AppendString(b, fieldMetricName, mf.GetName())
+	b = otlp.AppendString(b, fieldMetricDescription, mf.GetHelp())
+	return otlp.AppendMessage(b, field, data)
+}
+
+func encodeNumberPoint(labels []*dto.LabelPair, v float64, start, ts uint64) []byte {
+	var b []byte
+	b = otlp.AppendFixed64(b, fieldPointStartTime, start)
+	b = otlp.AppendFixed64(b, fieldPointTime, ts)
+	b = otlp.AppendDouble(b, fieldNumberPointAsDouble, v)
+	return appendLabels(b, fieldNumberPointAttributes, labels)
+}
+
+func encodeSummaryPoint(m *dto.Metric, start, ts uint64) []byte {
+	s := m.GetSummary()
+	var b []byte
+	b = otlp.AppendFixed64(b, fieldPointStartTime, start)
+	b = otlp.AppendFixed64(b, fieldPointTime, ts)
+	b = otlp.AppendFixed64(b, fieldSummaryPointCount, s.GetSampleCount())
+	b = otlp.AppendDouble(b, fieldSummaryPointSum, s.GetSampleSum())
+	for _, q := range s.GetQuantile() {
+		if math.IsNaN(q.GetValue()) {
+			continue
+		}
+		var qv []byte
+		qv = otlp.AppendDouble(qv, fieldQuantileValueQuantile, q.GetQuantile())
+		qv = otlp.AppendDouble(qv, fieldQuantileValueValue, q.GetValue())
+		b = otlp.AppendMessage(b, fieldSummaryPointQuantileValues, qv)
+	}
+	return appendLabels(b, fieldSummaryPointAttributes, m.GetLabel())
+}
+
+// encodeHistogramPoint converts the cumulative prometheus buckets to the OTLP bucket counts
+func encodeHistogramPoint(m *dto.Metric, start, ts uint64) []byte {
+	h := m.GetHistogram()
+	var (
+		counts, bounds []byte
+		cumulative     uint64
+	)
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		counts = protowire.AppendFixed64(counts, bucket.GetCumulativeCount()-cumulative)
+		bounds = protowire.AppendFixed64(bounds, math.Float64bits(bucket.GetUpperBound()))
+		cumulative = bucket.GetCumulativeCount()
+	}
+	counts = protowire.AppendFixed64(counts, h.GetSampleCount()-cumulative)
+
+	var b []byte
+	b = otlp.AppendFixed64(b, fieldPointStartTime, start)
+	b = otlp.AppendFixed64(b, fieldPointTime, ts)
+	b = otlp.AppendFixed64(b, fieldHistogramPointCount, h.GetSampleCount())
+	b = otlp.AppendDouble(b, fieldHistogramPointSum, h.GetSampleSum())
+	b = otlp.AppendBytes(b, fieldHistogramPointBucketCounts, counts)
+	if len(bounds) > 0 {
+		b = otlp.AppendBytes(b, fieldHistogramPointExplicitBounds, bounds)
+	}
+	return appendLabels(b, fieldHistogramPointAttributes, m.GetLabel())
+}
+
+func appendLabels(b []byte, num protowire.Number, labels []*dto.LabelPair) []byte {
+	for _, label := range labels {
+		b = otlp.AppendKeyValue(b, num, otlp.String(label.GetName(), label.GetValue()))
+	}
+	return b
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"github.com/apache/servicecomb-service-center/pkg/otlp/otlptest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestOTLPReporter_Report(t *testing.T) {
+	c := otlptest.NewCollector()
+	defer c.Stop()
+	endpoint, err := c.StartGRPC()
+	assert.NoError(t, err)
+	exporter, err := otlp.NewExporter(otlp.ProtocolGRPC, endpoint, otlp.Metrics)
+	assert.NoError(t, err)
+
+	old := Gatherer
+	defer func() { Gatherer = old }()
+	Gatherer = &Gather{Families: testFamilies(t)}
+	r := NewOTLPReporter(exporter, &otlp.Resource{
+		Attributes: []otlp.KeyValue{otlp.String("service.name", "servicecenter")},
+	}, 5*time.Second)
+	r.Report()
+
+	requests := c.Requests(otlp.Metrics)
+	assert.Equal(t, 1, len(requests))
+
+	metrics := []protowire.Number{fieldExportResourceMetrics, fieldResourceMetricsScopeMetrics, fieldScopeMetricsMetrics}
+	names, err := otlptest.Strings(requests[0], append(metrics, fieldMetricName)...)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"service_center_db_latency",
+		"service_center_db_service_total",
+		"service_center_http_request_durations_microseconds",
+		"service_center_http_request_total",
+	}, names)
+
+	for _, field := range []protowire.Number{fieldMetricHistogram, fieldMetricGauge, fieldMetricSummary, fieldMetricSum} {
+		points := 0
+		err := otlptest.Walk(requests[0], func([]byte) { points++ }, append(metrics, field, fieldDataPoints)...)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, points, "field %d", field)
+	}
+
+	keys, err := otlptest.Strings(requests[0],
+		append(metrics, fieldMetricGauge, fieldDataPoints, fieldNumberPointAttributes, 1)...)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"domain", "instance"}, keys)
+
+	resource, err := otlptest.Strings(requests[0], fieldExportResourceMetrics, fieldResourceMetricsResource, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"service.name"}, resource)
+}
+
+func TestEncodeHistogramPoint(t *testing.T) {
+	mfs := testFamilies(t)
+	b := encodeHistogramPoint(mfs[0].GetMetric()[0], 0, 0)
+	var counts []uint64
+	err := otlptest.Walk(b, func(v []byte) {
+		for len(v) > 0 {
+			c, n := protowire.ConsumeFixed64(v)
+			counts = append(counts, c)
+			v = v[n:]
+		}
+	}, fieldHistogramPointBucketCounts)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 1, 1}, counts)
+}
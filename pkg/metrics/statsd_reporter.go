@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	FlavorStatsD    = "statsd"
+	FlavorDogStatsD = "dogstatsd"
+
+	// keep the packet in the MTU of the common networks
+	maxStatsDPacketSize = 1432
+)
+
+var statsDReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+
+// StatsDOptions is the options of StatsDReporter
+type StatsDOptions struct {
+	// Address is the udp address of the StatsD agent
+	Address string
+	// Prefix is prepended to the metric names
+	Prefix string
+	// Flavor is statsd or dogstatsd, the labels are the tags in dogstatsd,
+	// and are appended to the metric name in statsd
+	Flavor string
+}
+
+// StatsDReporter pushes the metrics to StatsD/DogStatsD agent,
+// the gauges are reported as gauges, the counters are reported as the increments
+// since the last report, and the summaries and histograms are reported as the
+// count increments and the average, quantile gauges
+type StatsDReporter struct {
+	opts StatsDOptions
+	conn net.Conn
+	last map[string]float64
+}
+
+func NewStatsDReporter(opts StatsDOptions) (*StatsDReporter, error) {
+	conn, err := net.Dial("udp", opts.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsDReporter{opts: opts, conn: conn, last: make(map[string]float64)}, nil
+}
+
+func (r *StatsDReporter) Report() {
+	if Gatherer == nil {
+		return
+	}
+	for _, packet := range r.Packets(Gatherer.Families) {
+		if _, err := r.conn.Write(packet); err != nil {
+			log.Errorf(err, "push metrics to statsd %s failed", r.opts.Address)
+			return
+		}
+	}
+}
+
+// Packets encodes the metric families to StatsD lines and splits them into packets
+func (r *StatsDReporter) Packets(mfs []*dto.MetricFamily) [][]byte {
+	var (
+		packets [][]byte
+		b       bytes.Buffer
+	)
+	for _, line := range r.Lines(mfs) {
+		if b.Len() > 0 && b.Len()+1+len(line) > maxStatsDPacketSize {
+			packets = append(packets, append([]byte(nil), b.Bytes()...))
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(line)
+	}
+	if b.Len() > 0 {
+		packets = append(packets, b.Bytes())
+	}
+	return packets
+}
+
+// Lines encodes the metric families to StatsD lines
+func (r *StatsDReporter) Lines(mfs []*dto.MetricFamily) []string {
+	var lines []string
+	for _, mf := range mfs {
+		name := r.opts.Prefix + mf.GetName()
+		for _, m := range mf.GetMetric() {
+			labels := m.GetLabel()
+			switch mf.GetType() {
+			case dto.MetricType_GAUGE:
+				lines = append(lines, r.line(name, labels, m.GetGauge().GetValue(), "g"))
+			case dto.MetricType_COUNTER:
+				lines = append(lines, r.line(name, labels, r.delta(name, labels, m.GetCounter().GetValue()), "c"))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				lines = append(lines, r.sampleLines(name, labels, s.GetSampleCount(), s.GetSampleSum())...)
+				for _, q := range s.GetQuantile() {
+					lines = append(lines, r.line(name+".p"+strconv.FormatFloat(q.GetQuantile()*100, 'f', -1, 64),
+						labels, q.GetValue(), "g"))
+				}
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				lines = append(lines, r.sampleLines(name, labels, h.GetSampleCount(), h.GetSampleSum())...)
+			}
+		}
+	}
+	return lines
+}
+
+func (r *StatsDReporter) sampleLines(name string, labels []*dto.LabelPair, count uint64, sum float64) []string {
+	lines := []string{r.line(name+".count", labels, r.delta(name+".count", labels, float64(count)), "c")}
+	if count > 0 {
+		lines = append(lines, r.line(name+".avg", labels, sum/float64(count), "g"))
+	}
+	return lines
+}
+
+// delta returns the increment of the counter since last report, the value itself if the counter is reset
+func (r *StatsDReporter) delta(name string, labels []*dto.LabelPair, v float64) float64 {
+	key := name + "{" + labelsKey(labels) + "}"
+	old, ok := r.last[key]
+	r.last[key] = v
+	if !ok || v < old {
+		return v
+	}
+	return v - old
+}
+
+func (r *StatsDReporter) line(name string, labels []*dto.LabelPair, v float64, typ string) string {
+	var b strings.Builder
+	b.WriteString(statsDReplacer.Replace(name))
+	if r.opts.Flavor != FlavorDogStatsD {
+		for _, label := range labels {
+			b.WriteByte('.')
+			b.WriteString(statsDValue(label.GetValue()))
+		}
+	}
+	b.WriteByte(':')
+	b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	b.WriteByte('|')
+	b.WriteString(typ)
+	if r.opts.Flavor == FlavorDogStatsD && len(labels) > 0 {
+		b.WriteString("|#")
+		for i, label := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(statsDReplacer.Replace(label.GetName()))
+			b.WriteByte(':')
+			b.WriteString(statsDReplacer.Replace(label.GetValue()))
+		}
+	}
+	return b.String()
+}
+
+func statsDValue(v string) string {
+	if len(v) == 0 {
+		return "none"
+	}
+	// the dot is the separator of metric name segments
+	return strings.Replace(statsDReplacer.Replace(v), ".", "_", -1)
+}
+
+func labelsKey(labels []*dto.LabelPair) string {
+	var b strings.Builder
+	for _, label := range labels {
+		b.WriteString(label.GetName())
+		b.WriteByte('=')
+		b.WriteString(label.GetValue())
+		b.WriteByte(',')
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFamilies(t *testing.T) []*dto.MetricFamily {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: FamilyName, Subsystem: SubSystem, Name: KeyServiceTotal, Help: "services",
+	}, []string{"instance", "domain"})
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: FamilyName, Subsystem: "http", Name: "request_total", Help: "requests",
+	}, []string{"method", "domain"})
+	summary := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: FamilyName, Subsystem: "http", Name: "request_durations_microseconds", Help: "latency",
+		Objectives: map[float64]float64{0.5: 0.05},
+	}, []string{"method"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: FamilyName, Subsystem: "db", Name: "latency", Help: "db latency",
+		Buckets: []float64{1, 10},
+	})
+	registry.MustRegister(gauge, counter, summary, histogram)
+
+	gauge.WithLabelValues("127.0.0.1:30100", "default").Set(3)
+	counter.WithLabelValues("GET", "").Add(5)
+	summary.WithLabelValues("GET").Observe(10)
+	summary.WithLabelValues("GET").Observe(20)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	histogram.Observe(50)
+
+	mfs, err := registry.Gather()
+	assert.NoError(t, err)
+	return mfs
+}
+
+func TestStatsDReporter_Lines(t *testing.T) {
+	mfs := testFamilies(t)
+
+	r := &StatsDReporter{opts: StatsDOptions{Prefix: "sc.", Flavor: FlavorStatsD}, last: make(map[string]float64)}
+	assert.Equal(t, []string{
+		"sc.service_center_db_latency.count:3|c",
+		"sc.service_center_db_latency.avg:18.5|g",
+		"sc.service_center_db_service_total.default.127_0_0_1_30100:3|g",
+		"sc.service_center_http_request_durations_microseconds.count.GET:2|c",
+		"sc.service_center_http_request_durations_microseconds.avg.GET:15|g",
+		"sc.service_center_http_request_durations_microseconds.p50.GET:10|g",
+		"sc.service_center_http_request_total.none.GET:5|c",
+	}, r.Lines(mfs))
+
+	// the counters are reported as increments
+	lines := r.Lines(mfs)
+	assert.Contains(t, lines, "sc.service_center_http_request_total.none.GET:0|c")
+	assert.Contains(t, lines, "sc.service_center_db_latency.count:0|c")
+
+	r = &StatsDReporter{opts: StatsDOptions{Flavor: FlavorDogStatsD}, last: make(map[string]float64)}
+	lines = r.Lines(mfs)
+	assert.Contains(t, lines, "service_center_db_service_total:3|g|#domain:default,instance:127.0.0.1_30100")
+	assert.Contains(t, lines, "service_center_http_request_total:5|c|#domain:,method:GET")
+}
+
+func TestStatsDReporter_Report(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	r, err := NewStatsDReporter(StatsDOptions{Address: conn.LocalAddr().String(), Flavor: FlavorDogStatsD})
+	assert.NoError(t, err)
+	old := Gatherer
+	defer func() { Gatherer = old }()
+	Gatherer = &Gather{Families: testFamilies(t)}
+	r.Report()
+
+	buf := make([]byte, maxStatsDPacketSize)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, len(strings.Split(string(buf[:n]), "\n")))
+}
+
+func TestStatsDReporter_Packets(t *testing.T) {
+	r := &StatsDReporter{last: make(map[string]float64)}
+	var mfs []*dto.MetricFamily
+	for i := 0; i < 100; i++ {
+		mfs = append(mfs, testFamilies(t)...)
+	}
+	packets := r.Packets(mfs)
+	assert.True(t, len(packets) > 1)
+	for _, p := range packets {
+		assert.True(t, len(p) <= maxStatsDPacketSize)
+	}
+}
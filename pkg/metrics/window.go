@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// OtherKey counts the keys which exceed the capacity of the bucket
+const OtherKey = "other"
+
+// KeyCount is the count of a key in the window
+type KeyCount struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+type windowBucket struct {
+	start  int64
+	counts map[string]int64
+}
+
+// SlidingCounter counts the keys in a sliding time window, the window is divided into buckets
+// and each bucket holds at most max keys, the keys beyond the capacity are counted as OtherKey,
+// so the memory is bounded no matter how many distinct keys are added
+type SlidingCounter struct {
+	Window time.Duration
+
+	lock    sync.Mutex
+	width   int64
+	max     int
+	buckets []windowBucket
+	now     func() time.Time
+}
+
+func NewSlidingCounter(window time.Duration, buckets, max int) *SlidingCounter {
+	if buckets <= 0 {
+		buckets = 1
+	}
+	width := int64(window) / int64(buckets)
+	if width <= 0 {
+		width = 1
+	}
+	return &SlidingCounter{
+		Window:  window,
+		width:   width,
+		max:     max,
+		buckets: make([]windowBucket, buckets),
+		now:     time.Now,
+	}
+}
+
+func (c *SlidingCounter) Add(key string, n int64) {
+	start := c.now().UnixNano() / c.width
+	c.lock.Lock()
+	b := &c.buckets[start%int64(len(c.buckets))]
+	if b.start != start || b.counts == nil {
+		b.start, b.counts = start, make(map[string]int64)
+	}
+	if _, ok := b.counts[key]; !ok && len(b.counts) >= c.max {
+		key = OtherKey
+	}
+	b.counts[key] += n
+	c.lock.Unlock()
+}
+
+// Top returns the n keys with the largest counts in the window, all the keys if n <= 0
+func (c *SlidingCounter) Top(n int) []KeyCount {
+	oldest := c.now().UnixNano()/c.width - int64(len(c.buckets)) + 1
+	sum := make(map[string]int64)
+	c.lock.Lock()
+	for _, b := range c.buckets {
+		if b.start < oldest {
+			continue
+		}
+		for k, v := range b.counts {
+			sum[k] += v
+		}
+	}
+	c.lock.Unlock()
+
+	top := make([]KeyCount, 0, len(sum))
+	for k, v := range sum {
+		top = append(top, KeyCount{Key: k, Count: v})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Key < top[j].Key
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingCounter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := NewSlidingCounter(time.Minute, 6, 2)
+	c.now = func() time.Time { return now }
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	c.Add("a", 1)
+	assert.Equal(t, []KeyCount{{"other", 3}, {"a", 2}, {"b", 2}}, c.Top(0))
+	assert.Equal(t, []KeyCount{{"other", 3}}, c.Top(1))
+
+	t.Run("a new bucket has its own capacity", func(t *testing.T) {
+		now = now.Add(10 * time.Second)
+		c.Add("c", 3)
+		assert.Equal(t, []KeyCount{{"c", 3}, {"other", 3}, {"a", 2}, {"b", 2}}, c.Top(0))
+	})
+
+	t.Run("the expired buckets are not counted", func(t *testing.T) {
+		now = now.Add(55 * time.Second)
+		assert.Equal(t, []KeyCount{{"c", 3}}, c.Top(0))
+		now = now.Add(time.Minute)
+		assert.Empty(t, c.Top(0))
+		c.Add("d", 1)
+		assert.Equal(t, []KeyCount{{"d", 1}}, c.Top(0))
+	})
+}
@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package otlp implements the OTLP exporters over grpc and http,
+// the messages are encoded by protowire so that no generated OTLP code is required
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http/protobuf"
+
+	ContentType = "application/x-protobuf"
+)
+
+// Signal is the kind of telemetry data, which decides the collector service
+type Signal struct {
+	// Method is the grpc method
+	Method string
+	// Path is the http path
+	Path string
+}
+
+var (
+	Traces = Signal{
+		Method: "/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+		Path:   "/v1/traces",
+	}
+	Metrics = Signal{
+		Method: "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export",
+		Path:   "/v1/metrics",
+	}
+)
+
+// Exporter sends the encoded OTLP export request to the collector
+type Exporter interface {
+	Export(ctx context.Context, request []byte) error
+	Shutdown() error
+}
+
+// NewExporter returns the OTLP exporter of the protocol,
+// the endpoint is 'host:port' for grpc and 'http(s)://host:port' for http
+func NewExporter(protocol, endpoint string, signal Signal) (Exporter, error) {
+	switch protocol {
+	case ProtocolGRPC, "":
+		return NewGRPCExporter(endpoint, signal)
+	case ProtocolHTTP, "http":
+		return NewHTTPExporter(endpoint, signal), nil
+	default:
+		return nil, fmt.Errorf("unknown OTLP protocol '%s'", protocol)
+	}
+}
+
+// RawCodec passes the encoded message through
+type RawCodec struct{}
+
+type RawMessage struct {
+	Data []byte
+}
+
+func (RawCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(*RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return m.Data, nil
+}
+
+func (RawCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(*RawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	m.Data = append(m.Data[:0], data...)
+	return nil
+}
+
+func (RawCodec) Name() string {
+	return "proto"
+}
+
+// String makes RawCodec be a grpc.Codec, which is used by the collector stub
+func (c RawCodec) String() string {
+	return c.Name()
+}
+
+type GRPCExporter struct {
+	conn   *grpc.ClientConn
+	method string
+}
+
+func NewGRPCExporter(endpoint string, signal Signal) (*GRPCExporter, error) {
+	endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "grpc://")
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCExporter{conn: conn, method: signal.Method}, nil
+}
+
+func (e *GRPCExporter) Export(ctx context.Context, request []byte) error {
+	return e.conn.Invoke(ctx, e.method, &RawMessage{Data: request}, &RawMessage{},
+		grpc.ForceCodec(RawCodec{}))
+}
+
+func (e *GRPCExporter) Shutdown() error {
+	return e.conn.Close()
+}
+
+type HTTPExporter struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPExporter(endpoint string, signal Signal) *HTTPExporter {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	return &HTTPExporter{
+		url:    strings.TrimSuffix(endpoint, "/") + signal.Path,
+		client: &http.Client{},
+	}
+}
+
+func (e *HTTPExporter) Export(ctx context.Context, request []byte) error {
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", ContentType)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("export to %s failed, the collector response status is %d", e.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *HTTPExporter) Shutdown() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"github.com/apache/servicecomb-service-center/pkg/otlp/otlptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExporter(t *testing.T) {
+	_, err := otlp.NewExporter("unknown", "127.0.0.1:4317", otlp.Traces)
+	assert.Error(t, err)
+}
+
+func TestExporter(t *testing.T) {
+	c := otlptest.NewCollector()
+	defer c.Stop()
+	httpEndpoint := c.StartHTTP()
+	grpcEndpoint, err := c.StartGRPC()
+	assert.NoError(t, err)
+
+	exporter, err := otlp.NewExporter(otlp.ProtocolHTTP, httpEndpoint, otlp.Traces)
+	assert.NoError(t, err)
+	assert.NoError(t, exporter.Export(context.Background(), []byte("traces")))
+	assert.NoError(t, exporter.Shutdown())
+
+	exporter, err = otlp.NewExporter(otlp.ProtocolGRPC, grpcEndpoint, otlp.Metrics)
+	assert.NoError(t, err)
+	assert.NoError(t, exporter.Export(context.Background(), []byte("metrics")))
+	assert.NoError(t, exporter.Shutdown())
+
+	assert.Equal(t, [][]byte{[]byte("traces")}, c.Requests(otlp.Traces))
+	assert.Equal(t, [][]byte{[]byte("metrics")}, c.Requests(otlp.Metrics))
+
+	exporter = otlp.NewHTTPExporter(httpEndpoint+"/unknown", otlp.Traces)
+	assert.Error(t, exporter.Export(context.Background(), []byte("traces")))
+}
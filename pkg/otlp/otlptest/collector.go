@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package otlptest implements an in-process OTLP collector stub for tests
+package otlptest
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Collector records the export requests received by http or grpc
+type Collector struct {
+	lock     sync.Mutex
+	requests map[string][][]byte
+
+	http *httptest.Server
+	grpc *grpc.Server
+}
+
+func NewCollector() *Collector {
+	return &Collector{requests: make(map[string][][]byte)}
+}
+
+func (c *Collector) receive(signal otlp.Signal, request []byte) {
+	c.lock.Lock()
+	c.requests[signal.Path] = append(c.requests[signal.Path], request)
+	c.lock.Unlock()
+}
+
+// Requests returns the received export requests of the signal
+func (c *Collector) Requests(signal otlp.Signal) [][]byte {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([][]byte(nil), c.requests[signal.Path]...)
+}
+
+// StartHTTP starts the http collector and returns the endpoint 'http://host:port'
+func (c *Collector) StartHTTP() string {
+	c.http = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signal, ok := signalOf(r.URL.Path, func(s otlp.Signal) string { return s.Path })
+		if !ok || r.Method != http.MethodPost || r.Header.Get("Content-Type") != otlp.ContentType {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.receive(signal, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	return c.http.URL
+}
+
+// StartGRPC starts the grpc collector and returns the endpoint 'host:port'
+func (c *Collector) StartGRPC() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	c.grpc = grpc.NewServer(grpc.CustomCodec(otlp.RawCodec{}),
+		grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(stream)
+			signal, ok := signalOf(method, func(s otlp.Signal) string { return s.Method })
+			if !ok {
+				return nil
+			}
+			m := &otlp.RawMessage{}
+			if err := stream.RecvMsg(m); err != nil {
+				return err
+			}
+			c.receive(signal, m.Data)
+			return stream.SendMsg(&otlp.RawMessage{})
+		}))
+	go func() {
+		_ = c.grpc.Serve(l)
+	}()
+	return l.Addr().String(), nil
+}
+
+func (c *Collector) Stop() {
+	if c.http != nil {
+		c.http.Close()
+	}
+	if c.grpc != nil {
+		c.grpc.Stop()
+	}
+}
+
+func signalOf(s string, key func(otlp.Signal) string) (otlp.Signal, bool) {
+	for _, signal := range []otlp.Signal{otlp.Traces, otlp.Metrics} {
+		if key(signal) == s {
+			return signal, true
+		}
+	}
+	return otlp.Signal{}, false
+}
+
+// Walk calls fn with the value of each length-delimited field of the message,
+// the fields are followed by path, e.g. Walk(b, fn, 1, 2) visits the field 2 of every field 1 of b
+func Walk(b []byte, fn func([]byte), path ...protowire.Number) error {
+	if len(path) == 0 {
+		fn(b)
+		return nil
+	}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		m := protowire.ConsumeFieldValue(num, typ, b)
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		if num == path[0] && typ == protowire.BytesType {
+			v, _ := protowire.ConsumeBytes(b)
+			if err := Walk(v, fn, path[1:]...); err != nil {
+				return err
+			}
+		}
+		b = b[m:]
+	}
+	return nil
+}
+
+// Strings returns the string values of the field path
+func Strings(b []byte, path ...protowire.Number) ([]string, error) {
+	var values []string
+	err := Walk(b, func(v []byte) {
+		values = append(values, string(v))
+	}, path...)
+	return values, err
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// the field numbers of the OTLP common messages,
+// see opentelemetry/proto/common/v1/common.proto and opentelemetry/proto/resource/v1/resource.proto
+const (
+	fieldResourceAttributes protowire.Number = 1
+
+	fieldScopeName    protowire.Number = 1
+	fieldScopeVersion protowire.Number = 2
+
+	fieldKeyValueKey   protowire.Number = 1
+	fieldKeyValueValue protowire.Number = 2
+
+	fieldAnyValueString protowire.Number = 1
+	fieldAnyValueInt    protowire.Number = 3
+)
+
+// KeyValue is the attribute of resource, span and data point, the value is a string or an int
+type KeyValue struct {
+	Key      string
+	Value    string
+	IntValue int64
+	IsInt    bool
+}
+
+func String(key, value string) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+func Int(key string, value int64) KeyValue {
+	return KeyValue{Key: key, IntValue: value, IsInt: true}
+}
+
+// Resource describes the process which produces the telemetry data
+type Resource struct {
+	Attributes   []KeyValue
+	ScopeName    string
+	ScopeVersion string
+}
+
+// Encode encodes the Resource message
+func (r *Resource) Encode() []byte {
+	var b []byte
+	for _, attr := range r.Attributes {
+		b = AppendKeyValue(b, fieldResourceAttributes, attr)
+	}
+	return b
+}
+
+// EncodeScope encodes the InstrumentationScope message
+func (r *Resource) EncodeScope() []byte {
+	var b []byte
+	b = AppendString(b, fieldScopeName, r.ScopeName)
+	return AppendString(b, fieldScopeVersion, r.ScopeVersion)
+}
+
+func AppendKeyValue(b []byte, num protowire.Number, kv KeyValue) []byte {
+	var value []byte
+	if kv.IsInt {
+		value = AppendVarint(value, fieldAnyValueInt, uint64(kv.IntValue))
+	} else {
+		value = protowire.AppendTag(value, fieldAnyValueString, protowire.BytesType)
+		value = protowire.AppendString(value, kv.Value)
+	}
+
+	var m []byte
+	m = AppendString(m, fieldKeyValueKey, kv.Key)
+	m = AppendMessage(m, fieldKeyValueValue, value)
+	return AppendMessage(b, num, m)
+}
+
+// AppendString appends the non-empty string field
+func AppendString(b []byte, num protowire.Number, s string) []byte {
+	if len(s) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func AppendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func AppendMessage(b []byte, num protowire.Number, m []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m)
+}
+
+func AppendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func AppendFixed64(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, v)
+}
+
+func AppendDouble(b []byte, num protowire.Number, v float64) []byte {
+	return AppendFixed64(b, num, math.Float64bits(v))
+}
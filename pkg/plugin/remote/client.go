@@ -0,0 +1,255 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+)
+
+const (
+	DefaultStartTimeout = 10 * time.Second
+	DefaultCallTimeout  = 30 * time.Second
+	// stopTimeout is the time waiting for the plugin to exit after the stdin closed
+	stopTimeout = 5 * time.Second
+)
+
+var ErrClosed = errors.New("plugin client is closed")
+
+// DefaultPath returns the executable of the kind in the plugin dir, e.g. ./plugins/cipher_plugin
+func DefaultPath(kind plugin.Kind) string {
+	dir := os.ExpandEnv(plugin.GetConfigurator().GetPluginDir())
+	return filepath.Join(dir, kind.String()+"_plugin")
+}
+
+// Client starts the plugin process lazily and calls it through gRPC, the
+// process is restarted by the next call if it exited
+type Client struct {
+	Kind plugin.Kind
+	Path string
+	Args []string
+
+	StartTimeout time.Duration
+	CallTimeout  time.Duration
+
+	lock   sync.Mutex
+	proc   *process
+	closed bool
+}
+
+func NewClient(kind plugin.Kind, path string, args ...string) *Client {
+	return &Client{
+		Kind:         kind,
+		Path:         path,
+		Args:         args,
+		StartTimeout: DefaultStartTimeout,
+		CallTimeout:  DefaultCallTimeout,
+	}
+}
+
+// Invoke calls the method of the plugin service, the call timeout is applied
+// if the ctx has no deadline
+func (c *Client) Invoke(ctx context.Context, method string, in, out interface{}) error {
+	conn, err := c.connect()
+	if err != nil {
+		return err
+	}
+	if _, ok := ctx.Deadline(); !ok && c.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.CallTimeout)
+		defer cancel()
+	}
+	return conn.Invoke(ctx, "/"+ServiceName(c.Kind)+"/"+method, in, out, grpc.CallContentSubtype(CodecName))
+}
+
+func (c *Client) connect() (*grpc.ClientConn, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	if c.proc != nil && !c.proc.Exited() {
+		return c.proc.conn, nil
+	}
+	if c.proc != nil {
+		log.Warnf("%s plugin[%s] exited, restart it", c.Kind, c.Path)
+		c.proc.Stop()
+	}
+	p, err := c.start()
+	if err != nil {
+		log.Errorf(err, "start %s plugin[%s] failed", c.Kind, c.Path)
+		return nil, err
+	}
+	c.proc = p
+	log.Infof("%s plugin[%s] started, pid: %d", c.Kind, c.Path, p.cmd.Process.Pid)
+	return p.conn, nil
+}
+
+func (c *Client) start() (*process, error) {
+	cmd := exec.Command(c.Path, c.Args...)
+	cmd.Env = append(os.Environ(), MagicCookieKey+"="+MagicCookieValue)
+	// the plugin exits when the stdin is closed, even if service center is killed
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stderr = &logWriter{prefix: fmt.Sprintf("%s plugin[%s]: ", c.Kind, c.Path)}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	p := &process{cmd: cmd, stdin: stdin, exited: make(chan struct{})}
+	handshakes := make(chan handshakeResult, 1)
+	go p.run(c.Kind, stdout, &logWriter{prefix: fmt.Sprintf("%s plugin[%s]: ", c.Kind, c.Path)}, handshakes)
+
+	h, err := waitHandshake(handshakes, p.exited, c.StartTimeout)
+	if err != nil {
+		p.Stop()
+		return nil, err
+	}
+
+	p.conn, err = grpc.Dial(h.Address, grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, h.Network, addr)
+		}))
+	if err != nil {
+		p.Stop()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Close stops the plugin process, the calls after closing fail
+func (c *Client) Close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed = true
+	if c.proc != nil {
+		c.proc.Stop()
+		c.proc = nil
+	}
+}
+
+type process struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	conn   *grpc.ClientConn
+	exited chan struct{}
+}
+
+// run reads the handshake and then the logs from stdout, the process is
+// waited after stdout is closed, cmd.Wait must not be called before the reads finish
+func (p *process) run(kind plugin.Kind, stdout io.Reader, w io.Writer, handshakes chan<- handshakeResult) {
+	out := bufio.NewReader(stdout)
+	handshakes <- readHandshake(out, w)
+	_, _ = io.Copy(w, out)
+
+	err := p.cmd.Wait()
+	if err != nil {
+		log.Errorf(err, "%s plugin[%s] exited", kind, p.cmd.Path)
+	}
+	close(p.exited)
+}
+
+func (p *process) Exited() bool {
+	select {
+	case <-p.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *process) Stop() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	_ = p.stdin.Close()
+	select {
+	case <-p.exited:
+	case <-time.After(stopTimeout):
+		_ = p.cmd.Process.Kill()
+		<-p.exited
+	}
+}
+
+type handshakeResult struct {
+	h   handshake
+	err error
+}
+
+// readHandshake skips the lines written by the plugin before the handshake, e.g. logs
+func readHandshake(r *bufio.Reader, w io.Writer) handshakeResult {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return handshakeResult{err: fmt.Errorf("read handshake failed, %s", err)}
+		}
+		if !isHandshake(line) {
+			_, _ = w.Write([]byte(line))
+			continue
+		}
+		h, err := parseHandshake(line)
+		return handshakeResult{h, err}
+	}
+}
+
+func waitHandshake(handshakes <-chan handshakeResult, exited <-chan struct{}, timeout time.Duration) (handshake, error) {
+	select {
+	case ret := <-handshakes:
+		return ret.h, ret.err
+	case <-exited:
+		return handshake{}, errors.New("plugin exited before the handshake")
+	case <-time.After(timeout):
+		return handshake{}, errors.New("wait for the handshake timed out")
+	}
+}
+
+// logWriter writes the output of the plugin to the log line by line
+type logWriter struct {
+	prefix string
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	s := bufio.NewScanner(bytes.NewReader(p))
+	for s.Scan() {
+		if len(s.Bytes()) > 0 {
+			log.Info(w.prefix + s.Text())
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type echoRequest struct {
+	Message string `json:"message"`
+}
+
+type echoResponse struct {
+	Message string `json:"message"`
+	Pid     int    `json:"pid"`
+	Error   *Error `json:"error,omitempty"`
+}
+
+type echoServer interface {
+	Echo(msg string) (string, error)
+}
+
+type echo struct {
+}
+
+func (e *echo) Echo(msg string) (string, error) {
+	switch msg {
+	case "exit":
+		os.Exit(0)
+	case "error":
+		return "", errors.New("echo failed")
+	case "not found":
+		return "", discovery.NewError(discovery.ErrServiceNotExists, "not found")
+	}
+	return msg, nil
+}
+
+var echoDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName("echo"),
+	HandlerType: (*echoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		NewMethod("Echo", func() interface{} { return &echoRequest{} },
+			func(srv interface{}, _ context.Context, req interface{}) (interface{}, error) {
+				msg, err := srv.(echoServer).Echo(req.(*echoRequest).Message)
+				return &echoResponse{Message: msg, Pid: os.Getpid(), Error: NewError(err)}, nil
+			}),
+	},
+}
+
+// TestMain runs the test binary as the plugin process if started by the client
+func TestMain(m *testing.M) {
+	if os.Getenv(MagicCookieKey) == MagicCookieValue {
+		if err := Serve(&echoDesc, &echo{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestClient_Invoke(t *testing.T) {
+	c := NewClient("echo", os.Args[0])
+	defer c.Close()
+
+	resp := &echoResponse{}
+	assert.NoError(t, c.Invoke(context.Background(), "Echo", &echoRequest{Message: "hello"}, resp))
+	assert.Equal(t, "hello", resp.Message)
+	assert.NotEqual(t, os.Getpid(), resp.Pid)
+	pid := resp.Pid
+
+	t.Run("the plugin returns errors, should keep the code", func(t *testing.T) {
+		resp := &echoResponse{}
+		assert.NoError(t, c.Invoke(context.Background(), "Echo", &echoRequest{Message: "error"}, resp))
+		assert.EqualError(t, resp.Error.Err(), "echo failed")
+
+		resp = &echoResponse{}
+		assert.NoError(t, c.Invoke(context.Background(), "Echo", &echoRequest{Message: "not found"}, resp))
+		err, ok := resp.Error.Err().(*errsvc.Error)
+		assert.True(t, ok)
+		assert.Equal(t, discovery.ErrServiceNotExists, err.Code)
+	})
+
+	t.Run("the plugin exits, should be restarted by the next call", func(t *testing.T) {
+		_ = c.Invoke(context.Background(), "Echo", &echoRequest{Message: "exit"}, &echoResponse{})
+		select {
+		case <-c.proc.exited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the plugin does not exit")
+		}
+		resp := &echoResponse{}
+		assert.NoError(t, c.Invoke(context.Background(), "Echo", &echoRequest{Message: "again"}, resp))
+		assert.Equal(t, "again", resp.Message)
+		assert.NotEqual(t, pid, resp.Pid)
+	})
+
+	t.Run("the client is closed, should be failed", func(t *testing.T) {
+		c.Close()
+		assert.Equal(t, ErrClosed, c.Invoke(context.Background(), "Echo", &echoRequest{}, &echoResponse{}))
+	})
+}
+
+func TestClient_Start(t *testing.T) {
+	t.Run("the plugin does not exist, should be failed", func(t *testing.T) {
+		c := NewClient("echo", "/not/exist/echo_plugin")
+		assert.Error(t, c.Invoke(context.Background(), "Echo", &echoRequest{}, &echoResponse{}))
+	})
+
+	t.Run("the binary is not a plugin, should be failed", func(t *testing.T) {
+		c := NewClient("echo", "/bin/echo", "hello")
+		assert.Error(t, c.Invoke(context.Background(), "Echo", &echoRequest{}, &echoResponse{}))
+	})
+
+	t.Run("run the plugin directly, should be failed", func(t *testing.T) {
+		assert.Equal(t, ErrNotPluginProcess, Serve(&echoDesc, &echo{}))
+	})
+}
+
+func TestParseHandshake(t *testing.T) {
+	h, err := parseHandshake("1|unix|/tmp/plugin.sock|grpc\n")
+	assert.NoError(t, err)
+	assert.Equal(t, handshake{Version: 1, Network: "unix", Address: "/tmp/plugin.sock", Protocol: "grpc"}, h)
+	assert.Equal(t, "1|unix|/tmp/plugin.sock|grpc", h.String())
+
+	for _, line := range []string{"", "hello", "2|unix|/tmp/plugin.sock|grpc", "1|unix|/tmp/plugin.sock|netrpc", "1|udp|:1|grpc"} {
+		_, err = parseHandshake(line)
+		assert.Error(t, err, line)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The plugin process is started with the magic cookie in the env, it listens on
+// a unix socket and writes the handshake line to stdout, the format is
+// <protocol version>|<network>|<address>|<protocol>, e.g. 1|unix|/tmp/sc-plugin/plugin.sock|grpc
+const (
+	MagicCookieKey   = "SERVICECENTER_PLUGIN_MAGIC_COOKIE"
+	MagicCookieValue = "d5b8c7e1f4a64c0f9a3e6b2d8c1f7a90"
+	ProtocolVersion  = 1
+	ProtocolGRPC     = "grpc"
+)
+
+type handshake struct {
+	Version  int
+	Network  string
+	Address  string
+	Protocol string
+}
+
+func (h handshake) String() string {
+	return fmt.Sprintf("%d|%s|%s|%s", h.Version, h.Network, h.Address, h.Protocol)
+}
+
+// isHandshake returns true if the line looks like a handshake, the other
+// lines written by the plugin before the handshake are skipped
+func isHandshake(line string) bool {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 4 {
+		return false
+	}
+	_, err := strconv.Atoi(parts[0])
+	return err == nil
+}
+
+func parseHandshake(line string) (handshake, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 4 {
+		return handshake{}, fmt.Errorf("invalid handshake '%s'", line)
+	}
+	v, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return handshake{}, fmt.Errorf("invalid protocol version '%s'", parts[0])
+	}
+	h := handshake{Version: v, Network: parts[1], Address: parts[2], Protocol: parts[3]}
+	if h.Version != ProtocolVersion {
+		return handshake{}, fmt.Errorf("incompatible protocol version %d, expected %d", h.Version, ProtocolVersion)
+	}
+	if h.Protocol != ProtocolGRPC {
+		return handshake{}, fmt.Errorf("unsupported protocol '%s'", h.Protocol)
+	}
+	if h.Network != "unix" && h.Network != "tcp" {
+		return handshake{}, fmt.Errorf("unsupported network '%s'", h.Network)
+	}
+	return h, nil
+}
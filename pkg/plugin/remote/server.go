@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc"
+)
+
+var ErrNotPluginProcess = errors.New("this binary is a plugin of service center, " +
+	"it is not meant to be executed directly")
+
+// Serve is called in the main function of the plugin, it serves the
+// implementation of the plugin kind on a unix socket until service center
+// closes the stdin, e.g.
+//
+//	func main() {
+//		if err := remote.Serve(&cipherremote.ServiceDesc, &MyCipher{}); err != nil {
+//			fmt.Fprintln(os.Stderr, err)
+//			os.Exit(1)
+//		}
+//	}
+func Serve(desc *grpc.ServiceDesc, impl interface{}) error {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		return ErrNotPluginProcess
+	}
+	dir, err := ioutil.TempDir("", "sc-plugin")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	addr := filepath.Join(dir, "plugin.sock")
+	l, err := net.Listen("unix", addr)
+	if err != nil {
+		return err
+	}
+	return ServeListener(l, desc, impl, os.Stdin, os.Stdout)
+}
+
+// ServeListener writes the handshake of the listener to out and serves until
+// the stdin is closed
+func ServeListener(l net.Listener, desc *grpc.ServiceDesc, impl interface{}, stdin io.Reader, out io.Writer) error {
+	s := grpc.NewServer()
+	s.RegisterService(desc, impl)
+
+	h := handshake{
+		Version:  ProtocolVersion,
+		Network:  l.Addr().Network(),
+		Address:  l.Addr().String(),
+		Protocol: ProtocolGRPC,
+	}
+	if _, err := fmt.Fprintln(out, h.String()); err != nil {
+		_ = l.Close()
+		return err
+	}
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, stdin)
+		s.GracefulStop()
+	}()
+	return s.Serve(l)
+}
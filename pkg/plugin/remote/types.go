@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"google.golang.org/grpc"
+
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// ServiceName returns the gRPC service name of the plugin kind
+func ServiceName(kind plugin.Kind) string {
+	return "servicecomb.plugin." + kind.String()
+}
+
+// NewMethod returns the unary method of the service, the request is decoded
+// into the value returned by newRequest before calling handle
+func NewMethod(name string, newRequest func() interface{},
+	handle func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+			_ grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := newRequest()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			return handle(srv, ctx, req)
+		},
+	}
+}
+
+// Error is the error returned by the plugin implementation, the code is kept
+// if the plugin returns an *errsvc.Error
+type Error struct {
+	Code    int32  `json:"code,omitempty"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// NewError returns nil if err is nil
+func NewError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*errsvc.Error); ok {
+		return &Error{Code: e.Code, Message: e.Message, Detail: e.Detail}
+	}
+	return &Error{Message: err.Error()}
+}
+
+// Err returns nil if e is nil
+func (e *Error) Err() error {
+	if e == nil {
+		return nil
+	}
+	if e.Code != 0 {
+		return &errsvc.Error{Code: e.Code, Message: e.Message, Detail: e.Detail}
+	}
+	return errors.New(e.Message)
+}
+
+// HTTPRequest is the http request passed to the plugins without the body
+type HTTPRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	RemoteAddr string      `json:"remoteAddr,omitempty"`
+	// Pattern is the matched api pattern, e.g. /v4/:project/registry/microservices
+	Pattern string `json:"pattern,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	Project string `json:"project,omitempty"`
+}
+
+func NewHTTPRequest(r *http.Request) *HTTPRequest {
+	pattern, _ := r.Context().Value(rest.CtxMatchPattern).(string)
+	return &HTTPRequest{
+		Method:     r.Method,
+		URL:        r.URL.String(),
+		Header:     r.Header,
+		RemoteAddr: r.RemoteAddr,
+		Pattern:    pattern,
+		Domain:     util.ParseDomain(r.Context()),
+		Project:    util.ParseProject(r.Context()),
+	}
+}
+
+// Request rebuilds the http request in the plugin process
+func (h *HTTPRequest) Request(ctx context.Context) (*http.Request, error) {
+	r, err := http.NewRequest(h.Method, h.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if h.Header != nil {
+		r.Header = h.Header
+	}
+	r.RemoteAddr = h.RemoteAddr
+	ctx = util.SetDomainProject(ctx, h.Domain, h.Project)
+	if len(h.Pattern) > 0 {
+		ctx = util.SetContext(ctx, rest.CtxMatchPattern, h.Pattern)
+	}
+	return r.WithContext(ctx), nil
+}
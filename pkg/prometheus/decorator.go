@@ -58,6 +58,13 @@ func NewSummaryVec(opts prometheus.SummaryOpts, labelNames []string) *prometheus
 	return vec
 }
 
+func NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) *prometheus.HistogramVec {
+	name := util.StringJoin([]string{opts.Subsystem, opts.Name}, "_")
+	vec := prometheus.NewHistogramVec(opts, labelNames)
+	registerMetrics(name, vec)
+	return vec
+}
+
 func Gather() ([]*dto.MetricFamily, error) {
 	return prometheus.DefaultGatherer.Gather()
 }
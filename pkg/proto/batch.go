@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+type BatchRegisterInstancesRequest struct {
+	Instances []*discovery.MicroServiceInstance `json:"instances,omitempty"`
+}
+
+type BatchUnregisterInstancesRequest struct {
+	Instances []*discovery.HeartbeatSetElement `json:"instances,omitempty"`
+}
+
+// BatchInstancesResponse contains the result of each instance in the batch,
+// the ErrMessage is empty if the instance is processed successfully
+type BatchInstancesResponse struct {
+	Response  *discovery.Response        `json:"-"`
+	Instances []*discovery.InstanceHbRst `json:"instances,omitempty"`
+}
@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+// DependencySeen stamps the relation between the consumer and the provider
+// with the last time the consumer found the provider instances
+type DependencySeen struct {
+	DomainProject string `json:"domainProject"`
+	ConsumerId    string `json:"consumerId"`
+	// Provider is the provider rule of the relation, the relation is identified
+	// by the environment, appId and serviceName of the rule
+	Provider *discovery.MicroServiceKey `json:"provider"`
+	// LastSeen is the unix timestamp in seconds
+	LastSeen int64 `json:"lastSeen"`
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+// PropDrainDeadline is the reserved instance property key of the unix
+// timestamp when the draining instance will be unregistered
+const PropDrainDeadline = "sc.drainDeadline"
+
+type DrainInstanceRequest struct {
+	ServiceId  string `json:"serviceId,omitempty"`
+	InstanceId string `json:"instanceId,omitempty"`
+	// Timeout is the seconds to wait before unregistering the draining instance
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+type GetDrainStatusRequest struct {
+	ServiceId  string `json:"serviceId,omitempty"`
+	InstanceId string `json:"instanceId,omitempty"`
+}
+
+type DrainInstanceResponse struct {
+	Response *discovery.Response `json:"-"`
+	Status   *DrainStatus        `json:"status,omitempty"`
+}
+
+// DrainStatus is the progress of a draining instance
+type DrainStatus struct {
+	ServiceId  string `json:"serviceId"`
+	InstanceId string `json:"instanceId"`
+	// Revision is the revision of the first OUTOFSERVICE event pushed to watchers
+	Revision int64 `json:"revision,omitempty"`
+	// Deadline is the unix timestamp when the instance will be unregistered
+	Deadline int64 `json:"deadline"`
+	// Notified is the number of consumer watchers the event dispatched to,
+	// Notified, Delivered and Consumers only count the watchers connected to
+	// the service center node which serves the request
+	Notified int `json:"notified"`
+	// Delivered is the number of consumer watchers the event written to,
+	// it is not an acknowledgement of the consumers applying the event
+	Delivered int `json:"delivered"`
+	// Consumers is the consumer service ids which the event delivered to
+	Consumers []string `json:"consumers,omitempty"`
+}
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+// ImpactRequest specifies the services to retire, the service of ServiceId,
+// or the versions of the service matching VersionRule, e.g. latest, 1.0.0+, 1.0.0-2.0.0
+type ImpactRequest struct {
+	ServiceId   string `json:"serviceId,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	AppId       string `json:"appId,omitempty"`
+	ServiceName string `json:"serviceName,omitempty"`
+	VersionRule string `json:"versionRule,omitempty"`
+}
+
+// ImpactedConsumer is a direct or transitive consumer of the retiring services
+type ImpactedConsumer struct {
+	ServiceId   string `json:"serviceId"`
+	Environment string `json:"environment,omitempty"`
+	AppId       string `json:"appId"`
+	ServiceName string `json:"serviceName"`
+	Version     string `json:"version"`
+	// ProviderId is the service id of the provider the consumer depends on,
+	// Depth is 1 if the provider is one of the retiring services
+	ProviderId string `json:"providerId"`
+	Depth      int    `json:"depth"`
+	// VersionRule is the provider version rule the consumer used, e.g. latest, 1.0+
+	VersionRule string `json:"versionRule,omitempty"`
+	UpInstances int    `json:"upInstances"`
+}
+
+type ImpactResponse struct {
+	Response *discovery.Response `json:"-"`
+	// ServiceIds are the retiring services
+	ServiceIds []string            `json:"serviceIds"`
+	Consumers  []*ImpactedConsumer `json:"consumers,omitempty"`
+}
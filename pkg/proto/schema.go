@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/schema"
+)
+
+type CheckSchemaCompatibilityRequest struct {
+	ServiceId string `json:"serviceId,omitempty"`
+	SchemaId  string `json:"schemaId,omitempty"`
+	Schema    string `json:"schema,omitempty"`
+}
+
+type CheckSchemaCompatibilityResponse struct {
+	Response *discovery.Response `json:"-"`
+	// Policy is the compatibility policy of the service environment
+	Policy     string           `json:"policy"`
+	Compatible bool             `json:"compatible"`
+	Changes    []*schema.Change `json:"changes,omitempty"`
+}
+
+// SchemaRevision is a revision in the modification history of a schema
+type SchemaRevision struct {
+	Revision int64 `json:"revision"`
+	// Hash is the sha256 of the schema content
+	Hash      string `json:"hash"`
+	Summary   string `json:"summary,omitempty"`
+	Timestamp string `json:"timestamp"`
+	// Account is the user who modified the schema, empty if rbac is disabled
+	Account string `json:"account,omitempty"`
+	Schema  string `json:"schema,omitempty"`
+}
+
+type ListSchemaRevisionsRequest struct {
+	ServiceId string `json:"serviceId,omitempty"`
+	SchemaId  string `json:"schemaId,omitempty"`
+}
+
+type ListSchemaRevisionsResponse struct {
+	Response *discovery.Response `json:"-"`
+	// Revisions are sorted by the revision in descending order, without the schema content
+	Revisions []*SchemaRevision `json:"revisions,omitempty"`
+}
+
+type GetSchemaRevisionRequest struct {
+	ServiceId string `json:"serviceId,omitempty"`
+	SchemaId  string `json:"schemaId,omitempty"`
+	Revision  int64  `json:"revision,omitempty"`
+}
+
+type GetSchemaRevisionResponse struct {
+	Response *discovery.Response `json:"-"`
+	Revision *SchemaRevision     `json:"revision,omitempty"`
+}
+
+type DiffSchemaRevisionsRequest struct {
+	ServiceId string `json:"serviceId,omitempty"`
+	SchemaId  string `json:"schemaId,omitempty"`
+	// From is 0 means the revision before To
+	From int64 `json:"from,omitempty"`
+	// To is 0 means the latest revision
+	To int64 `json:"to,omitempty"`
+}
+
+type DiffSchemaRevisionsResponse struct {
+	Response *discovery.Response `json:"-"`
+	From     int64               `json:"from"`
+	To       int64               `json:"to"`
+	// Diff is the unified diff of the schema contents
+	Diff       string           `json:"diff,omitempty"`
+	Compatible bool             `json:"compatible"`
+	Changes    []*schema.Change `json:"changes,omitempty"`
+}
@@ -24,6 +24,20 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+type ServiceCtrlServerEx interface {
+	ServiceCtrlServer
+
+	CheckSchemaCompatibility(ctx context.Context, in *CheckSchemaCompatibilityRequest) (*CheckSchemaCompatibilityResponse, error)
+
+	ListSchemaRevisions(ctx context.Context, in *ListSchemaRevisionsRequest) (*ListSchemaRevisionsResponse, error)
+
+	GetSchemaRevision(ctx context.Context, in *GetSchemaRevisionRequest) (*GetSchemaRevisionResponse, error)
+
+	DiffSchemaRevisions(ctx context.Context, in *DiffSchemaRevisionsRequest) (*DiffSchemaRevisionsResponse, error)
+
+	GetImpact(ctx context.Context, in *ImpactRequest) (*ImpactResponse, error)
+}
+
 type ServiceInstanceCtrlServerEx interface {
 	ServiceInstanceCtrlServer
 
@@ -34,4 +48,12 @@ type ServiceInstanceCtrlServerEx interface {
 	WatchHeartbeat(ctx context.Context, in *discovery.HeartbeatRequest, conn *websocket.Conn)
 
 	ClusterHealth(ctx context.Context) (*discovery.GetInstancesResponse, error)
+
+	Drain(ctx context.Context, in *DrainInstanceRequest) (*DrainInstanceResponse, error)
+
+	GetDrainStatus(ctx context.Context, in *GetDrainStatusRequest) (*DrainInstanceResponse, error)
+
+	BatchRegister(ctx context.Context, in *BatchRegisterInstancesRequest) (*BatchInstancesResponse, error)
+
+	BatchUnregister(ctx context.Context, in *BatchUnregisterInstancesRequest) (*BatchInstancesResponse, error)
 }
@@ -0,0 +1,392 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package schema checks the backward compatibility of the OpenAPI
+// (swagger 2.0 and OpenAPI 3.x) documents registered as microservice schemas
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	KindPathAdded              = "PathAdded"
+	KindPathRemoved            = "PathRemoved"
+	KindOperationAdded         = "OperationAdded"
+	KindOperationRemoved       = "OperationRemoved"
+	KindParameterAdded         = "ParameterAdded"
+	KindParameterRemoved       = "ParameterRemoved"
+	KindRequiredParameterAdded = "RequiredParameterAdded"
+	KindFieldAdded             = "FieldAdded"
+	KindFieldRemoved           = "FieldRemoved"
+	KindRequiredFieldAdded     = "RequiredFieldAdded"
+	KindRequiredFieldRemoved   = "RequiredFieldRemoved"
+	KindDefinitionRemoved      = "DefinitionRemoved"
+	KindTypeChanged            = "TypeChanged"
+)
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Change is a difference between two revisions of a schema
+type Change struct {
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+	Breaking bool   `json:"breaking"`
+}
+
+// Report is the result of the compatibility checking
+type Report struct {
+	Changes []*Change `json:"changes,omitempty"`
+}
+
+// Compatible returns false if any of the changes is breaking
+func (r *Report) Compatible() bool {
+	return len(r.Breaking()) == 0
+}
+
+// Breaking returns the breaking changes
+func (r *Report) Breaking() []*Change {
+	var changes []*Change
+	for _, c := range r.Changes {
+		if c.Breaking {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String returns the summary of the breaking changes
+func (r *Report) String() string {
+	var msgs []string
+	for _, c := range r.Breaking() {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", c.Location, c.Message))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (r *Report) add(kind, location string, breaking bool, format string, args ...interface{}) {
+	r.Changes = append(r.Changes, &Change{
+		Kind:     kind,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+type document map[string]interface{}
+
+// parse parses the yaml or json format OpenAPI document
+func parse(content string) (document, error) {
+	doc := document{}
+	if len(strings.TrimSpace(content)) == 0 {
+		return doc, nil
+	}
+	b, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Check compares the new schema with the old one and classifies the changes,
+// an empty old schema is always compatible
+func Check(oldSchema, newSchema string) (*Report, error) {
+	report := &Report{}
+	if len(strings.TrimSpace(oldSchema)) == 0 {
+		return report, nil
+	}
+	oldDoc, err := parse(oldSchema)
+	if err != nil {
+		return nil, fmt.Errorf("parse old schema failed, %s", err.Error())
+	}
+	newDoc, err := parse(newSchema)
+	if err != nil {
+		return nil, fmt.Errorf("parse new schema failed, %s", err.Error())
+	}
+	c := &checker{oldDoc: oldDoc, newDoc: newDoc, report: report}
+	c.checkPaths()
+	c.checkDefinitions()
+	return report, nil
+}
+
+type checker struct {
+	oldDoc document
+	newDoc document
+	report *Report
+}
+
+func (c *checker) checkPaths() {
+	oldPaths, newPaths := toMap(c.oldDoc["paths"]), toMap(c.newDoc["paths"])
+	for _, p := range sortedKeys(oldPaths) {
+		newItem, ok := newPaths[p]
+		if !ok {
+			c.report.add(KindPathRemoved, p, true, "path is removed")
+			continue
+		}
+		c.checkPathItem(p, toMap(oldPaths[p]), toMap(newItem))
+	}
+	for _, p := range sortedKeys(newPaths) {
+		if _, ok := oldPaths[p]; !ok {
+			c.report.add(KindPathAdded, p, false, "path is added")
+		}
+	}
+}
+
+func (c *checker) checkPathItem(p string, oldItem, newItem map[string]interface{}) {
+	for _, method := range methods {
+		oldOp, hasOld := oldItem[method]
+		newOp, hasNew := newItem[method]
+		location := strings.ToUpper(method) + " " + p
+		switch {
+		case hasOld && !hasNew:
+			c.report.add(KindOperationRemoved, location, true, "operation is removed")
+		case !hasOld && hasNew:
+			c.report.add(KindOperationAdded, location, false, "operation is added")
+		case hasOld && hasNew:
+			c.checkOperation(location,
+				c.parameters(c.oldDoc, oldItem, toMap(oldOp)), c.parameters(c.newDoc, newItem, toMap(newOp)))
+			c.checkRequestBody(location, toMap(oldOp), toMap(newOp))
+		}
+	}
+}
+
+func (c *checker) checkOperation(location string, oldParams, newParams map[string]map[string]interface{}) {
+	for _, key := range sortedParamKeys(oldParams) {
+		newParam, ok := newParams[key]
+		if !ok {
+			c.report.add(KindParameterRemoved, location, false, "parameter %s is removed", key)
+			continue
+		}
+		oldParam := oldParams[key]
+		if !isRequired(oldParam) && isRequired(newParam) {
+			c.report.add(KindRequiredParameterAdded, location, true, "parameter %s becomes required", key)
+		}
+		c.checkSchema(location+" parameter "+key, paramSchema(oldParam), paramSchema(newParam))
+	}
+	for _, key := range sortedParamKeys(newParams) {
+		if _, ok := oldParams[key]; ok {
+			continue
+		}
+		if isRequired(newParams[key]) {
+			c.report.add(KindRequiredParameterAdded, location, true, "required parameter %s is added", key)
+			continue
+		}
+		c.report.add(KindParameterAdded, location, false, "optional parameter %s is added", key)
+	}
+}
+
+// checkRequestBody compares the OpenAPI 3.x request bodies
+func (c *checker) checkRequestBody(location string, oldOp, newOp map[string]interface{}) {
+	oldBody := toMap(c.resolve(c.oldDoc, oldOp["requestBody"]))
+	newBody := toMap(c.resolve(c.newDoc, newOp["requestBody"]))
+	if len(oldBody) == 0 {
+		if isRequired(newBody) {
+			c.report.add(KindRequiredParameterAdded, location, true, "required request body is added")
+		}
+		return
+	}
+	if len(newBody) == 0 {
+		c.report.add(KindParameterRemoved, location, false, "request body is removed")
+		return
+	}
+	if !isRequired(oldBody) && isRequired(newBody) {
+		c.report.add(KindRequiredParameterAdded, location, true, "request body becomes required")
+	}
+	oldContent, newContent := toMap(oldBody["content"]), toMap(newBody["content"])
+	for _, mediaType := range sortedKeys(oldContent) {
+		newMedia, ok := newContent[mediaType]
+		if !ok {
+			c.report.add(KindOperationRemoved, location, true, "request body media type %s is removed", mediaType)
+			continue
+		}
+		c.checkSchema(location+" body "+mediaType,
+			toMap(toMap(oldContent[mediaType])["schema"]), toMap(toMap(newMedia)["schema"]))
+	}
+}
+
+// checkDefinitions compares the swagger 2.0 definitions and the OpenAPI 3.x
+// component schemas
+func (c *checker) checkDefinitions() {
+	c.checkDefinitionSet("#/definitions/", toMap(c.oldDoc["definitions"]), toMap(c.newDoc["definitions"]))
+	c.checkDefinitionSet("#/components/schemas/",
+		toMap(toMap(c.oldDoc["components"])["schemas"]), toMap(toMap(c.newDoc["components"])["schemas"]))
+}
+
+func (c *checker) checkDefinitionSet(prefix string, oldDefs, newDefs map[string]interface{}) {
+	for _, name := range sortedKeys(oldDefs) {
+		newDef, ok := newDefs[name]
+		if !ok {
+			c.report.add(KindDefinitionRemoved, prefix+name, true, "definition is removed")
+			continue
+		}
+		c.checkSchema(prefix+name, toMap(oldDefs[name]), toMap(newDef))
+	}
+}
+
+// checkSchema compares two json schemas, the referenced schemas are compared
+// in checkDefinitions, so only the reference itself is compared here
+func (c *checker) checkSchema(location string, oldSchema, newSchema map[string]interface{}) {
+	if len(oldSchema) == 0 || len(newSchema) == 0 {
+		return
+	}
+	oldRef, newRef := toString(oldSchema["$ref"]), toString(newSchema["$ref"])
+	if len(oldRef) > 0 || len(newRef) > 0 {
+		if oldRef != newRef {
+			c.report.add(KindTypeChanged, location, true, "type is changed from '%s' to '%s'",
+				typeOf(oldSchema), typeOf(newSchema))
+		}
+		return
+	}
+	if oldType, newType := typeOf(oldSchema), typeOf(newSchema); oldType != newType {
+		c.report.add(KindTypeChanged, location, true, "type is changed from '%s' to '%s'", oldType, newType)
+		return
+	}
+	c.checkSchema(location+"[]", toMap(oldSchema["items"]), toMap(newSchema["items"]))
+
+	oldRequired, newRequired := toSet(oldSchema["required"]), toSet(newSchema["required"])
+	oldProps, newProps := toMap(oldSchema["properties"]), toMap(newSchema["properties"])
+	for _, name := range sortedKeys(oldProps) {
+		field := location + "." + name
+		newProp, ok := newProps[name]
+		if !ok {
+			if _, required := oldRequired[name]; required {
+				c.report.add(KindRequiredFieldRemoved, field, true, "required field is removed")
+				continue
+			}
+			c.report.add(KindFieldRemoved, field, false, "optional field is removed")
+			continue
+		}
+		if _, required := newRequired[name]; required {
+			if _, ok := oldRequired[name]; !ok {
+				c.report.add(KindRequiredFieldAdded, field, true, "field becomes required")
+			}
+		}
+		c.checkSchema(field, toMap(oldProps[name]), toMap(newProp))
+	}
+	for _, name := range sortedKeys(newProps) {
+		if _, ok := oldProps[name]; ok {
+			continue
+		}
+		field := location + "." + name
+		if _, required := newRequired[name]; required {
+			c.report.add(KindRequiredFieldAdded, field, true, "required field is added")
+			continue
+		}
+		c.report.add(KindFieldAdded, field, false, "optional field is added")
+	}
+}
+
+// parameters returns the operation parameters merged with the path item
+// parameters, indexed by 'in:name'
+func (c *checker) parameters(doc document, item, op map[string]interface{}) map[string]map[string]interface{} {
+	params := make(map[string]map[string]interface{})
+	for _, list := range []interface{}{item["parameters"], op["parameters"]} {
+		arr, _ := list.([]interface{})
+		for _, v := range arr {
+			param := toMap(c.resolve(doc, v))
+			name := toString(param["name"])
+			if len(name) == 0 {
+				continue
+			}
+			params[toString(param["in"])+":"+name] = param
+		}
+	}
+	return params
+}
+
+func paramSchema(param map[string]interface{}) map[string]interface{} {
+	if s, ok := param["schema"]; ok {
+		return toMap(s)
+	}
+	// swagger 2.0 non-body parameters define the type inline
+	return param
+}
+
+// resolve returns the object referenced by the local '$ref'
+func (c *checker) resolve(doc document, v interface{}) interface{} {
+	ref := toString(toMap(v)["$ref"])
+	if !strings.HasPrefix(ref, "#/") {
+		return v
+	}
+	var cur interface{} = map[string]interface{}(doc)
+	for _, seg := range strings.Split(ref[2:], "/") {
+		cur = toMap(cur)[seg]
+	}
+	return cur
+}
+
+func typeOf(s map[string]interface{}) string {
+	if ref := toString(s["$ref"]); len(ref) > 0 {
+		return ref
+	}
+	t := toString(s["type"])
+	if t == "array" {
+		return t + "<" + typeOf(toMap(s["items"])) + ">"
+	}
+	return t
+}
+
+func isRequired(m map[string]interface{}) bool {
+	b, _ := m["required"].(bool)
+	return b
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func toString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func toSet(v interface{}) map[string]struct{} {
+	set := make(map[string]struct{})
+	arr, _ := v.([]interface{})
+	for _, item := range arr {
+		set[toString(item)] = struct{}{}
+	}
+	return set
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedParamKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,276 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const swaggerV1 = `
+swagger: "2.0"
+paths:
+  /users/{id}:
+    parameters:
+    - name: id
+      in: path
+      required: true
+      type: string
+    get:
+      parameters:
+      - name: verbose
+        in: query
+        type: boolean
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/User"
+    delete:
+      responses:
+        204: {}
+  /users:
+    post:
+      parameters:
+      - name: body
+        in: body
+        required: true
+        schema:
+          $ref: "#/definitions/User"
+definitions:
+  User:
+    type: object
+    required:
+    - name
+    properties:
+      name:
+        type: string
+      age:
+        type: integer
+`
+
+const openapiV1 = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/orders": {
+      "post": {
+        "requestBody": {
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Order": {"type": "object", "properties": {"id": {"type": "string"}}}
+    }
+  }
+}`
+
+func kinds(report *schema.Report) map[string]bool {
+	m := make(map[string]bool)
+	for _, c := range report.Changes {
+		m[c.Kind] = c.Breaking
+	}
+	return m
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("empty old schema should be compatible", func(t *testing.T) {
+		report, err := schema.Check("", swaggerV1)
+		assert.NoError(t, err)
+		assert.True(t, report.Compatible())
+		assert.Empty(t, report.Changes)
+	})
+
+	t.Run("same schema should be compatible", func(t *testing.T) {
+		report, err := schema.Check(swaggerV1, swaggerV1)
+		assert.NoError(t, err)
+		assert.True(t, report.Compatible())
+		assert.Empty(t, report.Changes)
+	})
+
+	t.Run("invalid schema should return error", func(t *testing.T) {
+		_, err := schema.Check(swaggerV1, "paths: [")
+		assert.Error(t, err)
+	})
+
+	t.Run("add optional field should be compatible", func(t *testing.T) {
+		report, err := schema.Check(swaggerV1, swaggerV1+`
+      email:
+        type: string
+`)
+		assert.NoError(t, err)
+		assert.True(t, report.Compatible())
+		assert.Equal(t, map[string]bool{schema.KindFieldAdded: false}, kinds(report))
+	})
+
+	t.Run("remove path and operation should be breaking", func(t *testing.T) {
+		report, err := schema.Check(swaggerV1, `
+swagger: "2.0"
+paths:
+  /users/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      - name: verbose
+        in: query
+        type: boolean
+definitions:
+  User:
+    type: object
+    required:
+    - name
+    properties:
+      name:
+        type: string
+      age:
+        type: integer
+`)
+		assert.NoError(t, err)
+		assert.False(t, report.Compatible())
+		assert.Equal(t, map[string]bool{
+			schema.KindPathRemoved:      true,
+			schema.KindOperationRemoved: true,
+		}, kinds(report))
+		assert.NotEmpty(t, report.String())
+	})
+
+	t.Run("new required parameter and type change should be breaking", func(t *testing.T) {
+		report, err := schema.Check(swaggerV1, `
+swagger: "2.0"
+paths:
+  /users/{id}:
+    parameters:
+    - name: id
+      in: path
+      required: true
+      type: integer
+    get:
+      parameters:
+      - name: verbose
+        in: query
+        type: boolean
+      - name: tenant
+        in: header
+        required: true
+        type: string
+    delete:
+      responses:
+        204: {}
+  /users:
+    post:
+      parameters:
+      - name: body
+        in: body
+        required: true
+        schema:
+          $ref: "#/definitions/User"
+definitions:
+  User:
+    type: object
+    required:
+    - name
+    properties:
+      name:
+        type: string
+      age:
+        type: integer
+`)
+		assert.NoError(t, err)
+		assert.False(t, report.Compatible())
+		assert.Equal(t, map[string]bool{
+			schema.KindTypeChanged:            true,
+			schema.KindRequiredParameterAdded: true,
+		}, kinds(report))
+	})
+
+	t.Run("remove required field should be breaking", func(t *testing.T) {
+		report, err := schema.Check(swaggerV1, `
+swagger: "2.0"
+paths:
+  /users/{id}:
+    parameters:
+    - name: id
+      in: path
+      required: true
+      type: string
+    get:
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/User"
+    delete:
+      responses:
+        204: {}
+  /users:
+    post:
+      parameters:
+      - name: body
+        in: body
+        required: true
+        schema:
+          $ref: "#/definitions/User"
+definitions:
+  User:
+    type: object
+    properties:
+      age:
+        type: string
+`)
+		assert.NoError(t, err)
+		assert.False(t, report.Compatible())
+		assert.Equal(t, map[string]bool{
+			schema.KindParameterRemoved:     false,
+			schema.KindRequiredFieldRemoved: true,
+			schema.KindTypeChanged:          true,
+		}, kinds(report))
+	})
+
+	t.Run("openapi 3 request body changes", func(t *testing.T) {
+		report, err := schema.Check(openapiV1, `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/orders": {
+      "post": {
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Order": {"type": "object", "required": ["id", "count"],
+        "properties": {"id": {"type": "string"}, "count": {"type": "integer"}}}
+    }
+  }
+}`)
+		assert.NoError(t, err)
+		assert.False(t, report.Compatible())
+		assert.Equal(t, map[string]bool{
+			schema.KindRequiredParameterAdded: true,
+			schema.KindRequiredFieldAdded:     true,
+		}, kinds(report))
+	})
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("same schema should return empty diff", func(t *testing.T) {
+		diff, err := schema.Diff(swaggerV1, swaggerV1, "a", "b")
+		assert.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("modified schema should return unified diff", func(t *testing.T) {
+		diff, err := schema.Diff("a: 1\nb: 2\n", "a: 1\nb: 3\n", "test@1", "test@2")
+		assert.NoError(t, err)
+		assert.Equal(t, "--- test@1\n+++ test@2\n@@ -1,2 +1,2 @@\n a: 1\n-b: 2\n+b: 3\n", diff)
+	})
+}
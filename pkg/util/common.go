@@ -23,11 +23,13 @@ type CtxKey string
 
 const (
 	HeaderRev                  = "X-Resource-Revision"
+	HeaderConsumerID           = "X-ConsumerId"
 	CtxGlobal           CtxKey = "global"
 	CtxNocache          CtxKey = "noCache"
 	CtxCacheOnly        CtxKey = "cacheOnly"
 	CtxRequestRevision  CtxKey = "requestRev"
 	CtxResponseRevision CtxKey = "responseRev"
+	CtxConsumerID       CtxKey = "consumerId"
 )
 
 func GetAppRoot() string {
@@ -104,3 +104,46 @@ func VersionToInt64(versionStr string) (int64, error) {
 	ret := util.Int16ToInt64(verBytes[:])
 	return ret, nil
 }
+
+// VersionMatchRule returns true if the version matches the rule of the form
+// 'latest', x[.y[.z]]+, x[.y[.z]]-x[.y[.z]] or the exact version,
+// the invalid versions never match the range rules
+func VersionMatchRule(version, versionRule string) bool {
+	if len(versionRule) == 0 {
+		return false
+	}
+	rangeIdx := strings.Index(versionRule, "-")
+	switch {
+	case versionRule == "latest":
+		return true
+	case versionRule[len(versionRule)-1:] == "+":
+		ver, err := VersionToInt64(version)
+		if err != nil {
+			return false
+		}
+		start, err := VersionToInt64(versionRule[:len(versionRule)-1])
+		if err != nil {
+			return false
+		}
+		return ver >= start
+	case rangeIdx > 0:
+		ver, err := VersionToInt64(version)
+		if err != nil {
+			return false
+		}
+		start, err := VersionToInt64(versionRule[:rangeIdx])
+		if err != nil {
+			return false
+		}
+		end, err := VersionToInt64(versionRule[rangeIdx+1:])
+		if err != nil {
+			return false
+		}
+		if start > end {
+			start, end = end, start
+		}
+		return ver >= start && ver < end
+	default:
+		return version == versionRule
+	}
+}
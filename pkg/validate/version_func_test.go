@@ -98,3 +98,37 @@ func TestNewVersionRegexp(t *testing.T) {
 		assert.NotEqual(t, "", vr.String())
 	})
 }
+
+func TestVersionMatchRule(t *testing.T) {
+	t.Run("explicit", func(t *testing.T) {
+		assert.Equal(t, true, validate.VersionMatchRule("1.0", "1.0"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.0", "1.2"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.0", ""))
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		assert.Equal(t, true, validate.VersionMatchRule("1.0", "latest"))
+	})
+
+	t.Run("range ver in [1.4, 1.8]", func(t *testing.T) {
+		assert.Equal(t, true, validate.VersionMatchRule("1.4", "1.4-1.8"))
+		assert.Equal(t, true, validate.VersionMatchRule("1.6", "1.4-1.8"))
+		assert.Equal(t, true, validate.VersionMatchRule("1.6", "1.8-1.4"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.8", "1.4-1.8"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.0", "1.4-1.8"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.9", "1.4-1.8"))
+	})
+
+	t.Run("atLess ver >= 1.6", func(t *testing.T) {
+		assert.Equal(t, true, validate.VersionMatchRule("1.6", "1.6+"))
+		assert.Equal(t, true, validate.VersionMatchRule("1.9", "1.6+"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.0", "1.6+"))
+	})
+
+	t.Run("invalid version, should not match", func(t *testing.T) {
+		assert.Equal(t, false, validate.VersionMatchRule("a", "0+"))
+		assert.Equal(t, false, validate.VersionMatchRule("a", "0-1.0"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.0", "a+"))
+		assert.Equal(t, false, validate.VersionMatchRule("1.0", "0-a"))
+	})
+}
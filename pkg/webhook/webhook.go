@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webhook sends the http requests to the webhooks, retries with
+// backoff if it fails, and keeps the latest records of the requests
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/queue"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+)
+
+const (
+	MaxAttempts    = 5
+	MaxRecords     = 100
+	ResponseLimit  = 1024
+	RequestTimeout = 10 * time.Second
+)
+
+// Request is the http request sent to the webhook
+type Request struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+}
+
+// Result is the result of sending the request, Response is truncated to ResponseLimit
+type Result struct {
+	Success    bool
+	Attempts   int
+	StatusCode int
+	Response   string
+	Error      string
+	DoneAt     time.Time
+}
+
+// Sender sends the requests to the webhooks
+type Sender struct {
+	Client  *rest.URLClient
+	Backoff backoff.Backoff
+}
+
+// NewSender returns the sender with the default backoff
+func NewSender() (*Sender, error) {
+	option := rest.DefaultURLClientOption()
+	option.RequestTimeout = RequestTimeout
+	option.Compressed = false
+	client, err := rest.GetURLClient(option)
+	if err != nil {
+		return nil, err
+	}
+	return &Sender{Client: client, Backoff: backoff.GetBackoff()}, nil
+}
+
+// NewQueue returns the queue to send the requests asynchronously,
+// the worker is created with the sender
+func NewQueue(newWorker func(sender *Sender) queue.Worker) *queue.TaskQueue {
+	sender, err := NewSender()
+	if err != nil {
+		panic(err)
+	}
+	q := queue.NewTaskQueue(0)
+	q.AddWorker(newWorker(sender))
+	return q
+}
+
+// Send sends the request, retries with backoff if it fails, at most MaxAttempts times
+func (s *Sender) Send(ctx context.Context, req *Request) *Result {
+	method := req.Method
+	if len(method) == 0 {
+		method = http.MethodPost
+	}
+	result := &Result{}
+	for result.Attempts < MaxAttempts {
+		if result.Attempts > 0 {
+			select {
+			case <-ctx.Done():
+				result.Error = ctx.Err().Error()
+				result.DoneAt = time.Now()
+				return result
+			case <-time.After(s.Backoff.Delay(result.Attempts)):
+			}
+		}
+		result.Attempts++
+		err := s.send(ctx, method, req, result)
+		if err == nil {
+			break
+		}
+		result.Error = err.Error()
+		log.Warnf("webhook %s %s failed, attempts %d: %s", method, req.URL, result.Attempts, err)
+	}
+	result.DoneAt = time.Now()
+	return result
+}
+
+func (s *Sender) send(ctx context.Context, method string, req *Request, result *Result) error {
+	headers := req.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	resp, err := s.Client.HTTPDoWithContext(ctx, method, req.URL, headers, req.Body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if len(content) > ResponseLimit {
+		content = content[:ResponseLimit]
+	}
+	result.StatusCode = resp.StatusCode
+	result.Response = string(content)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	result.Success = true
+	result.Error = ""
+	return nil
+}
+
+// Records is the records of the requests sent to a webhook
+type Records interface {
+	Len() int
+	Swap(i, j int)
+	// ID returns the id of the i-th record
+	ID(i int) string
+	// Time returns the time of the i-th record in RFC3339
+	Time(i int) string
+}
+
+type latestFirst struct {
+	Records
+}
+
+func (r latestFirst) Less(i, j int) bool {
+	return r.Time(i) > r.Time(j)
+}
+
+// SortRecords sorts the records, the latest first
+func SortRecords(records Records) {
+	sort.Stable(latestFirst{records})
+}
+
+// Save saves the record by put, then removes the oldest records beyond MaxRecords
+func Save(ctx context.Context, put func(ctx context.Context) error,
+	list func(ctx context.Context) (Records, error),
+	remove func(ctx context.Context, id string) error) error {
+	if err := put(ctx); err != nil {
+		return err
+	}
+	records, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	SortRecords(records)
+	for i := MaxRecords; i < records.Len(); i++ {
+		if err := remove(ctx, records.ID(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
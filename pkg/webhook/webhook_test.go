@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	"github.com/apache/servicecomb-service-center/pkg/webhook"
+)
+
+type record struct {
+	id   string
+	time string
+}
+
+type records []*record
+
+func (r records) Len() int          { return len(r) }
+func (r records) Swap(i, j int)     { r[i], r[j] = r[j], r[i] }
+func (r records) ID(i int) string   { return r[i].id }
+func (r records) Time(i int) string { return r[i].time }
+
+func TestSender_Send(t *testing.T) {
+	sender, err := webhook.NewSender()
+	assert.NoError(t, err)
+	sender.Backoff = &backoff.PowerBackoff{InitDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	t.Run("retry until succeeded, should pass", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "v", r.Header.Get("X-Key"))
+			if requests == 1 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rw.Write(make([]byte, webhook.ResponseLimit+1))
+		}))
+		defer server.Close()
+
+		result := sender.Send(context.Background(), &webhook.Request{
+			URL:     server.URL,
+			Headers: http.Header{"X-Key": []string{"v"}},
+		})
+		assert.True(t, result.Success)
+		assert.Equal(t, 2, result.Attempts)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Len(t, result.Response, webhook.ResponseLimit)
+		assert.Empty(t, result.Error)
+		assert.False(t, result.DoneAt.IsZero())
+	})
+
+	t.Run("give up after max attempts, should pass", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		result := sender.Send(context.Background(), &webhook.Request{Method: http.MethodPut, URL: server.URL})
+		assert.False(t, result.Success)
+		assert.Equal(t, webhook.MaxAttempts, result.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		assert.NotEmpty(t, result.Error)
+	})
+}
+
+func TestSave(t *testing.T) {
+	var saved records
+	for i := 0; i < webhook.MaxRecords; i++ {
+		saved = append(saved, &record{id: fmt.Sprint(i), time: time.Unix(int64(i), 0).UTC().Format(time.RFC3339)})
+	}
+	var removed []string
+	err := webhook.Save(context.Background(), func(ctx context.Context) error {
+		saved = append(saved, &record{id: "latest", time: time.Now().UTC().Format(time.RFC3339)})
+		return nil
+	}, func(ctx context.Context) (webhook.Records, error) {
+		return saved, nil
+	}, func(ctx context.Context, id string) error {
+		removed = append(removed, id)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0"}, removed)
+	assert.Equal(t, "latest", saved[0].id)
+}
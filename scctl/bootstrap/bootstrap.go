@@ -28,5 +28,17 @@ import (
 
 	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get/cluster"
 
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get/lease"
+
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get/graph"
+
 	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/health"
+
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/export"
+
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/imports"
+
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/apply"
+
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/cipher"
 )
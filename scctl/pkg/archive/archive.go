@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package archive defines the registry backup format written by 'scctl export'
+// and replayed by 'scctl import'.
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+)
+
+// Version is the archive format version, the archive with a different version can not be imported
+const Version = "1"
+
+// StdStream is the file name that means reading from stdin or writing to stdout
+const StdStream = "-"
+
+const gzipSuffix = ".gz"
+
+type Archive struct {
+	Version   string          `json:"version"`
+	Timestamp string          `json:"timestamp"`
+	Projects  []*Project      `json:"projects,omitempty"`
+	Roles     []*rbac.Role    `json:"roles,omitempty"`
+	Accounts  []*rbac.Account `json:"accounts,omitempty"`
+}
+
+// Project holds the resources under one domain/project
+type Project struct {
+	Domain       string                          `json:"domain"`
+	Project      string                          `json:"project"`
+	Services     []*Service                      `json:"services,omitempty"`
+	Dependencies []*discovery.ConsumerDependency `json:"dependencies,omitempty"`
+}
+
+type Service struct {
+	Service   *discovery.MicroService           `json:"service"`
+	Schemas   []*discovery.Schema               `json:"schemas,omitempty"`
+	Tags      map[string]string                 `json:"tags,omitempty"`
+	Rules     []*discovery.ServiceRule          `json:"rules,omitempty"`
+	Instances []*discovery.MicroServiceInstance `json:"instances,omitempty"`
+}
+
+func New() *Archive {
+	return &Archive{
+		Version:   Version,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// Project returns the project under domain, it is appended to the archive if not exist
+func (a *Archive) Project(domain, project string) *Project {
+	for _, p := range a.Projects {
+		if p.Domain == domain && p.Project == project {
+			return p
+		}
+	}
+	p := &Project{Domain: domain, Project: project}
+	a.Projects = append(a.Projects, p)
+	return p
+}
+
+// CreateRequest returns the request to create the service with its rules, tags and instances,
+// the service id and instance ids are kept
+func (s *Service) CreateRequest() *discovery.CreateServiceRequest {
+	in := &discovery.CreateServiceRequest{
+		Service:   s.Service,
+		Tags:      s.Tags,
+		Instances: s.Instances,
+	}
+	for _, rule := range s.Rules {
+		in.Rules = append(in.Rules, &discovery.AddOrUpdateServiceRule{
+			RuleType:    rule.RuleType,
+			Attribute:   rule.Attribute,
+			Pattern:     rule.Pattern,
+			Description: rule.Description,
+		})
+	}
+	return in
+}
+
+// Write encodes the archive to w, the output is gzipped if compress is true
+func Write(w io.Writer, a *Archive, compress bool) error {
+	if compress {
+		zw := gzip.NewWriter(w)
+		if err := json.NewEncoder(zw).Encode(a); err != nil {
+			return err
+		}
+		return zw.Close()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a)
+}
+
+// Read decodes the archive from r, both the plain and gzipped archives are supported
+func Read(r io.Reader) (*Archive, error) {
+	br := bufio.NewReader(r)
+	var reader io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		reader = zr
+	}
+	a := &Archive{}
+	if err := json.NewDecoder(reader).Decode(a); err != nil {
+		return nil, err
+	}
+	if a.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version '%s', expected '%s'", a.Version, Version)
+	}
+	return a, nil
+}
+
+// WriteFile writes the archive to the file, the file is gzipped if the name ends with '.gz'
+func WriteFile(name string, a *Archive) error {
+	if name == StdStream {
+		return Write(os.Stdout, a, false)
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, a, strings.HasSuffix(name, gzipSuffix)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func ReadFile(name string) (*Archive, error) {
+	if name == StdStream {
+		return Read(os.Stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
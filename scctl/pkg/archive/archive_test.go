@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/scctl/pkg/archive"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func newArchive() *archive.Archive {
+	a := archive.New()
+	p := a.Project("default", "default")
+	p.Services = append(p.Services, &archive.Service{
+		Service: &discovery.MicroService{ServiceId: "svc1", AppId: "app", ServiceName: "svc", Version: "1.0.0"},
+		Tags:    map[string]string{"a": "b"},
+		Rules:   []*discovery.ServiceRule{{RuleId: "rule1", RuleType: "BLACK", Attribute: "ServiceName", Pattern: "x"}},
+		Instances: []*discovery.MicroServiceInstance{
+			{InstanceId: "ins1", HostName: "host"},
+		},
+	})
+	return a
+}
+
+func TestArchive_Project(t *testing.T) {
+	a := newArchive()
+	assert.Equal(t, a.Projects[0], a.Project("default", "default"))
+	a.Project("other", "default")
+	assert.Equal(t, 2, len(a.Projects))
+}
+
+func TestWriteAndRead(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		buf := bytes.NewBuffer(nil)
+		err := archive.Write(buf, newArchive(), compress)
+		assert.NoError(t, err)
+
+		a, err := archive.Read(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, archive.Version, a.Version)
+		assert.Equal(t, 1, len(a.Projects))
+		assert.Equal(t, "svc1", a.Projects[0].Services[0].Service.ServiceId)
+		assert.Equal(t, "ins1", a.Projects[0].Services[0].Instances[0].InstanceId)
+	}
+
+	_, err := archive.Read(strings.NewReader(`{"version":"0"}`))
+	assert.Error(t, err)
+}
+
+func TestService_CreateRequest(t *testing.T) {
+	s := newArchive().Projects[0].Services[0]
+	in := s.CreateRequest()
+	assert.Equal(t, "svc1", in.Service.ServiceId)
+	assert.Equal(t, "b", in.Tags["a"])
+	assert.Equal(t, 1, len(in.Rules))
+	assert.Equal(t, "BLACK", in.Rules[0].RuleType)
+	assert.Equal(t, "ins1", in.Instances[0].InstanceId)
+}
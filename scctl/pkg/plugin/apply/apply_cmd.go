@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/writer"
+	"github.com/spf13/cobra"
+)
+
+// ExitChanged is the exit code of diff command if the live state does not match the manifest
+const ExitChanged = cmd.ExitError + 1
+
+var (
+	Files []string
+	Prune bool
+)
+
+func init() {
+	NewApplyCommand(cmd.RootCmd())
+	NewDiffCommand(cmd.RootCmd())
+}
+
+func addFlags(c *cobra.Command) {
+	c.Flags().StringArrayVarP(&Files, "file", "f", nil,
+		"the manifest file or directory, '-' means stdin, can be specified multiple times")
+	c.Flags().BoolVar(&Prune, "prune", false,
+		"delete the resources in the scopes of manifest which are not declared in manifest")
+	_ = c.MarkFlagRequired("file")
+}
+
+func NewApplyCommand(parent *cobra.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "apply -f <file> [options]",
+		Short: "Apply the manifest to service center",
+		Run:   ApplyCommandFunc,
+	}
+	addFlags(c)
+	parent.AddCommand(c)
+	return c
+}
+
+func NewDiffCommand(parent *cobra.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "diff -f <file> [options]",
+		Short: "Output the changes to apply the manifest to service center",
+		Run:   DiffCommandFunc,
+	}
+	addFlags(c)
+	parent.AddCommand(c)
+	return c
+}
+
+func plan(ctx context.Context) (*client.Client, []*Change) {
+	m, err := Load(Files)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	state, err := FetchState(ctx, scClient, m)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	changes, err := Plan(m, state, Prune)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes, service center matches the manifest.")
+		return scClient, nil
+	}
+	writer.PrintTable(&PlanPrinter{Changes: changes})
+	return scClient, changes
+}
+
+func DiffCommandFunc(_ *cobra.Command, args []string) {
+	_, changes := plan(context.Background())
+	if len(changes) > 0 {
+		cmd.StopAndExit(ExitChanged)
+	}
+}
+
+// ApplyCommandFunc stops at the first failed change, the manifest can be applied again
+// after the problem is fixed because the plan is always computed from the live state
+func ApplyCommandFunc(_ *cobra.Command, args []string) {
+	ctx := context.Background()
+	scClient, changes := plan(ctx)
+	for i, c := range changes {
+		if err := c.Apply(ctx, scClient); err != nil {
+			cmd.StopAndExit(cmd.ExitError, fmt.Errorf("%s %s %s failed, %d/%d changes applied: %s",
+				c.Action, c.Kind, c.Name, i, len(changes), err.Error()))
+		}
+	}
+	if len(changes) > 0 {
+		fmt.Printf("%d changes applied.\n", len(changes))
+	}
+}
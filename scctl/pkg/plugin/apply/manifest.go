@@ -0,0 +1,176 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/archive"
+	"github.com/ghodss/yaml"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+)
+
+const defaultDomainProject = "default"
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// Manifest is the desired state of service center
+type Manifest struct {
+	Services []*Service      `json:"services,omitempty"`
+	Roles    []*rbac.Role    `json:"roles,omitempty"`
+	Accounts []*rbac.Account `json:"accounts,omitempty"`
+	Policies []*Policy       `json:"policies,omitempty"`
+}
+
+// Service is the microservice with its tags and black/white list rules,
+// only the properties of an existing microservice can be updated
+type Service struct {
+	Domain  string                              `json:"domain,omitempty"`
+	Project string                              `json:"project,omitempty"`
+	Service *discovery.MicroService             `json:"service"`
+	Tags    map[string]string                   `json:"tags,omitempty"`
+	Rules   []*discovery.AddOrUpdateServiceRule `json:"rules,omitempty"`
+}
+
+// Policy is the governance policy, it is identified by project, kind, name and selector
+type Policy struct {
+	Project string `json:"project,omitempty"`
+	*gov.Policy
+}
+
+func (m *Manifest) merge(o *Manifest) {
+	m.Services = append(m.Services, o.Services...)
+	m.Roles = append(m.Roles, o.Roles...)
+	m.Accounts = append(m.Accounts, o.Accounts...)
+	m.Policies = append(m.Policies, o.Policies...)
+}
+
+func (m *Manifest) validate() error {
+	for _, s := range m.Services {
+		if s.Service == nil || len(s.Service.ServiceName) == 0 || len(s.Service.Version) == 0 {
+			return fmt.Errorf("the serviceName and version of service are required")
+		}
+		if len(s.Domain) == 0 {
+			s.Domain = defaultDomainProject
+		}
+		if len(s.Project) == 0 {
+			s.Project = defaultDomainProject
+		}
+		if len(s.Service.AppId) == 0 {
+			s.Service.AppId = defaultDomainProject
+		}
+	}
+	for _, r := range m.Roles {
+		if len(r.Name) == 0 {
+			return fmt.Errorf("the name of role is required")
+		}
+	}
+	for _, a := range m.Accounts {
+		if len(a.Name) == 0 {
+			return fmt.Errorf("the name of account is required")
+		}
+	}
+	for _, p := range m.Policies {
+		if p.Policy == nil || p.GovernancePolicy == nil || len(p.Kind) == 0 || len(p.Name) == 0 {
+			return fmt.Errorf("the kind and name of policy are required")
+		}
+		if len(p.Project) == 0 {
+			p.Project = defaultDomainProject
+		}
+	}
+	return nil
+}
+
+// Load reads the manifests from the yaml or json files, the yaml files in the directories
+// are also read and a file can contain multiple documents separated by '---'
+func Load(names []string) (*Manifest, error) {
+	m := &Manifest{}
+	for _, name := range names {
+		files, err := expand(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			content, err := read(file)
+			if err != nil {
+				return nil, err
+			}
+			documents, err := Parse(content)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s failed: %s", file, err.Error())
+			}
+			m.merge(documents)
+		}
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Parse decodes the yaml or json content which may contain multiple documents
+func Parse(content []byte) (*Manifest, error) {
+	m := &Manifest{}
+	for _, document := range documentSeparator.Split(string(content), -1) {
+		if len(strings.TrimSpace(document)) == 0 {
+			continue
+		}
+		doc := &Manifest{}
+		if err := yaml.Unmarshal([]byte(document), doc); err != nil {
+			return nil, err
+		}
+		m.merge(doc)
+	}
+	return m, nil
+}
+
+func expand(name string) ([]string, error) {
+	if name == archive.StdStream {
+		return []string{name}, nil
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{name}, nil
+	}
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(name, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+func read(name string) ([]byte, error) {
+	if name == archive.StdStream {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(name)
+}
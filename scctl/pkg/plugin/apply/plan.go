@@ -0,0 +1,467 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	KindService = "service"
+	KindTag     = "tag"
+	KindRule    = "rule"
+	KindRole    = "role"
+	KindAccount = "account"
+	KindPolicy  = "policy"
+
+	// rootAccount is the build-in account which can not be deleted
+	rootAccount = "root"
+)
+
+// Change is an operation to make the live state match the manifest
+type Change struct {
+	Action string
+	Kind   string
+	Scope  string
+	Name   string
+	Detail string
+	do     func(ctx context.Context, scClient *client.Client) error
+}
+
+// Apply executes the change against service center
+func (c *Change) Apply(ctx context.Context, scClient *client.Client) error {
+	return c.do(ctx, scClient)
+}
+
+// toError avoids returning a nil *errsvc.Error as a non-nil error
+func toError(err *errsvc.Error) error {
+	if err == nil {
+		return nil
+	}
+	return err
+}
+
+// policyScope is the project and kind whose policies are managed by manifest
+type policyScope struct {
+	Project string
+	Kind    string
+}
+
+// Plan compares the manifest with the live state and returns the changes in the applying order,
+// the live resources which are not declared in manifest are deleted only if prune is true
+func Plan(m *Manifest, state *State, prune bool) ([]*Change, error) {
+	var changes []*Change
+	changes = append(changes, planRoles(m, state, prune)...)
+	accounts, err := planAccounts(m, state, prune)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, accounts...)
+	changes = append(changes, planServices(m, state, prune)...)
+	changes = append(changes, planPolicies(m, state, prune)...)
+	return changes, nil
+}
+
+func serviceKey(domain, project string, ms *discovery.MicroService) string {
+	return util.StringJoin([]string{domain, project, ms.Environment, ms.AppId, ms.ServiceName, ms.Version}, "/")
+}
+
+func serviceName(ms *discovery.MicroService) string {
+	name := util.StringJoin([]string{ms.AppId, ms.ServiceName, ms.Version}, "/")
+	if len(ms.Environment) > 0 {
+		name = ms.Environment + "/" + name
+	}
+	return name
+}
+
+func serviceScopes(m *Manifest) map[string]struct{} {
+	scopes := make(map[string]struct{})
+	for _, s := range m.Services {
+		scopes[util.StringJoin([]string{s.Domain, s.Project}, "/")] = struct{}{}
+	}
+	return scopes
+}
+
+func policyKey(project string, p *gov.Policy) string {
+	var app, env string
+	if p.Selector != nil {
+		app, env = p.Selector.App, p.Selector.Environment
+	}
+	return util.StringJoin([]string{project, p.Kind, p.Name, app, env}, "/")
+}
+
+func policyScopes(m *Manifest) []policyScope {
+	exists := make(map[policyScope]struct{})
+	var scopes []policyScope
+	for _, p := range m.Policies {
+		scope := policyScope{Project: p.Project, Kind: p.Kind}
+		if _, ok := exists[scope]; ok {
+			continue
+		}
+		exists[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+func planRoles(m *Manifest, state *State, prune bool) (changes []*Change) {
+	desired := make(map[string]struct{}, len(m.Roles))
+	for _, role := range m.Roles {
+		role := role
+		desired[role.Name] = struct{}{}
+		live, ok := state.Roles[role.Name]
+		switch {
+		case !ok:
+			changes = append(changes, &Change{Action: ActionCreate, Kind: KindRole, Name: role.Name,
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.CreateRole(ctx, role))
+				}})
+		case !jsonEqual(role.Perms, live.Perms):
+			changes = append(changes, &Change{Action: ActionUpdate, Kind: KindRole, Name: role.Name, Detail: "perms",
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.UpdateRole(ctx, role))
+				}})
+		}
+	}
+	if !prune || len(m.Roles) == 0 {
+		return
+	}
+	for _, name := range sortedKeys(state.Roles) {
+		name := name
+		if _, ok := desired[name]; ok || name == rbac.RoleAdmin || name == rbac.RoleDeveloper {
+			continue
+		}
+		changes = append(changes, &Change{Action: ActionDelete, Kind: KindRole, Name: name,
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.DeleteRole(ctx, name))
+			}})
+	}
+	return
+}
+
+// planAccounts never changes the password of existing accounts,
+// the password in manifest is only used to create the account
+func planAccounts(m *Manifest, state *State, prune bool) (changes []*Change, err error) {
+	desired := make(map[string]struct{}, len(m.Accounts))
+	for _, account := range m.Accounts {
+		account := account
+		desired[account.Name] = struct{}{}
+		live, ok := state.Accounts[account.Name]
+		if !ok {
+			if len(account.Password) == 0 {
+				return nil, fmt.Errorf("the password of account [%s] is required to create it", account.Name)
+			}
+			changes = append(changes, &Change{Action: ActionCreate, Kind: KindAccount, Name: account.Name,
+				Detail: "roles: " + strings.Join(account.Roles, ","),
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.CreateAccount(ctx, account))
+				}})
+			continue
+		}
+		status := account.Status
+		if len(status) == 0 {
+			status = live.Status
+		}
+		if sameStrings(account.Roles, live.Roles) && status == live.Status {
+			continue
+		}
+		update := &rbac.Account{Name: account.Name, Roles: account.Roles, Status: status}
+		changes = append(changes, &Change{Action: ActionUpdate, Kind: KindAccount, Name: account.Name,
+			Detail: fmt.Sprintf("roles: %s, status: %s", strings.Join(account.Roles, ","), status),
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.UpdateAccount(ctx, update))
+			}})
+	}
+	if !prune || len(m.Accounts) == 0 {
+		return
+	}
+	for _, name := range sortedKeys(state.Accounts) {
+		name := name
+		if _, ok := desired[name]; ok || name == rootAccount {
+			continue
+		}
+		changes = append(changes, &Change{Action: ActionDelete, Kind: KindAccount, Name: name,
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.DeleteAccount(ctx, name))
+			}})
+	}
+	return
+}
+
+func planServices(m *Manifest, state *State, prune bool) (changes []*Change) {
+	desired := make(map[string]struct{}, len(m.Services))
+	for _, s := range m.Services {
+		s := s
+		key := serviceKey(s.Domain, s.Project, s.Service)
+		desired[key] = struct{}{}
+		scope := util.StringJoin([]string{s.Domain, s.Project}, "/")
+		name := serviceName(s.Service)
+		live, ok := state.Services[key]
+		if !ok {
+			changes = append(changes, &Change{Action: ActionCreate, Kind: KindService, Scope: scope, Name: name,
+				do: func(ctx context.Context, scClient *client.Client) error {
+					_, err := scClient.CreateServiceEx(ctx, s.Domain, s.Project, &discovery.CreateServiceRequest{
+						Service: s.Service,
+						Tags:    s.Tags,
+						Rules:   s.Rules,
+					})
+					return toError(err)
+				}})
+			continue
+		}
+		serviceID := live.Service.ServiceId
+		if !sameMap(s.Service.Properties, live.Service.Properties) {
+			changes = append(changes, &Change{Action: ActionUpdate, Kind: KindService, Scope: scope, Name: name,
+				Detail: "properties",
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.UpdateServiceProperties(ctx, s.Domain, s.Project, serviceID, s.Service.Properties))
+				}})
+		}
+		changes = append(changes, planRules(s, live, scope, name, prune)...)
+		changes = append(changes, planTags(s, live, scope, name, prune)...)
+	}
+	if !prune {
+		return
+	}
+	for _, key := range sortedKeys(state.Services) {
+		live := state.Services[key]
+		if _, ok := desired[key]; ok || isSelf(live) {
+			continue
+		}
+		changes = append(changes, &Change{Action: ActionDelete, Kind: KindService,
+			Scope: util.StringJoin([]string{live.Domain, live.Project}, "/"), Name: serviceName(live.Service),
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.DeleteService(ctx, live.Domain, live.Project, live.Service.ServiceId))
+			}})
+	}
+	return
+}
+
+// isSelf returns true if the service is service center itself
+func isSelf(live *LiveService) bool {
+	return core.IsDefaultDomainProject(util.StringJoin([]string{live.Domain, live.Project}, "/")) &&
+		live.Service.AppId == core.RegistryAppID && live.Service.ServiceName == core.RegistryServiceName
+}
+
+func ruleKey(ruleType, attribute, pattern string) string {
+	return util.StringJoin([]string{ruleType, attribute, pattern}, "|")
+}
+
+func ruleName(service string, rule *discovery.AddOrUpdateServiceRule) string {
+	return fmt.Sprintf("%s %s %s=%s", service, rule.RuleType, rule.Attribute, rule.Pattern)
+}
+
+// planRules deletes the rules before adding, so the rule type of service can be switched with prune
+func planRules(s *Service, live *LiveService, scope, name string, prune bool) (changes []*Change) {
+	serviceID := live.Service.ServiceId
+	lives := make(map[string]*discovery.ServiceRule, len(live.Rules))
+	for _, rule := range live.Rules {
+		lives[ruleKey(rule.RuleType, rule.Attribute, rule.Pattern)] = rule
+	}
+	desired := make(map[string]struct{}, len(s.Rules))
+	var adds, updates []*Change
+	for _, rule := range s.Rules {
+		rule := rule
+		key := ruleKey(rule.RuleType, rule.Attribute, rule.Pattern)
+		desired[key] = struct{}{}
+		exist, ok := lives[key]
+		switch {
+		case !ok:
+			adds = append(adds, &Change{Action: ActionCreate, Kind: KindRule, Scope: scope, Name: ruleName(name, rule),
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.AddRules(ctx, s.Domain, s.Project, serviceID,
+						[]*discovery.AddOrUpdateServiceRule{rule}))
+				}})
+		case exist.Description != rule.Description:
+			ruleID := exist.RuleId
+			updates = append(updates, &Change{Action: ActionUpdate, Kind: KindRule, Scope: scope, Name: ruleName(name, rule),
+				Detail: "description",
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.UpdateRule(ctx, s.Domain, s.Project, serviceID, ruleID, rule))
+				}})
+		}
+	}
+	if prune {
+		for _, key := range sortedKeys(lives) {
+			exist := lives[key]
+			if _, ok := desired[key]; ok {
+				continue
+			}
+			changes = append(changes, &Change{Action: ActionDelete, Kind: KindRule, Scope: scope,
+				Name: ruleName(name, &discovery.AddOrUpdateServiceRule{
+					RuleType: exist.RuleType, Attribute: exist.Attribute, Pattern: exist.Pattern}),
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.DeleteRules(ctx, s.Domain, s.Project, serviceID, []string{exist.RuleId}))
+				}})
+		}
+	}
+	changes = append(changes, updates...)
+	return append(changes, adds...)
+}
+
+func planTags(s *Service, live *LiveService, scope, name string, prune bool) (changes []*Change) {
+	serviceID := live.Service.ServiceId
+	for _, key := range sortedKeys(s.Tags) {
+		key, value := key, s.Tags[key]
+		exist, ok := live.Tags[key]
+		if ok && exist == value {
+			continue
+		}
+		action := ActionCreate
+		if ok {
+			action = ActionUpdate
+		}
+		changes = append(changes, &Change{Action: action, Kind: KindTag, Scope: scope, Name: name,
+			Detail: key + "=" + value,
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.AddTags(ctx, s.Domain, s.Project, serviceID, map[string]string{key: value}))
+			}})
+	}
+	if !prune {
+		return
+	}
+	for _, key := range sortedKeys(live.Tags) {
+		key := key
+		if _, ok := s.Tags[key]; ok {
+			continue
+		}
+		changes = append(changes, &Change{Action: ActionDelete, Kind: KindTag, Scope: scope, Name: name,
+			Detail: key,
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.DeleteTags(ctx, s.Domain, s.Project, serviceID, []string{key}))
+			}})
+	}
+	return
+}
+
+func planPolicies(m *Manifest, state *State, prune bool) (changes []*Change) {
+	desired := make(map[string]struct{}, len(m.Policies))
+	for _, p := range m.Policies {
+		p := p
+		key := policyKey(p.Project, p.Policy)
+		desired[key] = struct{}{}
+		live, ok := state.Policies[key]
+		switch {
+		case !ok:
+			changes = append(changes, &Change{Action: ActionCreate, Kind: KindPolicy, Scope: p.Project,
+				Name: policyName(p.Policy),
+				do: func(ctx context.Context, scClient *client.Client) error {
+					_, err := scClient.CreatePolicy(ctx, p.Project, p.Kind, p.Policy)
+					return toError(err)
+				}})
+		case !jsonEqual(p.Spec, live.Spec) || (len(p.Status) > 0 && p.Status != live.Status):
+			id := live.ID
+			changes = append(changes, &Change{Action: ActionUpdate, Kind: KindPolicy, Scope: p.Project,
+				Name: policyName(p.Policy), Detail: "spec",
+				do: func(ctx context.Context, scClient *client.Client) error {
+					return toError(scClient.UpdatePolicy(ctx, p.Project, p.Kind, id, p.Policy))
+				}})
+		}
+	}
+	if !prune {
+		return
+	}
+	for _, key := range sortedKeys(state.Policies) {
+		live := state.Policies[key]
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		changes = append(changes, &Change{Action: ActionDelete, Kind: KindPolicy, Scope: live.Project,
+			Name: policyName(live.Policy),
+			do: func(ctx context.Context, scClient *client.Client) error {
+				return toError(scClient.DeletePolicy(ctx, live.Project, live.Kind, live.ID))
+			}})
+	}
+	return
+}
+
+func policyName(p *gov.Policy) string {
+	name := p.Kind + "/" + p.Name
+	if p.Selector != nil && (len(p.Selector.App) > 0 || len(p.Selector.Environment) > 0) {
+		name += fmt.Sprintf("(app: %s, env: %s)", p.Selector.App, p.Selector.Environment)
+	}
+	return name
+}
+
+// jsonEqual compares the values by their json encoding, so the decoded yaml values
+// can be compared with the values returned by service center
+func jsonEqual(a, b interface{}) bool {
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	var va, vb interface{}
+	if json.Unmarshal(ja, &va) != nil || json.Unmarshal(jb, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func sameMap(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string(nil), a...)
+	sb := append([]string(nil), b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	return reflect.DeepEqual(sa, sb)
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	s := make([]string, 0, len(keys))
+	for _, key := range keys {
+		s = append(s, key.String())
+	}
+	sort.Strings(s)
+	return s
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+const manifestYAML = `
+services:
+- service:
+    serviceName: provider
+    version: 1.0.0
+    properties:
+      allowCrossApp: "true"
+  tags:
+    a: "1"
+    b: "2"
+  rules:
+  - ruleType: BLACK
+    attribute: ServiceName
+    pattern: consumer
+---
+roles:
+- name: tester
+  perms:
+  - resources:
+    - type: service
+    verbs:
+    - get
+accounts:
+- name: dev
+  password: Pwd0000_1
+  roles:
+  - developer
+policies:
+- kind: rate-limiting
+  name: limit
+  selector:
+    app: default
+  spec:
+    rate: 10
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse([]byte(manifestYAML))
+	assert.NoError(t, err)
+	assert.NoError(t, m.validate())
+	assert.Equal(t, 1, len(m.Services))
+	assert.Equal(t, "default", m.Services[0].Domain)
+	assert.Equal(t, "default", m.Services[0].Service.AppId)
+	assert.Equal(t, "2", m.Services[0].Tags["b"])
+	assert.Equal(t, 1, len(m.Roles))
+	assert.Equal(t, 1, len(m.Accounts))
+	assert.Equal(t, 1, len(m.Policies))
+	assert.Equal(t, "default", m.Policies[0].Project)
+	assert.Equal(t, "rate-limiting", m.Policies[0].Kind)
+	assert.Equal(t, "default", m.Policies[0].Selector.App)
+
+	_, err = Parse([]byte("services: ["))
+	assert.Error(t, err)
+	m, _ = Parse([]byte("policies:\n- name: x"))
+	assert.Error(t, m.validate())
+}
+
+func actions(changes []*Change) (s []string) {
+	for _, c := range changes {
+		s = append(s, c.Action+" "+c.Kind+" "+c.Detail)
+	}
+	return
+}
+
+func TestPlan(t *testing.T) {
+	m, err := Parse([]byte(manifestYAML))
+	assert.NoError(t, err)
+	assert.NoError(t, m.validate())
+
+	t.Run("create all resources when service center is empty", func(t *testing.T) {
+		changes, err := Plan(m, NewState(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"create role ", "create account roles: developer",
+			"create service ", "create policy "}, actions(changes))
+	})
+
+	state := NewState()
+	state.Roles["tester"] = m.Roles[0]
+	state.Roles[rbac.RoleAdmin] = &rbac.Role{Name: rbac.RoleAdmin}
+	state.Roles["old"] = &rbac.Role{Name: "old"}
+	state.Accounts["dev"] = &rbac.Account{Name: "dev", Roles: []string{"developer"}, Status: "active"}
+	state.Accounts[rootAccount] = &rbac.Account{Name: rootAccount}
+	state.Services["default/default//default/provider/1.0.0"] = &LiveService{
+		Domain: "default", Project: "default",
+		Service: &discovery.MicroService{ServiceId: "1", AppId: "default", ServiceName: "provider", Version: "1.0.0",
+			Properties: map[string]string{"allowCrossApp": "true"}},
+		Tags: map[string]string{"a": "1", "b": "1", "c": "3"},
+		Rules: []*discovery.ServiceRule{
+			{RuleId: "r1", RuleType: "BLACK", Attribute: "ServiceName", Pattern: "consumer"},
+			{RuleId: "r2", RuleType: "BLACK", Attribute: "ServiceName", Pattern: "other"},
+		},
+	}
+	state.Services["default/default//default/orphan/1.0.0"] = &LiveService{
+		Domain: "default", Project: "default",
+		Service: &discovery.MicroService{ServiceId: "2", AppId: "default", ServiceName: "orphan", Version: "1.0.0"},
+	}
+	state.Policies["default/rate-limiting/limit/default/"] = &LivePolicy{Project: "default", Policy: &gov.Policy{
+		GovernancePolicy: &gov.GovernancePolicy{ID: "p1", Name: "limit", Selector: &gov.Selector{App: "default"}},
+		Kind:             "rate-limiting",
+		Spec:             map[string]interface{}{"rate": 10},
+	}}
+
+	t.Run("only update the changed resources without prune", func(t *testing.T) {
+		changes, err := Plan(m, state, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"update tag b=2"}, actions(changes))
+	})
+
+	t.Run("delete the undeclared resources with prune", func(t *testing.T) {
+		changes, err := Plan(m, state, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"delete role ", "delete rule ", "update tag b=2", "delete tag c",
+			"delete service "}, actions(changes))
+		assert.Equal(t, "default/orphan/1.0.0", changes[4].Name)
+	})
+
+	t.Run("the password is required to create account", func(t *testing.T) {
+		m.Accounts[0].Password = ""
+		defer func() { m.Accounts[0].Password = "Pwd0000_1" }()
+		_, err := Plan(m, NewState(), false)
+		assert.Error(t, err)
+	})
+}
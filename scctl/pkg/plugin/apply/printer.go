@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"github.com/apache/servicecomb-service-center/scctl/pkg/writer"
+)
+
+var (
+	planTableHeader = []string{"ACTION", "KIND", "SCOPE", "NAME", "DETAIL"}
+)
+
+type PlanPrinter struct {
+	Changes []*Change
+	flags   []interface{}
+}
+
+func (pp *PlanPrinter) Flags(flags ...interface{}) []interface{} {
+	if len(flags) > 0 {
+		pp.flags = flags
+	}
+	return pp.flags
+}
+
+func (pp *PlanPrinter) PrintBody() (slice [][]string) {
+	for _, c := range pp.Changes {
+		slice = append(slice, []string{c.Action, c.Kind, c.Scope, c.Name, c.Detail})
+	}
+	return
+}
+
+func (pp *PlanPrinter) PrintTitle() []string {
+	return planTableHeader
+}
+
+// Sorter groups the changes by action and kind
+func (pp *PlanPrinter) Sorter() *writer.RecordsSorter {
+	return writer.NewRecordsSorter(func(row1, row2 []string) bool {
+		for i := 0; i < len(row1); i++ {
+			if row1[i] != row2[i] {
+				return row1[i] < row2[i]
+			}
+		}
+		return false
+	})
+}
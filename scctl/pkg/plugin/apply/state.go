@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/model"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+)
+
+// State is the live state of the resources in the scopes of manifest
+type State struct {
+	// Services is indexed by serviceKey
+	Services map[string]*LiveService
+	Roles    map[string]*rbac.Role
+	Accounts map[string]*rbac.Account
+	// Policies is indexed by policyKey
+	Policies map[string]*LivePolicy
+}
+
+type LiveService struct {
+	Domain  string
+	Project string
+	Service *discovery.MicroService
+	Tags    map[string]string
+	Rules   []*discovery.ServiceRule
+}
+
+type LivePolicy struct {
+	Project string
+	*gov.Policy
+}
+
+func NewState() *State {
+	return &State{
+		Services: make(map[string]*LiveService),
+		Roles:    make(map[string]*rbac.Role),
+		Accounts: make(map[string]*rbac.Account),
+		Policies: make(map[string]*LivePolicy),
+	}
+}
+
+// FetchState gets the live state of the resources which are managed by manifest,
+// only the tags and rules of the services declared in manifest are fetched
+func FetchState(ctx context.Context, scClient *client.Client, m *Manifest) (*State, error) {
+	state := NewState()
+	if err := fetchServices(ctx, scClient, m, state); err != nil {
+		return nil, err
+	}
+	if len(m.Roles) > 0 {
+		roles, err := scClient.ListRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			state.Roles[role.Name] = role
+		}
+	}
+	if len(m.Accounts) > 0 {
+		accounts, err := scClient.ListAccounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			state.Accounts[account.Name] = account
+		}
+	}
+	for _, scope := range policyScopes(m) {
+		policies, err := scClient.ListPolicies(ctx, scope.Project, scope.Kind, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies {
+			if policy.GovernancePolicy == nil {
+				continue
+			}
+			policy.Kind = scope.Kind
+			live := &LivePolicy{Project: scope.Project, Policy: policy}
+			state.Policies[policyKey(scope.Project, policy)] = live
+		}
+	}
+	return state, nil
+}
+
+func fetchServices(ctx context.Context, scClient *client.Client, m *Manifest, state *State) error {
+	if len(m.Services) == 0 {
+		return nil
+	}
+	scopes := serviceScopes(m)
+	desired := make(map[string]struct{}, len(m.Services))
+	for _, s := range m.Services {
+		desired[serviceKey(s.Domain, s.Project, s.Service)] = struct{}{}
+	}
+
+	cache, err := scClient.GetScCache(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ms := range cache.Microservices {
+		domainProject := model.GetDomainProject(ms)
+		if _, ok := scopes[domainProject]; !ok {
+			continue
+		}
+		domain, project := util.FromDomainProject(domainProject)
+		live := &LiveService{Domain: domain, Project: project, Service: ms.Value}
+		key := serviceKey(domain, project, ms.Value)
+		state.Services[key] = live
+		if _, ok := desired[key]; !ok {
+			continue
+		}
+		if live.Tags, err = scClient.GetTags(ctx, domain, project, ms.Value.ServiceId); err != nil {
+			return err
+		}
+		if live.Rules, err = scClient.GetRules(ctx, domain, project, ms.Value.ServiceId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
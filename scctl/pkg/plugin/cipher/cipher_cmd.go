@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cipher
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/keyring"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// the same envs as the buildin cipher plugin of service center
+	RootKeyEnv     = "CIPHER_ROOT_KEY"
+	RootKeyFileEnv = "CIPHER_ROOT_KEY_FILE"
+)
+
+var (
+	KeyID   string
+	KeyFile string
+)
+
+func init() {
+	NewCipherCommand(cmd.RootCmd())
+}
+
+func NewCipherCommand(parent *cobra.Command) *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "cipher <command> [options]",
+		Short: "Manage the root keys and encrypt the values of config files",
+	}
+	cc.PersistentFlags().StringVar(&KeyFile, "key-file", os.Getenv(RootKeyFileEnv),
+		"the root key file path, can be overrode by env "+RootKeyFileEnv+
+			", the keys in env "+RootKeyEnv+" take precedence")
+
+	genKey := &cobra.Command{
+		Use:   "genkey [options]",
+		Short: "Generate a new AES-256 root key, put it in front of the old keys to rotate",
+		Run:   GenKeyCommandFunc,
+	}
+	genKey.Flags().StringVar(&KeyID, "id", "", "the key id, default is the current date time")
+
+	encrypt := &cobra.Command{
+		Use:   "encrypt [value]",
+		Short: "Encrypt the value with the active root key, read from stdin if value is not specified",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   EncryptCommandFunc,
+	}
+	decrypt := &cobra.Command{
+		Use:   "decrypt [value]",
+		Short: "Decrypt the value with the root keys, read from stdin if value is not specified",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   DecryptCommandFunc,
+	}
+
+	cc.AddCommand(genKey, encrypt, decrypt)
+	parent.AddCommand(cc)
+	return cc
+}
+
+func GenKeyCommandFunc(_ *cobra.Command, _ []string) {
+	id := KeyID
+	if len(id) == 0 {
+		id = "k" + time.Now().Format("20060102150405")
+	}
+	key, err := keyring.GenerateKey(id)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	cmd.StopAndExit(cmd.ExitSuccess, key)
+}
+
+func EncryptCommandFunc(_ *cobra.Command, args []string) {
+	encrypted, err := loadKeyring().Encrypt(readValue(args))
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	cmd.StopAndExit(cmd.ExitSuccess, encrypted)
+}
+
+func DecryptCommandFunc(_ *cobra.Command, args []string) {
+	decrypted, err := loadKeyring().Decrypt(readValue(args))
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	cmd.StopAndExit(cmd.ExitSuccess, decrypted)
+}
+
+func loadKeyring() *keyring.Keyring {
+	kr, err := keyring.Load(os.Getenv(RootKeyEnv), KeyFile)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	if kr.Len() == 0 {
+		cmd.StopAndExit(cmd.ExitError, fmt.Errorf("%v, specify the --key-file or env %s",
+			keyring.ErrNoKey, RootKeyEnv))
+	}
+	return kr
+}
+
+func readValue(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "value: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && len(line) == 0 {
+			cmd.StopAndExit(cmd.ExitError, err)
+		}
+		return strings.TrimRight(line, "\r\n")
+	}
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	return strings.TrimRight(string(data), "\r\n")
+}
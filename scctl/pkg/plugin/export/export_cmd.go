@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/archive"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/model"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/progress-bar"
+	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/spf13/cobra"
+)
+
+var (
+	Domain        string
+	AllDomains    bool
+	File          string
+	WithInstances bool
+)
+
+func init() {
+	NewExportCommand(cmd.RootCmd())
+}
+
+func NewExportCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [options]",
+		Short: "Export the resources of service center to an archive",
+		Run:   ExportCommandFunc,
+	}
+
+	cmd.Flags().StringVarP(&Domain, "domain", "d", "default", "export the resources under the specified domain")
+	cmd.Flags().BoolVar(&AllDomains, "all-domains", false, "export the resources under all domains")
+	cmd.Flags().StringVarP(&File, "file", "f", archive.StdStream,
+		"the archive file to write, gzipped if the name ends with '.gz', '-' means stdout")
+	cmd.Flags().BoolVar(&WithInstances, "with-instances", false, "export the microservice instances")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func ExportCommandFunc(_ *cobra.Command, args []string) {
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	ctx := context.Background()
+	cache, scErr := scClient.GetScCache(ctx)
+	if scErr != nil {
+		cmd.StopAndExit(cmd.ExitError, scErr)
+	}
+
+	var progressBarWriter io.Writer = os.Stdout
+	if File == archive.StdStream {
+		progressBarWriter = ioutil.Discard
+	}
+	progressBar := pb.NewProgressBar(len(cache.Microservices), progressBarWriter)
+
+	a := archive.New()
+	rules := consumerRules(cache)
+	instances := serviceInstances(cache)
+	for _, ms := range cache.Microservices {
+		progressBar.Increment()
+
+		domainProject := model.GetDomainProject(ms)
+		if !AllDomains && strings.Index(domainProject+path.SPLIT, Domain+path.SPLIT) != 0 {
+			continue
+		}
+		if isSelf(domainProject, ms.Value) {
+			continue
+		}
+		domain, project := util.FromDomainProject(domainProject)
+		p := a.Project(domain, project)
+		s, err := exportService(ctx, scClient, domain, project, ms.Value)
+		if err != nil {
+			cmd.StopAndExit(cmd.ExitError, err)
+		}
+		if WithInstances {
+			s.Instances = instances[ms.Value.ServiceId]
+		}
+		p.Services = append(p.Services, s)
+
+		dependency, err := exportDependency(ctx, scClient, domain, project, ms.Value, rules[domainProject])
+		if err != nil {
+			cmd.StopAndExit(cmd.ExitError, err)
+		}
+		if dependency != nil {
+			p.Dependencies = append(p.Dependencies, dependency)
+		}
+	}
+	progressBar.Finish()
+
+	exportRBAC(ctx, scClient, a)
+
+	if err := archive.WriteFile(File, a); err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+}
+
+// isSelf returns true if the service is service center itself, it is registered by every cluster
+func isSelf(domainProject string, ms *discovery.MicroService) bool {
+	return core.IsDefaultDomainProject(domainProject) &&
+		ms.AppId == core.RegistryAppID && ms.ServiceName == core.RegistryServiceName
+}
+
+func exportService(ctx context.Context, scClient *client.Client, domain, project string,
+	ms *discovery.MicroService) (*archive.Service, error) {
+	s := &archive.Service{Service: ms}
+	schemas, err := scClient.GetSchemasByServiceID(ctx, domain, project, ms.ServiceId)
+	if err != nil {
+		return nil, err
+	}
+	s.Schemas = schemas
+	tags, err := scClient.GetTags(ctx, domain, project, ms.ServiceId)
+	if err != nil {
+		return nil, err
+	}
+	s.Tags = tags
+	rules, err := scClient.GetRules(ctx, domain, project, ms.ServiceId)
+	if err != nil {
+		return nil, err
+	}
+	s.Rules = rules
+	return s, nil
+}
+
+// exportDependency prefers the dependency rules in cache which keep the version rules,
+// the resolved providers are used if the datasource does not dump the rules
+func exportDependency(ctx context.Context, scClient *client.Client, domain, project string,
+	ms *discovery.MicroService, rules map[string][]*discovery.MicroServiceKey) (*discovery.ConsumerDependency, error) {
+	consumer := discovery.MicroServiceToKey("", ms)
+	providers, ok := rules[ruleKey(consumer)]
+	if !ok {
+		services, err := scClient.GetProviders(ctx, domain, project, ms.ServiceId)
+		if err != nil {
+			return nil, err
+		}
+		for _, provider := range services {
+			providers = append(providers, discovery.MicroServiceToKey("", provider))
+		}
+	}
+	if len(providers) == 0 {
+		return nil, nil
+	}
+	return &discovery.ConsumerDependency{Consumer: consumer, Providers: providers}, nil
+}
+
+func exportRBAC(ctx context.Context, scClient *client.Client, a *archive.Archive) {
+	roles, err := scClient.ListRoles(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "skip exporting roles:", err.Error())
+	}
+	a.Roles = roles
+	accounts, err := scClient.ListAccounts(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "skip exporting accounts:", err.Error())
+	}
+	a.Accounts = accounts
+}
+
+// consumerRules returns the dependency rules of consumers, indexed by domainProject and consumer key
+func consumerRules(cache *dump.Cache) map[string]map[string][]*discovery.MicroServiceKey {
+	rules := make(map[string]map[string][]*discovery.MicroServiceKey)
+	for _, rule := range cache.DependencyRules {
+		if rule.Value == nil {
+			continue
+		}
+		t, consumer := path.GetInfoFromDependencyRuleKV(util.StringToBytesWithNoCopy(rule.Key))
+		if t != path.DepsConsumer || consumer == nil {
+			continue
+		}
+		domainProject := consumer.Tenant
+		if _, ok := rules[domainProject]; !ok {
+			rules[domainProject] = make(map[string][]*discovery.MicroServiceKey)
+		}
+		providers := make([]*discovery.MicroServiceKey, 0, len(rule.Value.Dependency))
+		for _, provider := range rule.Value.Dependency {
+			key := *provider
+			key.Tenant = ""
+			providers = append(providers, &key)
+		}
+		consumer.Tenant = ""
+		rules[domainProject][ruleKey(consumer)] = providers
+	}
+	return rules
+}
+
+func ruleKey(key *discovery.MicroServiceKey) string {
+	return util.StringJoin([]string{key.Environment, key.AppId, key.ServiceName, key.Version}, path.SPLIT)
+}
+
+func serviceInstances(cache *dump.Cache) map[string][]*discovery.MicroServiceInstance {
+	instances := make(map[string][]*discovery.MicroServiceInstance)
+	for _, instance := range cache.Instances {
+		if instance.Value == nil {
+			continue
+		}
+		serviceID := instance.Value.ServiceId
+		instances[serviceID] = append(instances[serviceID], instance.Value)
+	}
+	return instances
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get"
+	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/spf13/cobra"
+)
+
+var (
+	Format     string
+	AppID      string
+	Env        string
+	ServiceID  string
+	Hops       int
+	WithShared bool
+	SaveFile   string
+)
+
+func init() {
+	NewGraphCommand(get.RootCmd)
+}
+
+func NewGraphCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph [options]",
+		Short: "Output the microservice dependency graph of the service center",
+		Run:   GraphCommandFunc,
+	}
+
+	cmd.Flags().StringVar(&Format, "format", "dot", "the output format, one of json, dot, mermaid and graphml")
+	cmd.Flags().StringVar(&AppID, "app", "", "only output the microservices of the application")
+	cmd.Flags().StringVar(&Env, "env", "", "only output the microservices of the environment")
+	cmd.Flags().StringVar(&ServiceID, "service-id", "", "only output the microservices around the specified microservice")
+	cmd.Flags().IntVar(&Hops, "hops", 0, "the max hops around the specified microservice, 0 means no limit")
+	cmd.Flags().BoolVar(&WithShared, "with-shared", false, "output the shared microservices")
+	cmd.Flags().StringVarP(&SaveFile, "save-file", "s", "", "the file to save the graph")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func GraphCommandFunc(_ *cobra.Command, args []string) {
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+
+	query := url.Values{}
+	query.Set("format", Format)
+	query.Set("withShared", strconv.FormatBool(WithShared))
+	if len(AppID) > 0 {
+		query.Set("appId", AppID)
+	}
+	if len(Env) > 0 {
+		query.Set("env", Env)
+	}
+	if len(ServiceID) > 0 {
+		query.Set("serviceId", ServiceID)
+		query.Set("hops", strconv.Itoa(Hops))
+	}
+
+	domain, project := util.FromDomainProject(get.Domain)
+	if len(project) == 0 {
+		project = core.RegistryProject
+	}
+	data, scErr := scClient.GetGraph(context.Background(), domain, project, query)
+	if scErr != nil {
+		cmd.StopAndExit(cmd.ExitError, scErr)
+	}
+
+	if len(SaveFile) > 0 {
+		if err := ioutil.WriteFile(SaveFile, data, 0640); err != nil {
+			cmd.StopAndExit(cmd.ExitError, err)
+		}
+		return
+	}
+	if _, err := os.Stdout.Write(data); err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+}
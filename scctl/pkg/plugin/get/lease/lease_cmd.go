@@ -0,0 +1,87 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/model"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/writer"
+	"github.com/spf13/cobra"
+)
+
+var ServiceID string
+
+func init() {
+	NewLeaseCommand(get.RootCmd)
+}
+
+func NewLeaseCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lease [options]",
+		Short: "Output the instance leases of the service center",
+		Run:   LeaseCommandFunc,
+	}
+	cmd.Flags().StringVar(&ServiceID, "service-id", "", "print the leases of the specified microservice")
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func LeaseCommandFunc(_ *cobra.Command, args []string) {
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	cache, scErr := scClient.GetScCache(context.Background())
+	if scErr != nil {
+		cmd.StopAndExit(cmd.ExitError, scErr)
+	}
+
+	records := make(map[string]*LeaseRecord)
+	for _, ms := range cache.Microservices {
+		if len(ServiceID) > 0 && ms.Value.ServiceId != ServiceID {
+			continue
+		}
+		domainProject := model.GetDomainProject(ms)
+		if !get.AllDomains && strings.Index(domainProject+path.SPLIT, get.Domain+path.SPLIT) != 0 {
+			continue
+		}
+
+		domain, project := util.FromDomainProject(domainProject)
+		leases, scErr := scClient.GetLeases(context.Background(), domain, project, ms.Value.ServiceId)
+		if scErr != nil {
+			cmd.StopAndExit(cmd.ExitError, scErr)
+		}
+		for _, lease := range leases {
+			records[lease.InstanceID] = &LeaseRecord{
+				DomainProject: domainProject,
+				ServiceName:   ms.Value.ServiceName,
+				Version:       ms.Value.Version,
+				Lease:         lease,
+			}
+		}
+	}
+
+	sp := &LeasePrinter{Records: records}
+	sp.SetOutputFormat(get.Output, get.AllDomains)
+	writer.PrintTable(sp)
+}
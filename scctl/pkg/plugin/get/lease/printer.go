@@ -0,0 +1,122 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/writer"
+)
+
+const expired = "Expired"
+
+var (
+	longLeaseTableHeader   = []string{"DOMAIN", "SERVICE", "VERSION", "INSTANCE", "LEASE ID", "TTL", "REMAINING", "LAST RENEW"}
+	domainLeaseTableHeader = []string{"DOMAIN", "SERVICE", "VERSION", "INSTANCE", "TTL", "REMAINING", "LAST RENEW"}
+	shortLeaseTableHeader  = []string{"SERVICE", "VERSION", "INSTANCE", "TTL", "REMAINING", "LAST RENEW"}
+)
+
+type LeaseRecord struct {
+	DomainProject string
+	ServiceName   string
+	Version       string
+	Lease         *dump.Lease
+}
+
+func (s *LeaseRecord) Domain() string {
+	domain, _ := util.FromDomainProject(s.DomainProject)
+	return domain
+}
+
+func (s *LeaseRecord) LeaseIDString() string {
+	if s.Lease.LeaseID == 0 {
+		return ""
+	}
+	return strconv.FormatInt(s.Lease.LeaseID, 16)
+}
+
+func (s *LeaseRecord) TTLString() string {
+	return writer.TimeFormat(time.Duration(s.Lease.TTL) * time.Second)
+}
+
+func (s *LeaseRecord) RemainingString() string {
+	if s.Lease.Remaining < 0 {
+		return expired
+	}
+	return writer.TimeFormat(time.Duration(s.Lease.Remaining) * time.Second)
+}
+
+func (s *LeaseRecord) LastRenewString() string {
+	if s.Lease.LastRenewTime == 0 {
+		return ""
+	}
+	return writer.TimeFormat(time.Since(time.Unix(s.Lease.LastRenewTime, 0))) + " ago"
+}
+
+func (s *LeaseRecord) PrintBody(fmt string, all bool) []string {
+	switch {
+	case fmt == "wide":
+		return []string{s.Domain(), s.ServiceName, s.Version, s.Lease.InstanceID, s.LeaseIDString(),
+			s.TTLString(), s.RemainingString(), s.LastRenewString()}
+	case all:
+		return []string{s.Domain(), s.ServiceName, s.Version, s.Lease.InstanceID,
+			s.TTLString(), s.RemainingString(), s.LastRenewString()}
+	default:
+		return []string{s.ServiceName, s.Version, s.Lease.InstanceID,
+			s.TTLString(), s.RemainingString(), s.LastRenewString()}
+	}
+}
+
+type LeasePrinter struct {
+	Records map[string]*LeaseRecord
+	flags   []interface{}
+}
+
+func (sp *LeasePrinter) SetOutputFormat(f string, all bool) {
+	sp.Flags(f, all)
+}
+
+func (sp *LeasePrinter) Flags(flags ...interface{}) []interface{} {
+	if len(flags) > 0 {
+		sp.flags = flags
+	}
+	return sp.flags
+}
+
+func (sp *LeasePrinter) PrintBody() (slice [][]string) {
+	for _, s := range sp.Records {
+		slice = append(slice, s.PrintBody(sp.flags[0].(string), sp.flags[1].(bool)))
+	}
+	return
+}
+
+func (sp *LeasePrinter) PrintTitle() []string {
+	switch {
+	case sp.flags[0] == "wide":
+		return longLeaseTableHeader
+	case sp.flags[1].(bool):
+		return domainLeaseTableHeader
+	default:
+		return shortLeaseTableHeader
+	}
+}
+
+func (sp *LeasePrinter) Sorter() *writer.RecordsSorter {
+	return nil
+}
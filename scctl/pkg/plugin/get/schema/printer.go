@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/writer"
+)
+
+const shortHashLength = 12
+
+var (
+	domainHistoryTableHeader = []string{"DOMAIN", "SERVICE", "VERSION", "SCHEMA", "REVISION", "HASH", "ACCOUNT", "MODIFIED"}
+	shortHistoryTableHeader  = []string{"SERVICE", "VERSION", "SCHEMA", "REVISION", "HASH", "ACCOUNT", "MODIFIED"}
+)
+
+type HistoryRecord struct {
+	DomainProject string
+	ServiceName   string
+	Version       string
+	SchemaID      string
+	Revision      *proto.SchemaRevision
+}
+
+func (s *HistoryRecord) Domain() string {
+	domain, _ := util.FromDomainProject(s.DomainProject)
+	return domain
+}
+
+func (s *HistoryRecord) HashString() string {
+	if len(s.Revision.Hash) > shortHashLength {
+		return s.Revision.Hash[:shortHashLength]
+	}
+	return s.Revision.Hash
+}
+
+func (s *HistoryRecord) ModifiedString() string {
+	sec, err := strconv.ParseInt(s.Revision.Timestamp, 10, 64)
+	if err != nil || sec == 0 {
+		return ""
+	}
+	return writer.TimeFormat(time.Since(time.Unix(sec, 0))) + " ago"
+}
+
+func (s *HistoryRecord) PrintBody(all bool) []string {
+	body := []string{s.ServiceName, s.Version, s.SchemaID, strconv.FormatInt(s.Revision.Revision, 10),
+		s.HashString(), s.Revision.Account, s.ModifiedString()}
+	if all {
+		return append([]string{s.Domain()}, body...)
+	}
+	return body
+}
+
+type HistoryPrinter struct {
+	Records []*HistoryRecord
+	flags   []interface{}
+}
+
+func (sp *HistoryPrinter) SetOutputFormat(f string, all bool) {
+	sp.Flags(f, all)
+}
+
+func (sp *HistoryPrinter) Flags(flags ...interface{}) []interface{} {
+	if len(flags) > 0 {
+		sp.flags = flags
+	}
+	return sp.flags
+}
+
+func (sp *HistoryPrinter) PrintBody() (slice [][]string) {
+	for _, s := range sp.Records {
+		slice = append(slice, s.PrintBody(sp.flags[1].(bool)))
+	}
+	return
+}
+
+func (sp *HistoryPrinter) PrintTitle() []string {
+	if sp.flags[1].(bool) {
+		return domainHistoryTableHeader
+	}
+	return shortHistoryTableHeader
+}
+
+func (sp *HistoryPrinter) Sorter() *writer.RecordsSorter {
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/servicecomb-service-center/scctl/pkg/model"
 	"github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get"
 	"github.com/apache/servicecomb-service-center/scctl/pkg/progress-bar"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/writer"
 	"github.com/apache/servicecomb-service-center/server/core"
 	"github.com/spf13/cobra"
 )
@@ -41,6 +42,7 @@ var (
 	ServiceName string
 	Version     string
 	SaveDir     string
+	History     bool
 )
 
 func init() {
@@ -59,6 +61,7 @@ func NewSchemaCommand(parent *cobra.Command) *cobra.Command {
 	cmd.Flags().StringVar(&AppId, "app", "", "the application name of microservice")
 	cmd.Flags().StringVar(&ServiceName, "name", "", "the name of microservice")
 	cmd.Flags().StringVar(&Version, "version", "", "the semantic version of microservice")
+	cmd.Flags().BoolVar(&History, "history", false, "output the revision history of the schemas")
 
 	parent.AddCommand(cmd)
 	return cmd
@@ -90,12 +93,13 @@ func SchemaCommandFunc(_ *cobra.Command, args []string) {
 	}
 
 	var progressBarWriter io.Writer = os.Stdout
-	if len(SaveDir) == 0 {
+	if len(SaveDir) == 0 || History {
 		progressBarWriter = ioutil.Discard
 	}
 	progressBar := pb.NewProgressBar(len(cache.Microservices), progressBarWriter)
 	defer progressBar.FinishPrint("Finished.")
 
+	var records []*HistoryRecord
 	for _, ms := range cache.Microservices {
 		progressBar.Increment()
 
@@ -121,9 +125,35 @@ func SchemaCommandFunc(_ *cobra.Command, args []string) {
 			continue
 		}
 
-		writer := NewSchemaWriter(Config{SaveDir: saveDirectory(SaveDir, ms)})
-		if err := writer.Write(schemas); err != nil {
+		if History {
+			for _, schema := range schemas {
+				revisions, err := scClient.ListSchemaRevisions(context.Background(), dp[0], dp[1],
+					ms.Value.ServiceId, schema.SchemaId)
+				if err != nil {
+					cmd.StopAndExit(cmd.ExitError, err)
+				}
+				for _, revision := range revisions {
+					records = append(records, &HistoryRecord{
+						DomainProject: domainProject,
+						ServiceName:   ms.Value.ServiceName,
+						Version:       ms.Value.Version,
+						SchemaID:      schema.SchemaId,
+						Revision:      revision,
+					})
+				}
+			}
+			continue
+		}
+
+		schemaWriter := NewSchemaWriter(Config{SaveDir: saveDirectory(SaveDir, ms)})
+		if err := schemaWriter.Write(schemas); err != nil {
 			fmt.Fprintln(os.Stderr, "output schema data failed", err.Error())
 		}
 	}
+
+	if History {
+		sp := &HistoryPrinter{Records: records}
+		sp.SetOutputFormat(get.Output, get.AllDomains)
+		writer.PrintTable(sp)
+	}
 }
@@ -0,0 +1,370 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imports
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/archive"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/spf13/cobra"
+)
+
+// the policies to handle the resource which already exists in service center
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictAbort     = "abort"
+)
+
+var (
+	File            string
+	Conflict        string
+	AccountPassword string
+)
+
+func init() {
+	NewImportCommand(cmd.RootCmd())
+}
+
+func NewImportCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [options]",
+		Short: "Import the resources from an archive to service center",
+		Run:   ImportCommandFunc,
+	}
+
+	cmd.Flags().StringVarP(&File, "file", "f", archive.StdStream, "the archive file to read, '-' means stdin")
+	cmd.Flags().StringVar(&Conflict, "conflict", ConflictSkip,
+		"the policy if the resource already exists, support 'skip', 'overwrite' and 'abort'")
+	cmd.Flags().StringVar(&AccountPassword, "account-password", "",
+		"the initial password of the imported accounts, accounts are not imported if it is empty")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+type summary struct {
+	Created int
+	Skipped int
+	Failed  int
+}
+
+func (s *summary) String() string {
+	return fmt.Sprintf("created %d, skipped %d, failed %d", s.Created, s.Skipped, s.Failed)
+}
+
+func ImportCommandFunc(_ *cobra.Command, args []string) {
+	switch Conflict {
+	case ConflictSkip, ConflictOverwrite, ConflictAbort:
+	default:
+		cmd.StopAndExit(cmd.ExitError, fmt.Errorf("invalid conflict policy '%s'", Conflict))
+	}
+
+	a, err := archive.ReadFile(File)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	ctx := context.Background()
+
+	roles := importRoles(ctx, scClient, a.Roles)
+	fmt.Println("roles:", roles)
+	accounts := importAccounts(ctx, scClient, a.Accounts)
+	fmt.Println("accounts:", accounts)
+	services := &summary{}
+	for _, p := range a.Projects {
+		importProject(ctx, scClient, p, services)
+	}
+	fmt.Println("services:", services)
+
+	if roles.Failed+accounts.Failed+services.Failed > 0 {
+		cmd.StopAndExit(cmd.ExitError, fmt.Errorf("some resources import failed"))
+	}
+}
+
+// onConflict returns true if the existing resource should be overwritten
+func onConflict(s *summary, resource string) bool {
+	switch Conflict {
+	case ConflictOverwrite:
+		return true
+	case ConflictAbort:
+		cmd.StopAndExit(cmd.ExitError, fmt.Errorf("%s already exists", resource))
+	}
+	s.Skipped++
+	return false
+}
+
+func onError(s *summary, resource string, err error) {
+	fmt.Fprintf(os.Stderr, "import %s failed: %s\n", resource, err.Error())
+	s.Failed++
+}
+
+func importRoles(ctx context.Context, scClient *client.Client, roles []*rbac.Role) *summary {
+	s := &summary{}
+	if len(roles) == 0 {
+		return s
+	}
+	exists, scErr := scClient.ListRoles(ctx)
+	if scErr != nil {
+		onError(s, "roles", scErr)
+		return s
+	}
+	names := make(map[string]struct{}, len(exists))
+	for _, role := range exists {
+		names[role.Name] = struct{}{}
+	}
+	for _, role := range roles {
+		if role.Name == rbac.RoleAdmin || role.Name == rbac.RoleDeveloper {
+			// the build-in roles can not be changed
+			s.Skipped++
+			continue
+		}
+		resource := fmt.Sprintf("role [%s]", role.Name)
+		if _, ok := names[role.Name]; ok {
+			if !onConflict(s, resource) {
+				continue
+			}
+			if scErr = scClient.UpdateRole(ctx, role); scErr != nil {
+				onError(s, resource, scErr)
+				continue
+			}
+			s.Created++
+			continue
+		}
+		if scErr = scClient.CreateRole(ctx, role); scErr != nil {
+			onError(s, resource, scErr)
+			continue
+		}
+		s.Created++
+	}
+	return s
+}
+
+// importAccounts creates the accounts with AccountPassword, because the passwords are never exported
+func importAccounts(ctx context.Context, scClient *client.Client, accounts []*rbac.Account) *summary {
+	s := &summary{}
+	if len(accounts) == 0 {
+		return s
+	}
+	if len(AccountPassword) == 0 {
+		fmt.Fprintf(os.Stderr, "skip importing %d accounts, the account password is not set\n", len(accounts))
+		s.Skipped = len(accounts)
+		return s
+	}
+	exists, scErr := scClient.ListAccounts(ctx)
+	if scErr != nil {
+		onError(s, "accounts", scErr)
+		return s
+	}
+	names := make(map[string]struct{}, len(exists))
+	for _, account := range exists {
+		names[account.Name] = struct{}{}
+	}
+	for _, account := range accounts {
+		resource := fmt.Sprintf("account [%s]", account.Name)
+		if _, ok := names[account.Name]; ok {
+			if !onConflict(s, resource) {
+				continue
+			}
+			if scErr = scClient.UpdateAccount(ctx, &rbac.Account{
+				Name:   account.Name,
+				Roles:  account.Roles,
+				Status: account.Status,
+			}); scErr != nil {
+				onError(s, resource, scErr)
+				continue
+			}
+			s.Created++
+			continue
+		}
+		account.ID = ""
+		account.Password = AccountPassword
+		if scErr = scClient.CreateAccount(ctx, account); scErr != nil {
+			onError(s, resource, scErr)
+			continue
+		}
+		s.Created++
+	}
+	return s
+}
+
+func importProject(ctx context.Context, scClient *client.Client, p *archive.Project, s *summary) {
+	for _, service := range p.Services {
+		importService(ctx, scClient, p.Domain, p.Project, service, s)
+	}
+	if len(p.Dependencies) == 0 {
+		return
+	}
+	if scErr := scClient.AddDependencies(ctx, p.Domain, p.Project, p.Dependencies); scErr != nil {
+		onError(s, fmt.Sprintf("dependencies under [%s/%s]", p.Domain, p.Project), scErr)
+	}
+}
+
+func importService(ctx context.Context, scClient *client.Client, domain, project string, service *archive.Service, s *summary) {
+	ms := service.Service
+	resource := fmt.Sprintf("service [%s/%s/%s/%s/%s]", domain, project, ms.AppId, ms.ServiceName, ms.Version)
+	conflicts, err := conflictServiceIDs(ctx, scClient, domain, project, ms)
+	if err != nil {
+		onError(s, resource, err)
+		return
+	}
+	if len(conflicts) > 0 {
+		if !onConflict(s, resource) {
+			return
+		}
+		if len(conflicts) > 1 {
+			onError(s, resource, fmt.Errorf("conflicts with more than one service %v", conflicts))
+			return
+		}
+		if err := overwriteService(ctx, scClient, domain, project, conflicts[0], service); err != nil {
+			onError(s, resource, err)
+			return
+		}
+		s.Created++
+		return
+	}
+
+	if _, scErr := scClient.CreateServiceEx(ctx, domain, project, service.CreateRequest()); scErr != nil {
+		onError(s, resource, scErr)
+		return
+	}
+	if len(service.Schemas) > 0 {
+		if scErr := scClient.CreateSchemas(ctx, domain, project, ms.ServiceId, service.Schemas); scErr != nil {
+			onError(s, resource, scErr)
+			return
+		}
+	}
+	s.Created++
+}
+
+// overwriteService updates the existing service in place with the properties, schemas, tags, rules
+// and instances in the archive, the existing instances and dependencies are kept
+func overwriteService(ctx context.Context, scClient *client.Client, domain, project, serviceID string,
+	service *archive.Service) error {
+	if scErr := scClient.UpdateServiceProperties(ctx, domain, project, serviceID, service.Service.Properties); scErr != nil {
+		return scErr
+	}
+	if len(service.Schemas) > 0 {
+		if scErr := scClient.CreateSchemas(ctx, domain, project, serviceID, service.Schemas); scErr != nil {
+			return scErr
+		}
+	}
+	if err := overwriteTags(ctx, scClient, domain, project, serviceID, service.Tags); err != nil {
+		return err
+	}
+	if err := overwriteRules(ctx, scClient, domain, project, serviceID, service.CreateRequest().Rules); err != nil {
+		return err
+	}
+	for _, instance := range service.Instances {
+		instance.ServiceId = serviceID
+		if _, scErr := scClient.RegisterInstance(ctx, domain, project, serviceID, instance); scErr != nil {
+			return scErr
+		}
+	}
+	return nil
+}
+
+func overwriteTags(ctx context.Context, scClient *client.Client, domain, project, serviceID string,
+	tags map[string]string) error {
+	exists, scErr := scClient.GetTags(ctx, domain, project, serviceID)
+	if scErr != nil {
+		return scErr
+	}
+	var removed []string
+	for key := range exists {
+		if _, ok := tags[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	if len(removed) > 0 {
+		if scErr = scClient.DeleteTags(ctx, domain, project, serviceID, removed); scErr != nil {
+			return scErr
+		}
+	}
+	if len(tags) > 0 {
+		if scErr = scClient.AddTags(ctx, domain, project, serviceID, tags); scErr != nil {
+			return scErr
+		}
+	}
+	return nil
+}
+
+// overwriteRules replaces all the rules, the black and white list rules can not be mixed,
+// so the existing ones are deleted before adding
+func overwriteRules(ctx context.Context, scClient *client.Client, domain, project, serviceID string,
+	rules []*discovery.AddOrUpdateServiceRule) error {
+	exists, scErr := scClient.GetRules(ctx, domain, project, serviceID)
+	if scErr != nil {
+		return scErr
+	}
+	if len(exists) > 0 {
+		ruleIDs := make([]string, 0, len(exists))
+		for _, rule := range exists {
+			ruleIDs = append(ruleIDs, rule.RuleId)
+		}
+		if scErr = scClient.DeleteRules(ctx, domain, project, serviceID, ruleIDs); scErr != nil {
+			return scErr
+		}
+	}
+	if len(rules) > 0 {
+		if scErr = scClient.AddRules(ctx, domain, project, serviceID, rules); scErr != nil {
+			return scErr
+		}
+	}
+	return nil
+}
+
+// conflictServiceIDs returns the ids of the existing services which have
+// the same service id or the same service key with the imported one
+func conflictServiceIDs(ctx context.Context, scClient *client.Client, domain, project string,
+	ms *discovery.MicroService) ([]string, error) {
+	var serviceIDs []string
+	_, scErr := scClient.GetService(ctx, domain, project, ms.ServiceId)
+	if scErr == nil {
+		serviceIDs = append(serviceIDs, ms.ServiceId)
+	} else if !isNotExist(scErr) {
+		return nil, scErr
+	}
+
+	serviceID, scErr := scClient.ServiceExistence(ctx, domain, project,
+		ms.AppId, ms.ServiceName, ms.Version, ms.Environment)
+	if scErr != nil {
+		if isNotExist(scErr) {
+			return serviceIDs, nil
+		}
+		return nil, scErr
+	}
+	if serviceID != ms.ServiceId {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	return serviceIDs, nil
+}
+
+func isNotExist(err *errsvc.Error) bool {
+	return err.Code == discovery.ErrServiceNotExists
+}
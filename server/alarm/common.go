@@ -32,6 +32,7 @@ const (
 	IDInternalError           model.ID = "InternalError"
 	IDIncrementPullError      model.ID = "IncrementPullError"
 	IDWebsocketOfScSyncerLost model.ID = "WebsocketOfScSyncerLost"
+	IDSelfPreservationEnabled model.ID = "SelfPreservationEnabled"
 )
 
 const (
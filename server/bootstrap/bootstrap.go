@@ -32,18 +32,25 @@ import (
 
 	//cipher
 	_ "github.com/apache/servicecomb-service-center/server/plugin/security/cipher/buildin"
+	_ "github.com/apache/servicecomb-service-center/server/plugin/security/cipher/remote"
 
 	//quota
 	_ "github.com/apache/servicecomb-service-center/server/plugin/quota/buildin"
+	_ "github.com/apache/servicecomb-service-center/server/plugin/quota/remote"
 
 	//auth
 	_ "github.com/apache/servicecomb-service-center/server/plugin/auth/buildin"
+	_ "github.com/apache/servicecomb-service-center/server/plugin/auth/remote"
+
+	//auditlog
+	_ "github.com/apache/servicecomb-service-center/server/plugin/auditlog/remote"
 
 	//uuid
 	_ "github.com/apache/servicecomb-service-center/server/plugin/uuid/buildin"
 	_ "github.com/apache/servicecomb-service-center/server/plugin/uuid/context"
 
 	//tracing
+	_ "github.com/apache/servicecomb-service-center/server/plugin/tracing/potel"
 	_ "github.com/apache/servicecomb-service-center/server/plugin/tracing/pzipkin"
 
 	//tlsconf
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package brokerpb
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+// Deployment records the version of a participant currently deployed to an environment
+type Deployment struct {
+	Environment   string `json:"environment,omitempty"`
+	ParticipantId int32  `json:"participantId,omitempty"`
+	VersionId     int32  `json:"versionId,omitempty"`
+	DeployedAt    string `json:"deployedAt,omitempty"`
+}
+
+type PacticipantVersionRequest struct {
+	Pacticipant string `json:"pacticipant,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type PacticipantVersionResponse struct {
+	Response     *discovery.Response `json:"-"`
+	Pacticipant  string              `json:"pacticipant,omitempty"`
+	Version      string              `json:"version,omitempty"`
+	Tags         []string            `json:"tags,omitempty"`
+	Environments []string            `json:"environments,omitempty"`
+}
+
+// MatrixSelector selects the versions of a pacticipant,
+// all versions are selected if none of Version, Latest, Tag and Environment is set
+type MatrixSelector struct {
+	Pacticipant string `json:"pacticipant,omitempty"`
+	Version     string `json:"version,omitempty"`
+	// Latest selects the latest version, or the latest one with the Tag
+	Latest      bool   `json:"latest,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type MatrixRequest struct {
+	Selectors []*MatrixSelector `json:"selectors,omitempty"`
+	// LatestBy "cvp" keeps only the latest verified provider version for each consumer version,
+	// "cvpv" keeps all the verified provider versions
+	LatestBy string `json:"latestby,omitempty"`
+	// Latest, Tag or Environment specify the versions of the other integrated pacticipants,
+	// the result answers whether the selected versions can be deployed with them
+	Latest      bool   `json:"latest,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type MatrixPacticipant struct {
+	Name    string `json:"name"`
+	AppId   string `json:"appId,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type MatrixVerification struct {
+	Success    bool   `json:"success"`
+	VerifiedAt string `json:"verifiedAt,omitempty"`
+}
+
+type MatrixRow struct {
+	Consumer           *MatrixPacticipant  `json:"consumer"`
+	Provider           *MatrixPacticipant  `json:"provider"`
+	VerificationResult *MatrixVerification `json:"verificationResult,omitempty"`
+}
+
+type MatrixSummary struct {
+	Deployable bool   `json:"deployable"`
+	Reason     string `json:"reason"`
+	Success    int    `json:"success"`
+	Failed     int    `json:"failed"`
+	Unknown    int    `json:"unknown"`
+}
+
+type MatrixResponse struct {
+	Response *discovery.Response `json:"-"`
+	Summary  *MatrixSummary      `json:"summary,omitempty"`
+	Matrix   []*MatrixRow        `json:"matrix"`
+}
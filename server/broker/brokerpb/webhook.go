@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package brokerpb
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+// the pact broker events which trigger the webhooks
+const (
+	EventContractContentChanged        = "contract_content_changed"
+	EventProviderVerificationPublished = "provider_verification_published"
+)
+
+// WebhookRequest is the http request sent when the webhook is triggered,
+// the placeholders like ${pactbroker.consumerName} in Url, Headers and Body are replaced by the event data
+type WebhookRequest struct {
+	Method  string            `json:"method,omitempty"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Webhook is registered on the events of a provider/consumer pair,
+// the participant id 0 matches any participant
+type Webhook struct {
+	Id                    string          `json:"id"`
+	Description           string          `json:"description,omitempty"`
+	ProviderParticipantId int32           `json:"providerParticipantId,omitempty"`
+	ConsumerParticipantId int32           `json:"consumerParticipantId,omitempty"`
+	Events                []string        `json:"events"`
+	Request               *WebhookRequest `json:"request"`
+	CreatedAt             string          `json:"createdAt,omitempty"`
+}
+
+// WebhookExecution is the delivery log of a triggered webhook
+type WebhookExecution struct {
+	Id         string `json:"id"`
+	WebhookId  string `json:"webhookId"`
+	Event      string `json:"event"`
+	Url        string `json:"url"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Response   string `json:"response,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Attempts   int    `json:"attempts"`
+	ExecutedAt string `json:"executedAt"`
+}
+
+type CreateWebhookRequest struct {
+	Description string `json:"description,omitempty"`
+	// Provider and Consumer are the pacticipants, service id or service name, empty matches any one
+	Provider string          `json:"provider,omitempty"`
+	Consumer string          `json:"consumer,omitempty"`
+	Events   []string        `json:"events"`
+	Request  *WebhookRequest `json:"request"`
+}
+
+type WebhookResponse struct {
+	Response *discovery.Response `json:"-"`
+	Webhook  *Webhook            `json:"webhook,omitempty"`
+}
+
+type ListWebhooksResponse struct {
+	Response *discovery.Response `json:"-"`
+	Webhooks []*Webhook          `json:"webhooks"`
+}
+
+type ListWebhookExecutionsResponse struct {
+	Response   *discovery.Response `json:"-"`
+	Executions []*WebhookExecution `json:"executions"`
+}
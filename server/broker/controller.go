@@ -19,9 +19,12 @@ package broker
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
 
@@ -59,6 +62,39 @@ func (brokerService *Controller) URLPatterns() []rest.Route {
 		{Method: http.MethodGet,
 			Path: "/verification-results/consumer/:consumerId/version/:consumerVersion/latest",
 			Func: brokerService.RetrieveVerificationResults},
+		{Method: http.MethodGet,
+			Path: "/pacticipants/:pacticipant/versions/:version",
+			Func: brokerService.GetPacticipantVersion},
+		{Method: http.MethodPut,
+			Path: "/pacticipants/:pacticipant/versions/:version/tags/:tag",
+			Func: brokerService.CreateTag},
+		{Method: http.MethodDelete,
+			Path: "/pacticipants/:pacticipant/versions/:version/tags/:tag",
+			Func: brokerService.DeleteTag},
+		{Method: http.MethodPut,
+			Path: "/pacticipants/:pacticipant/versions/:version/environments/:environment",
+			Func: brokerService.RecordDeployment},
+		{Method: http.MethodDelete,
+			Path: "/pacticipants/:pacticipant/versions/:version/environments/:environment",
+			Func: brokerService.RecordUndeployment},
+		{Method: http.MethodGet,
+			Path: "/matrix",
+			Func: brokerService.Matrix},
+		{Method: http.MethodGet,
+			Path: "/can-i-deploy",
+			Func: brokerService.CanIDeploy},
+		{Method: http.MethodGet,
+			Path: "/webhooks",
+			Func: brokerService.ListWebhooks},
+		{Method: http.MethodPost,
+			Path: "/webhooks",
+			Func: brokerService.CreateWebhook},
+		{Method: http.MethodDelete,
+			Path: "/webhooks/:webhookId",
+			Func: brokerService.DeleteWebhook},
+		{Method: http.MethodGet,
+			Path: "/webhooks/:webhookId/executions",
+			Func: brokerService.ListWebhookExecutions},
 	}
 }
 
@@ -192,6 +228,164 @@ func (*Controller) RetrieveVerificationResults(w http.ResponseWriter, r *http.Re
 	rest.WriteResponse(w, r, resp.Response, resp)
 }
 
+func (*Controller) GetPacticipantVersion(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.GetPacticipantVersion(r.Context(), pacticipantVersionRequest(r))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) CreateTag(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.CreateTag(r.Context(), pacticipantVersionRequest(r))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.DeleteTag(r.Context(), pacticipantVersionRequest(r))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) RecordDeployment(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.RecordDeployment(r.Context(), pacticipantVersionRequest(r))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) RecordUndeployment(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.RecordUndeployment(r.Context(), pacticipantVersionRequest(r))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+// Matrix queries the verification results between the selected pacticipant versions,
+// selectors are specified as q[][pacticipant]=a&q[][version]=1&q[][pacticipant]=b&q[][latest]=true
+func (*Controller) Matrix(w http.ResponseWriter, r *http.Request) {
+	request, err := matrixRequest(r.URL.RawQuery)
+	if err != nil {
+		PactLogger.Error("invalid matrix query", err)
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return
+	}
+	resp, _ := ServiceAPI.Matrix(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+// CanIDeploy checks whether the pacticipant version can be deployed to the environment,
+// or with the latest versions of the other pacticipants tagged with 'to'
+func (*Controller) CanIDeploy(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &brokerpb.MatrixRequest{
+		Selectors: []*brokerpb.MatrixSelector{{
+			Pacticipant: query.Get("pacticipant"),
+			Version:     query.Get("version"),
+		}},
+		Tag:         query.Get("to"),
+		Environment: query.Get("environment"),
+	}
+	request.Latest = len(request.Tag) > 0 || len(request.Environment) == 0
+	resp, _ := ServiceAPI.Matrix(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.ListWebhooks(r.Context())
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		PactLogger.Error("body err", err)
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return
+	}
+	request := &brokerpb.CreateWebhookRequest{}
+	err = json.Unmarshal(requestBody, request)
+	if err != nil {
+		PactLogger.Error("Unmarshal error", err)
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return
+	}
+	resp, _ := ServiceAPI.CreateWebhook(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (*Controller) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.DeleteWebhook(r.Context(), r.URL.Query().Get(":webhookId"))
+	rest.WriteResponse(w, r, resp, nil)
+}
+
+func (*Controller) ListWebhookExecutions(w http.ResponseWriter, r *http.Request) {
+	resp, _ := ServiceAPI.ListWebhookExecutions(r.Context(), r.URL.Query().Get(":webhookId"))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func pacticipantVersionRequest(r *http.Request) *brokerpb.PacticipantVersionRequest {
+	query := r.URL.Query()
+	return &brokerpb.PacticipantVersionRequest{
+		Pacticipant: query.Get(":pacticipant"),
+		Version:     query.Get(":version"),
+		Tag:         query.Get(":tag"),
+		Environment: query.Get(":environment"),
+	}
+}
+
+func matrixRequest(rawQuery string) (*brokerpb.MatrixRequest, error) {
+	request := &brokerpb.MatrixRequest{}
+	var selector *brokerpb.MatrixSelector
+	for _, param := range strings.Split(rawQuery, "&") {
+		if len(param) == 0 {
+			continue
+		}
+		kv := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			return nil, err
+		}
+		value := ""
+		if len(kv) > 1 {
+			value, err = url.QueryUnescape(kv[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+		switch key {
+		case "q[][pacticipant]":
+			selector = &brokerpb.MatrixSelector{Pacticipant: value}
+			request.Selectors = append(request.Selectors, selector)
+			continue
+		case "latestby":
+			request.LatestBy = value
+			continue
+		case "latest":
+			request.Latest, err = strconv.ParseBool(value)
+		case "tag":
+			request.Tag = value
+		case "environment":
+			request.Environment = value
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(key, "q[][") {
+			continue
+		}
+		if selector == nil {
+			return nil, fmt.Errorf("%s must follow q[][pacticipant]", key)
+		}
+		switch key {
+		case "q[][version]":
+			selector.Version = value
+		case "q[][latest]":
+			selector.Latest, err = strconv.ParseBool(value)
+		case "q[][tag]":
+			selector.Tag = value
+		case "q[][environment]":
+			selector.Environment = value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return request, nil
+}
+
 func getScheme(r *http.Request) string {
 	if len(r.URL.Scheme) < 1 {
 		return DefaultScheme
@@ -0,0 +1,458 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+)
+
+const (
+	LatestByConsumerVersionProvider        = "cvp"
+	LatestByConsumerVersionProviderVersion = "cvpv"
+
+	reasonNoDependencies = "There are no missing dependencies"
+	reasonFailed         = "One or more verifications have failed"
+	reasonUnknown        = "Missing one or more required verification results"
+	reasonSuccess        = "All required verification results are published and successful"
+)
+
+// matrixData is the snapshot of the broker data building the matrix
+type matrixData struct {
+	participants map[int32]*brokerpb.Participant
+	versions     map[int32]*brokerpb.Version
+	tags         map[int32][]string
+	deployments  []*brokerpb.Deployment
+	integrations []*integration
+}
+
+// integration is the pact between a consumer version and a provider,
+// with the latest verification result of each provider version
+type integration struct {
+	consumer        *brokerpb.Participant
+	consumerVersion string
+	provider        *brokerpb.Participant
+	verifications   map[string]*brokerpb.Verification
+}
+
+// versionSelection is the versions of a participant selected, all versions if all is true
+type versionSelection struct {
+	participantID int32
+	all           bool
+	versions      []string
+}
+
+func (s *versionSelection) match(participantID int32, version string) bool {
+	if s.participantID != participantID {
+		return false
+	}
+	if s.all {
+		return true
+	}
+	for _, v := range s.versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Matrix returns the verification results between the pacticipant versions,
+// the summary tells whether the selected versions can be deployed
+func (*Service) Matrix(ctx context.Context, in *brokerpb.MatrixRequest) (*brokerpb.MatrixResponse, error) {
+	if in == nil || len(in.Selectors) == 0 {
+		PactLogger.Errorf(nil, "matrix request failed: invalid params.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	target := in.Latest || len(in.Tag) > 0 || len(in.Environment) > 0
+	for _, selector := range in.Selectors {
+		if selector == nil || len(selector.Pacticipant) == 0 {
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInvalidParams, "Pacticipant is required."),
+			}, nil
+		}
+		if target && !selectsVersion(selector) {
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInvalidParams,
+					fmt.Sprintf("Version of pacticipant %s is required.", selector.Pacticipant)),
+			}, nil
+		}
+	}
+	tenant := GetDefaultTenantProject()
+	data, err := loadMatrixData(ctx, tenant)
+	if err != nil {
+		PactLogger.Errorf(err, "matrix request failed, broker data cannot be searched.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "broker data cannot be searched."),
+		}, err
+	}
+	var selections []*versionSelection
+	for _, selector := range in.Selectors {
+		participant, err := ResolvePacticipant(ctx, tenant, selector.Pacticipant, false)
+		if err != nil && !errors.Is(err, ErrPacticipantAmbiguous) {
+			PactLogger.Errorf(err, "matrix request failed, pacticipant[%s] cannot be searched.", selector.Pacticipant)
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInternal, "Pacticipant cannot be searched."),
+			}, err
+		}
+		if err != nil || participant == nil {
+			message := fmt.Sprintf("Pacticipant %s does not exist.", selector.Pacticipant)
+			if err != nil {
+				message = err.Error()
+			}
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInvalidParams, message),
+			}, nil
+		}
+		selections = append(selections, data.selectVersions(participant.Id, selector))
+	}
+
+	var rows []*brokerpb.MatrixRow
+	if target {
+		rows = data.deployRows(selections, &brokerpb.MatrixSelector{
+			Latest:      in.Latest || len(in.Tag) > 0,
+			Tag:         in.Tag,
+			Environment: in.Environment,
+		})
+	} else {
+		rows = data.queryRows(selections, in.LatestBy)
+	}
+	return &brokerpb.MatrixResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Matrix retrieved successfully."),
+		Summary:  summarize(rows),
+		Matrix:   rows,
+	}, nil
+}
+
+func selectsVersion(selector *brokerpb.MatrixSelector) bool {
+	return len(selector.Version) > 0 || selector.Latest || len(selector.Tag) > 0 || len(selector.Environment) > 0
+}
+
+func loadMatrixData(ctx context.Context, tenant string) (*matrixData, error) {
+	ds := datasource.Instance()
+	data := &matrixData{
+		participants: make(map[int32]*brokerpb.Participant),
+		versions:     make(map[int32]*brokerpb.Version),
+		tags:         make(map[int32][]string),
+	}
+	participants, err := ds.ListParticipants(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	for _, participant := range participants {
+		data.participants[participant.Id] = participant
+	}
+	versions, err := ds.ListVersions(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		data.versions[version.Id] = version
+	}
+	tags, err := ds.ListTags(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		data.tags[tag.VersionId] = append(data.tags[tag.VersionId], tag.Name)
+	}
+	data.deployments, err = ds.ListDeployments(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	pactVersions, err := ds.ListPactVersions(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	for _, pactVersion := range latestPactVersions(pactVersions) {
+		version, ok := data.versions[pactVersion.VersionId]
+		if !ok {
+			continue
+		}
+		consumer, provider := data.participants[version.ParticipantId], data.participants[pactVersion.ProviderParticipantId]
+		if consumer == nil || provider == nil {
+			continue
+		}
+		verifications, err := ds.ListVerifications(ctx, tenant, pactVersion.Id)
+		if err != nil {
+			return nil, err
+		}
+		integ := &integration{
+			consumer:        consumer,
+			consumerVersion: version.Number,
+			provider:        provider,
+			verifications:   make(map[string]*brokerpb.Verification),
+		}
+		for _, verification := range verifications {
+			latest, ok := integ.verifications[verification.ProviderVersion]
+			if !ok || verification.Number > latest.Number {
+				integ.verifications[verification.ProviderVersion] = verification
+			}
+		}
+		data.integrations = append(data.integrations, integ)
+	}
+	return data, nil
+}
+
+// latestPactVersions returns the latest pact published of each consumer version and provider,
+// the older ones are replaced by the republished pact and never verified again
+func latestPactVersions(pactVersions []*brokerpb.PactVersion) []*brokerpb.PactVersion {
+	type pactKey struct {
+		versionID  int32
+		providerID int32
+	}
+	latest := make(map[pactKey]*brokerpb.PactVersion, len(pactVersions))
+	var keys []pactKey
+	for _, pactVersion := range pactVersions {
+		key := pactKey{versionID: pactVersion.VersionId, providerID: pactVersion.ProviderParticipantId}
+		last, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || pactVersion.Id > last.Id {
+			latest[key] = pactVersion
+		}
+	}
+	result := make([]*brokerpb.PactVersion, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result
+}
+
+// selectVersions returns the versions of the participant matching the selector
+func (d *matrixData) selectVersions(participantID int32, selector *brokerpb.MatrixSelector) *versionSelection {
+	selection := &versionSelection{participantID: participantID}
+	switch {
+	case len(selector.Version) > 0:
+		selection.versions = []string{selector.Version}
+	case len(selector.Environment) > 0:
+		for _, deployment := range d.deployments {
+			version, ok := d.versions[deployment.VersionId]
+			if ok && deployment.ParticipantId == participantID && deployment.Environment == selector.Environment {
+				selection.versions = append(selection.versions, version.Number)
+			}
+		}
+	case len(selector.Tag) > 0 || selector.Latest:
+		var candidates []*brokerpb.Version
+		for _, version := range d.versions {
+			if version.ParticipantId != participantID {
+				continue
+			}
+			if len(selector.Tag) > 0 && !hasTag(d.tags[version.Id], selector.Tag) {
+				continue
+			}
+			candidates = append(candidates, version)
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Order > candidates[j].Order
+		})
+		if selector.Latest && len(candidates) > 1 {
+			candidates = candidates[:1]
+		}
+		for _, version := range candidates {
+			selection.versions = append(selection.versions, version.Number)
+		}
+	default:
+		selection.all = true
+	}
+	return selection
+}
+
+// queryRows returns the rows between the selected versions,
+// or all the rows of the selected versions if only one is selected
+func (d *matrixData) queryRows(selections []*versionSelection, latestBy string) []*brokerpb.MatrixRow {
+	var rows []*brokerpb.MatrixRow
+	for _, integ := range d.integrations {
+		consumerMatched, providerMatched := -1, -1
+		for i, selection := range selections {
+			if consumerMatched < 0 && selection.match(integ.consumer.Id, integ.consumerVersion) {
+				consumerMatched = i
+			}
+		}
+		if len(selections) == 1 {
+			if consumerMatched >= 0 || selections[0].participantID == integ.provider.Id {
+				rows = append(rows, d.integrationRows(integ, selections[0], latestBy)...)
+			}
+			continue
+		}
+		if consumerMatched < 0 {
+			continue
+		}
+		for i, selection := range selections {
+			if i != consumerMatched && selection.participantID == integ.provider.Id {
+				providerMatched = i
+				break
+			}
+		}
+		if providerMatched < 0 {
+			continue
+		}
+		rows = append(rows, d.integrationRows(integ, selections[providerMatched], latestBy)...)
+	}
+	return rows
+}
+
+// integrationRows returns the rows of the integration, filtered by the provider selection
+func (d *matrixData) integrationRows(integ *integration, provider *versionSelection,
+	latestBy string) []*brokerpb.MatrixRow {
+	var verifications []*brokerpb.Verification
+	for providerVersion, verification := range integ.verifications {
+		if provider.participantID == integ.provider.Id && !provider.match(integ.provider.Id, providerVersion) {
+			continue
+		}
+		verifications = append(verifications, verification)
+	}
+	if len(verifications) == 0 {
+		if provider.participantID == integ.provider.Id && !provider.all {
+			return nil
+		}
+		return []*brokerpb.MatrixRow{newMatrixRow(integ, "", nil)}
+	}
+	sort.Slice(verifications, func(i, j int) bool {
+		return verifications[i].Id > verifications[j].Id
+	})
+	if latestBy == LatestByConsumerVersionProvider {
+		verifications = verifications[:1]
+	}
+	rows := make([]*brokerpb.MatrixRow, 0, len(verifications))
+	for _, verification := range verifications {
+		rows = append(rows, newMatrixRow(integ, verification.ProviderVersion, verification))
+	}
+	return rows
+}
+
+// deployRows returns the rows required to deploy the selected versions
+// with the target versions of the other integrated pacticipants
+func (d *matrixData) deployRows(selections []*versionSelection, target *brokerpb.MatrixSelector) []*brokerpb.MatrixRow {
+	var rows []*brokerpb.MatrixRow
+	targets := make(map[int32]*versionSelection)
+	targetOf := func(participantID int32) *versionSelection {
+		for _, selection := range selections {
+			if selection.participantID == participantID {
+				return selection
+			}
+		}
+		if _, ok := targets[participantID]; !ok {
+			targets[participantID] = d.selectVersions(participantID, target)
+		}
+		return targets[participantID]
+	}
+	for _, selection := range selections {
+		for _, integ := range d.integrations {
+			switch {
+			case selection.match(integ.consumer.Id, integ.consumerVersion):
+				// the providers of the selected consumer version must have verified it
+				providerVersions := targetOf(integ.provider.Id).versions
+				if len(providerVersions) == 0 {
+					rows = append(rows, newMatrixRow(integ, "", nil))
+				}
+				for _, providerVersion := range providerVersions {
+					rows = append(rows, newMatrixRow(integ, providerVersion, integ.verifications[providerVersion]))
+				}
+			case selection.participantID == integ.provider.Id && integ.consumer.Id != integ.provider.Id:
+				// the selected provider version must have verified the target consumer versions
+				if targets[integ.consumer.Id] == nil && isSelected(selections, integ.consumer.Id) {
+					continue
+				}
+				if !targetOf(integ.consumer.Id).match(integ.consumer.Id, integ.consumerVersion) {
+					continue
+				}
+				for _, providerVersion := range selection.versions {
+					rows = append(rows, newMatrixRow(integ, providerVersion, integ.verifications[providerVersion]))
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func isSelected(selections []*versionSelection, participantID int32) bool {
+	for _, selection := range selections {
+		if selection.participantID == participantID {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func newMatrixRow(integ *integration, providerVersion string, verification *brokerpb.Verification) *brokerpb.MatrixRow {
+	row := &brokerpb.MatrixRow{
+		Consumer: &brokerpb.MatrixPacticipant{
+			Name:    integ.consumer.ServiceName,
+			AppId:   integ.consumer.AppId,
+			Version: integ.consumerVersion,
+		},
+		Provider: &brokerpb.MatrixPacticipant{
+			Name:    integ.provider.ServiceName,
+			AppId:   integ.provider.AppId,
+			Version: providerVersion,
+		},
+	}
+	if verification != nil {
+		row.VerificationResult = &brokerpb.MatrixVerification{
+			Success:    verification.Success,
+			VerifiedAt: verification.VerificationDate,
+		}
+	}
+	return row
+}
+
+func summarize(rows []*brokerpb.MatrixRow) *brokerpb.MatrixSummary {
+	summary := &brokerpb.MatrixSummary{}
+	for _, row := range rows {
+		switch {
+		case row.VerificationResult == nil:
+			summary.Unknown++
+		case row.VerificationResult.Success:
+			summary.Success++
+		default:
+			summary.Failed++
+		}
+	}
+	switch {
+	case len(rows) == 0:
+		summary.Reason = reasonNoDependencies
+	case summary.Failed > 0:
+		summary.Reason = reasonFailed
+	case summary.Unknown > 0:
+		summary.Reason = reasonUnknown
+	default:
+		summary.Reason = reasonSuccess
+	}
+	summary.Deployable = summary.Failed == 0 && summary.Unknown == 0
+	return summary
+}
@@ -0,0 +1,302 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+)
+
+var ErrPacticipantAmbiguous = errors.New("pacticipant name is ambiguous, use the service id instead")
+
+func (*Service) GetPacticipantVersion(ctx context.Context,
+	in *brokerpb.PacticipantVersionRequest) (*brokerpb.PacticipantVersionResponse, error) {
+	if in == nil || len(in.Pacticipant) == 0 || len(in.Version) == 0 {
+		PactLogger.Errorf(nil, "pacticipant version retrieve request failed: invalid params.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	_, version, resp, err := getPacticipantVersion(ctx, tenant, in, false)
+	if resp != nil {
+		return resp, err
+	}
+	return pacticipantVersionResponse(ctx, tenant, in, version)
+}
+
+func (*Service) CreateTag(ctx context.Context,
+	in *brokerpb.PacticipantVersionRequest) (*brokerpb.PacticipantVersionResponse, error) {
+	if in == nil || len(in.Pacticipant) == 0 || len(in.Version) == 0 || len(in.Tag) == 0 {
+		PactLogger.Errorf(nil, "tag create request failed: invalid params.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	_, version, resp, err := getPacticipantVersion(ctx, tenant, in, true)
+	if resp != nil {
+		return resp, err
+	}
+	err = datasource.Instance().CreateTag(ctx, tenant, &brokerpb.Tag{Name: in.Tag, VersionId: version.Id})
+	if err != nil {
+		PactLogger.Errorf(err, "tag[%s] create failed, pacticipant is %s, version is %s.", in.Tag, in.Pacticipant, in.Version)
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "tag cannot be created."),
+		}, err
+	}
+	PactLogger.Infof("Tag created: (%s, %s, %s)", in.Pacticipant, in.Version, in.Tag)
+	return pacticipantVersionResponse(ctx, tenant, in, version)
+}
+
+func (*Service) DeleteTag(ctx context.Context,
+	in *brokerpb.PacticipantVersionRequest) (*brokerpb.PacticipantVersionResponse, error) {
+	if in == nil || len(in.Pacticipant) == 0 || len(in.Version) == 0 || len(in.Tag) == 0 {
+		PactLogger.Errorf(nil, "tag delete request failed: invalid params.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	_, version, resp, err := getPacticipantVersion(ctx, tenant, in, false)
+	if resp != nil {
+		return resp, err
+	}
+	err = datasource.Instance().DeleteTag(ctx, tenant, version.Id, in.Tag)
+	if err != nil {
+		PactLogger.Errorf(err, "tag[%s] delete failed, pacticipant is %s, version is %s.", in.Tag, in.Pacticipant, in.Version)
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "tag cannot be deleted."),
+		}, err
+	}
+	PactLogger.Infof("Tag deleted: (%s, %s, %s)", in.Pacticipant, in.Version, in.Tag)
+	return pacticipantVersionResponse(ctx, tenant, in, version)
+}
+
+// RecordDeployment records the version as the one of the pacticipant deployed to the environment
+func (*Service) RecordDeployment(ctx context.Context,
+	in *brokerpb.PacticipantVersionRequest) (*brokerpb.PacticipantVersionResponse, error) {
+	if in == nil || len(in.Pacticipant) == 0 || len(in.Version) == 0 || len(in.Environment) == 0 {
+		PactLogger.Errorf(nil, "deployment record request failed: invalid params.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	participant, version, resp, err := getPacticipantVersion(ctx, tenant, in, true)
+	if resp != nil {
+		return resp, err
+	}
+	err = datasource.Instance().PutDeployment(ctx, tenant, &brokerpb.Deployment{
+		Environment:   in.Environment,
+		ParticipantId: participant.Id,
+		VersionId:     version.Id,
+		DeployedAt:    time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		PactLogger.Errorf(err, "deployment record failed, pacticipant is %s, version is %s, environment is %s.",
+			in.Pacticipant, in.Version, in.Environment)
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "deployment cannot be recorded."),
+		}, err
+	}
+	PactLogger.Infof("Deployment recorded: (%s, %s, %s)", in.Pacticipant, in.Version, in.Environment)
+	return pacticipantVersionResponse(ctx, tenant, in, version)
+}
+
+// RecordUndeployment removes the version of the pacticipant from the environment
+func (*Service) RecordUndeployment(ctx context.Context,
+	in *brokerpb.PacticipantVersionRequest) (*brokerpb.PacticipantVersionResponse, error) {
+	if in == nil || len(in.Pacticipant) == 0 || len(in.Version) == 0 || len(in.Environment) == 0 {
+		PactLogger.Errorf(nil, "undeployment record request failed: invalid params.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	participant, version, resp, err := getPacticipantVersion(ctx, tenant, in, false)
+	if resp != nil {
+		return resp, err
+	}
+	deployed, err := deployedEnvironments(ctx, tenant, version)
+	if err != nil {
+		PactLogger.Errorf(err, "undeployment record failed, deployments cannot be searched.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "deployments cannot be searched."),
+		}, err
+	}
+	if !util.SliceHave(deployed, in.Environment) {
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams,
+				fmt.Sprintf("Version is not deployed to environment %s.", in.Environment)),
+		}, nil
+	}
+	err = datasource.Instance().DeleteDeployment(ctx, tenant, in.Environment, participant.Id)
+	if err != nil {
+		PactLogger.Errorf(err, "undeployment record failed, pacticipant is %s, version is %s, environment is %s.",
+			in.Pacticipant, in.Version, in.Environment)
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "undeployment cannot be recorded."),
+		}, err
+	}
+	PactLogger.Infof("Undeployment recorded: (%s, %s, %s)", in.Pacticipant, in.Version, in.Environment)
+	return pacticipantVersionResponse(ctx, tenant, in, version)
+}
+
+// getPacticipantVersion returns the participant and its version, creates them if create is true,
+// the response is not nil if they can not be found
+func getPacticipantVersion(ctx context.Context, tenant string, in *brokerpb.PacticipantVersionRequest,
+	create bool) (*brokerpb.Participant, *brokerpb.Version, *brokerpb.PacticipantVersionResponse, error) {
+	participant, err := ResolvePacticipant(ctx, tenant, in.Pacticipant, create)
+	if err != nil {
+		PactLogger.Errorf(err, "pacticipant[%s] cannot be searched.", in.Pacticipant)
+		if errors.Is(err, ErrPacticipantAmbiguous) {
+			return nil, nil, &brokerpb.PacticipantVersionResponse{
+				Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+			}, nil
+		}
+		return nil, nil, &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "Pacticipant cannot be searched."),
+		}, err
+	}
+	if participant == nil {
+		return nil, nil, &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Pacticipant does not exist."),
+		}, nil
+	}
+	version, err := GetVersion(ctx, tenant, in.Version, participant.Id)
+	if err != nil {
+		PactLogger.Errorf(err, "pacticipant[%s] version[%s] cannot be searched.", in.Pacticipant, in.Version)
+		return nil, nil, &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "version cannot be searched."),
+		}, err
+	}
+	if version != nil {
+		return participant, version, nil, nil
+	}
+	if !create {
+		return nil, nil, &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Pacticipant version does not exist."),
+		}, nil
+	}
+	id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerVersion)
+	if err == nil {
+		order := GetLastestVersionNumberForParticipant(ctx, tenant, participant.Id) + 1
+		version = &brokerpb.Version{Id: id + 1, Number: in.Version, ParticipantId: participant.Id, Order: order}
+		_, err = CreateVersion(ctx, tenant, version)
+	}
+	if err != nil {
+		return nil, nil, &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "version cannot be created."),
+		}, err
+	}
+	return participant, version, nil, nil
+}
+
+// ResolvePacticipant returns the participant of the service id,
+// or the only one named pacticipant if no such service
+func ResolvePacticipant(ctx context.Context, tenant string, pacticipant string,
+	create bool) (*brokerpb.Participant, error) {
+	service, err := GetService(ctx, tenant, pacticipant)
+	if err == nil {
+		participant, err := GetParticipant(ctx, tenant, service.AppId, service.ServiceName)
+		if err != nil || participant != nil || !create {
+			return participant, err
+		}
+		id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerParticipant)
+		if err != nil {
+			return nil, err
+		}
+		participant = &brokerpb.Participant{Id: id + 1, AppId: service.AppId, ServiceName: service.ServiceName}
+		_, err = CreateParticipant(ctx, tenant, participant)
+		if err != nil {
+			return nil, err
+		}
+		return participant, nil
+	}
+	if !errors.Is(err, datasource.ErrNoData) {
+		return nil, err
+	}
+	participants, err := datasource.Instance().ListParticipants(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	var matched *brokerpb.Participant
+	for _, participant := range participants {
+		if participant.ServiceName != pacticipant {
+			continue
+		}
+		if matched != nil {
+			return nil, ErrPacticipantAmbiguous
+		}
+		matched = participant
+	}
+	return matched, nil
+}
+
+func pacticipantVersionResponse(ctx context.Context, tenant string, in *brokerpb.PacticipantVersionRequest,
+	version *brokerpb.Version) (*brokerpb.PacticipantVersionResponse, error) {
+	tags, err := datasource.Instance().ListTags(ctx, tenant)
+	if err != nil {
+		PactLogger.Errorf(err, "tags cannot be searched.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "tags cannot be searched."),
+		}, err
+	}
+	environments, err := deployedEnvironments(ctx, tenant, version)
+	if err != nil {
+		PactLogger.Errorf(err, "deployments cannot be searched.")
+		return &brokerpb.PacticipantVersionResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "deployments cannot be searched."),
+		}, err
+	}
+	resp := &brokerpb.PacticipantVersionResponse{
+		Response:     pb.CreateResponse(pb.ResponseSuccess, "Pacticipant version found."),
+		Pacticipant:  in.Pacticipant,
+		Version:      version.Number,
+		Environments: environments,
+	}
+	for _, tag := range tags {
+		if tag.VersionId == version.Id {
+			resp.Tags = append(resp.Tags, tag.Name)
+		}
+	}
+	return resp, nil
+}
+
+func deployedEnvironments(ctx context.Context, tenant string, version *brokerpb.Version) ([]string, error) {
+	deployments, err := datasource.Instance().ListDeployments(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	var environments []string
+	for _, deployment := range deployments {
+		if deployment.ParticipantId == version.ParticipantId && deployment.VersionId == version.Id {
+			environments = append(environments, deployment.Environment)
+		}
+	}
+	return environments, nil
+}
@@ -29,10 +29,7 @@ import (
 	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
-	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
-	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
-	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
 	apt "github.com/apache/servicecomb-service-center/server/core"
 	pb "github.com/go-chassis/cari/discovery"
@@ -117,7 +114,7 @@ func (*Service) RetrieveProviderPacts(ctx context.Context,
 	}
 	tenant := GetDefaultTenantProject()
 
-	provider, err := serviceUtil.GetService(ctx, tenant, in.ProviderId)
+	provider, err := GetService(ctx, tenant, in.ProviderId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("all provider pact retrieve failed, providerId is %s: provider not exist.", in.ProviderId))
@@ -141,49 +138,31 @@ func (*Service) RetrieveProviderPacts(ctx context.Context,
 	}
 	PactLogger.Infof("[RetrieveProviderPacts] Provider participant id : %d", providerParticipant.Id)
 	// Get all versions
-	versionKey := util.StringJoin([]string{GetBrokerVersionKey(tenant), ""}, "/")
-	versions, err := Store().Version().Search(ctx,
-		client.WithPrefix(),
-		client.WithStrKey(versionKey))
-
+	versions, err := datasource.Instance().ListVersions(ctx, tenant)
 	if err != nil {
 		return nil, err
 	}
-	if len(versions.Kvs) == 0 {
+	if len(versions) == 0 {
 		PactLogger.Info("[RetrieveProviderPacts] No versions found, sorry")
 		return nil, nil
 	}
 	// Store versions in a map
 	versionObjects := make(map[int32]brokerpb.Version)
-	for i := 0; i < len(versions.Kvs); i++ {
-		version := &brokerpb.Version{}
-		err = json.Unmarshal(versions.Kvs[i].Value.([]byte), version)
-		if err != nil {
-			return nil, err
-		}
+	for _, version := range versions {
 		PactLogger.Infof("[RetrieveProviderPacts] Version found : (%d, %s)", version.Id, version.Number)
 		versionObjects[version.Id] = *version
 	}
 	// Get all pactversions and filter using the provider participant id
-	pactVersionKey := util.StringJoin([]string{GetBrokerPactVersionKey(tenant), ""}, "/")
-	pactVersions, err := Store().PactVersion().Search(ctx,
-		client.WithStrKey(pactVersionKey),
-		client.WithPrefix())
-
+	pactVersions, err := datasource.Instance().ListPactVersions(ctx, tenant)
 	if err != nil {
 		return nil, err
 	}
-	if len(pactVersions.Kvs) == 0 {
+	if len(pactVersions) == 0 {
 		PactLogger.Info("[RetrieveProviderPacts] No pact version found, sorry")
 		return nil, nil
 	}
 	participantToVersionObj := make(map[int32]brokerpb.Version)
-	for i := 0; i < len(pactVersions.Kvs); i++ {
-		pactVersion := &brokerpb.PactVersion{}
-		err = json.Unmarshal(pactVersions.Kvs[i].Value.([]byte), pactVersion)
-		if err != nil {
-			return nil, err
-		}
+	for _, pactVersion := range pactVersions {
 		if pactVersion.ProviderParticipantId != providerParticipant.Id {
 			continue
 		}
@@ -198,30 +177,21 @@ func (*Service) RetrieveProviderPacts(ctx context.Context,
 		}
 	}
 	// Get all participants
-	participantKey := util.StringJoin([]string{GetBrokerParticipantKey(tenant), ""}, "/")
-	participants, err := Store().Participant().Search(ctx,
-		client.WithStrKey(participantKey),
-		client.WithPrefix())
-
+	participants, err := datasource.Instance().ListParticipants(ctx, tenant)
 	if err != nil {
 		return nil, err
 	}
-	if len(participants.Kvs) == 0 {
+	if len(participants) == 0 {
 		return nil, nil
 	}
 	consumerInfoArr := make([]*brokerpb.ConsumerInfo, 0)
-	for i := 0; i < len(participants.Kvs); i++ {
-		participant := &brokerpb.Participant{}
-		err = json.Unmarshal(participants.Kvs[i].Value.([]byte), participant)
-		if err != nil {
-			return nil, err
-		}
+	for _, participant := range participants {
 		if _, ok := participantToVersionObj[participant.Id]; !ok {
 			continue
 		}
 		PactLogger.Infof("[RetrieveProviderPacts] Consumer found: (%d, %s, %s)", participant.Id, participant.AppId, participant.ServiceName)
 		consumerVersion := participantToVersionObj[participant.Id].Number
-		consumerID, err := serviceUtil.GetServiceID(ctx, &pb.MicroServiceKey{
+		consumerID, err := GetServiceID(ctx, &pb.MicroServiceKey{
 			Tenant:      tenant,
 			AppId:       participant.AppId,
 			ServiceName: participant.ServiceName,
@@ -270,7 +240,7 @@ func (*Service) GetAllProviderPacts(ctx context.Context,
 	}
 	tenant := GetDefaultTenantProject()
 
-	provider, err := serviceUtil.GetService(ctx, tenant, in.ProviderId)
+	provider, err := GetService(ctx, tenant, in.ProviderId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("all provider pact retrieve failed, providerId is %s: provider not exist.", in.ProviderId))
@@ -294,49 +264,31 @@ func (*Service) GetAllProviderPacts(ctx context.Context,
 	}
 	PactLogger.Infof("[RetrieveProviderPacts] Provider participant id : %d", providerParticipant.Id)
 	// Get all versions
-	versionKey := util.StringJoin([]string{GetBrokerVersionKey(tenant), ""}, "/")
-	versions, err := Store().Version().Search(ctx,
-		client.WithPrefix(),
-		client.WithStrKey(versionKey))
-
+	versions, err := datasource.Instance().ListVersions(ctx, tenant)
 	if err != nil {
 		return nil, err
 	}
-	if len(versions.Kvs) == 0 {
+	if len(versions) == 0 {
 		PactLogger.Info("[RetrieveProviderPacts] No versions found, sorry")
 		return nil, nil
 	}
 	// Store versions in a map
 	versionObjects := make(map[int32]brokerpb.Version)
-	for i := 0; i < len(versions.Kvs); i++ {
-		version := &brokerpb.Version{}
-		err = json.Unmarshal(versions.Kvs[i].Value.([]byte), version)
-		if err != nil {
-			return nil, err
-		}
+	for _, version := range versions {
 		PactLogger.Infof("[RetrieveProviderPacts] Version found : (%d, %s)", version.Id, version.Number)
 		versionObjects[version.Id] = *version
 	}
 	// Get all pactversions and filter using the provider participant id
-	pactVersionKey := util.StringJoin([]string{GetBrokerPactVersionKey(tenant), ""}, "/")
-	pactVersions, err := Store().PactVersion().Search(ctx,
-		client.WithStrKey(pactVersionKey),
-		client.WithPrefix())
-
+	pactVersions, err := datasource.Instance().ListPactVersions(ctx, tenant)
 	if err != nil {
 		return nil, err
 	}
-	if len(pactVersions.Kvs) == 0 {
+	if len(pactVersions) == 0 {
 		PactLogger.Info("[RetrieveProviderPacts] No pact version found, sorry")
 		return nil, nil
 	}
 	participantToVersionObj := make(map[int32]brokerpb.Version)
-	for i := 0; i < len(pactVersions.Kvs); i++ {
-		pactVersion := &brokerpb.PactVersion{}
-		err = json.Unmarshal(pactVersions.Kvs[i].Value.([]byte), pactVersion)
-		if err != nil {
-			return nil, err
-		}
+	for _, pactVersion := range pactVersions {
 		if pactVersion.ProviderParticipantId != providerParticipant.Id {
 			continue
 		}
@@ -351,30 +303,21 @@ func (*Service) GetAllProviderPacts(ctx context.Context,
 		}
 	}
 	// Get all participants
-	participantKey := util.StringJoin([]string{GetBrokerParticipantKey(tenant), ""}, "/")
-	participants, err := Store().Participant().Search(ctx,
-		client.WithStrKey(participantKey),
-		client.WithPrefix())
-
+	participants, err := datasource.Instance().ListParticipants(ctx, tenant)
 	if err != nil {
 		return nil, err
 	}
-	if len(participants.Kvs) == 0 {
+	if len(participants) == 0 {
 		return nil, nil
 	}
 	consumerInfoArr := make([]*brokerpb.ConsumerInfo, 0)
-	for i := 0; i < len(participants.Kvs); i++ {
-		participant := &brokerpb.Participant{}
-		err = json.Unmarshal(participants.Kvs[i].Value.([]byte), participant)
-		if err != nil {
-			return nil, err
-		}
+	for _, participant := range participants {
 		if _, ok := participantToVersionObj[participant.Id]; !ok {
 			continue
 		}
 		PactLogger.Infof("[RetrieveProviderPacts] Consumer found: (%d, %s, %s)", participant.Id, participant.AppId, participant.ServiceName)
 		consumerVersion := participantToVersionObj[participant.Id].Number
-		consumerID, err := serviceUtil.GetServiceID(ctx, &pb.MicroServiceKey{
+		consumerID, err := GetServiceID(ctx, &pb.MicroServiceKey{
 			Tenant:      tenant,
 			AppId:       participant.AppId,
 			ServiceName: participant.ServiceName,
@@ -420,7 +363,7 @@ func (*Service) RetrieveVerificationResults(ctx context.Context, in *brokerpb.Re
 		}, nil
 	}
 	tenant := GetDefaultTenantProject()
-	consumer, err := serviceUtil.GetService(ctx, tenant, in.ConsumerId)
+	consumer, err := GetService(ctx, tenant, in.ConsumerId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("verification result retrieve request failed, consumerID is %s: consumer not exist.", in.ConsumerId))
@@ -452,12 +395,8 @@ func (*Service) RetrieveVerificationResults(ctx context.Context, in *brokerpb.Re
 		}, err
 	}
 	PactLogger.Infof("Version found/created: (%d, %s, %d, %d)", version.Id, version.Number, version.ParticipantId, version.Order)
-	key := util.StringJoin([]string{GetBrokerPactVersionKey(tenant), strconv.Itoa(int(version.Id))}, "/")
-	pactVersions, err := Store().PactVersion().Search(ctx,
-		client.WithPrefix(),
-		client.WithStrKey(key))
-
-	if err != nil || len(pactVersions.Kvs) == 0 {
+	pactVersions, err := listPactVersionsOfVersion(ctx, tenant, version.Id)
+	if err != nil || len(pactVersions) == 0 {
 		PactLogger.Errorf(nil, "verification result publish request failed, pact version cannot be searched.")
 		return &brokerpb.RetrieveVerificationResponse{
 			Response: pb.CreateResponse(pb.ErrInvalidParams, "pact version cannot be searched."),
@@ -470,21 +409,9 @@ func (*Service) RetrieveVerificationResults(ctx context.Context, in *brokerpb.Re
 	unknowns := make([]string, 0)
 
 	verificationDetailsArr := make([]*brokerpb.VerificationDetail, 0)
-	for j := 0; j < len(pactVersions.Kvs); j++ {
-		pactVersion := &brokerpb.PactVersion{}
-		err = json.Unmarshal(pactVersions.Kvs[j].Value.([]byte), &pactVersion)
-		if err != nil {
-			PactLogger.Errorf(nil, "verification result retrieve request failed, pact version cannot be searched.")
-			return &brokerpb.RetrieveVerificationResponse{
-				Response: pb.CreateResponse(pb.ErrInvalidParams, "pact version cannot be searched."),
-			}, err
-		}
-		key = util.StringJoin([]string{GetBrokerVerificationKey(tenant), strconv.Itoa(int(pactVersion.Id))}, "/")
-		verifications, err := Store().Verification().Search(ctx,
-			client.WithPrefix(),
-			client.WithStrKey(key))
-
-		if err != nil || len(verifications.Kvs) == 0 {
+	for _, pactVersion := range pactVersions {
+		verifications, err := datasource.Instance().ListVerifications(ctx, tenant, pactVersion.Id)
+		if err != nil || len(verifications) == 0 {
 			PactLogger.Errorf(nil, "verification result retrieve request failed, verification results cannot be searched.")
 			return &brokerpb.RetrieveVerificationResponse{
 				Response: pb.CreateResponse(pb.ErrInvalidParams, "verification results cannot be searched."),
@@ -492,15 +419,7 @@ func (*Service) RetrieveVerificationResults(ctx context.Context, in *brokerpb.Re
 		}
 		lastNumber := int32(math.MinInt32)
 		var lastVerificationResult *brokerpb.Verification
-		for i := 0; i < len(verifications.Kvs); i++ {
-			verification := &brokerpb.Verification{}
-			err = json.Unmarshal(verifications.Kvs[i].Value.([]byte), &verification)
-			if err != nil {
-				PactLogger.Errorf(nil, "verification result retrieve request failed, verification result unmarshall error.")
-				return &brokerpb.RetrieveVerificationResponse{
-					Response: pb.CreateResponse(pb.ErrInvalidParams, "verification result unmarshall error."),
-				}, err
-			}
+		for _, verification := range verifications {
 			if verification.Number > lastNumber {
 				lastNumber = verification.Number
 				lastVerificationResult = verification
@@ -517,27 +436,15 @@ func (*Service) RetrieveVerificationResults(ctx context.Context, in *brokerpb.Re
 			lastVerificationResult.Success, lastVerificationResult.ProviderVersion,
 			lastVerificationResult.BuildUrl, lastVerificationResult.VerificationDate)
 
-		key = util.StringJoin([]string{GetBrokerParticipantKey(tenant), ""}, "/")
-		participants, err := Store().Participant().Search(ctx,
-			client.WithStrKey(key),
-			client.WithPrefix())
-
-		if err != nil || len(participants.Kvs) == 0 {
+		participants, err := datasource.Instance().ListParticipants(ctx, tenant)
+		if err != nil || len(participants) == 0 {
 			PactLogger.Errorf(nil, "verification result retrieve request failed, provider participant cannot be searched.")
 			return &brokerpb.RetrieveVerificationResponse{
 				Response: pb.CreateResponse(pb.ErrInvalidParams, "provider participant cannot be searched."),
 			}, err
 		}
 		var providerParticipant *brokerpb.Participant
-		for i := 0; i < len(participants.Kvs); i++ {
-			participant := &brokerpb.Participant{}
-			err = json.Unmarshal(participants.Kvs[i].Value.([]byte), &participant)
-			if err != nil {
-				PactLogger.Errorf(nil, "verification result retrieve request failed, verification result unmarshall error.")
-				return &brokerpb.RetrieveVerificationResponse{
-					Response: pb.CreateResponse(pb.ErrInvalidParams, "verification result unmarshall error."),
-				}, err
-			}
+		for _, participant := range participants {
 			if participant.Id == pactVersion.ProviderParticipantId {
 				providerParticipant = participant
 				break
@@ -595,7 +502,7 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		}, nil
 	}
 	tenant := GetDefaultTenantProject()
-	consumer, err := serviceUtil.GetService(ctx, tenant, in.ConsumerId)
+	consumer, err := GetService(ctx, tenant, in.ConsumerId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("verification result publish request failed, consumerID is %s: consumer not exist.", in.ConsumerId))
@@ -627,27 +534,15 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		}, err
 	}
 	PactLogger.Infof("Version found/created: (%d, %s, %d, %d)", version.Id, version.Number, version.ParticipantId, version.Order)
-	key := util.StringJoin([]string{GetBrokerPactKey(tenant), ""}, "/")
-	pacts, err := Store().Pact().Search(ctx,
-		client.WithStrKey(key),
-		client.WithPrefix())
-
-	if err != nil || len(pacts.Kvs) == 0 {
+	pacts, err := datasource.Instance().ListPacts(ctx, tenant)
+	if err != nil || len(pacts) == 0 {
 		PactLogger.Errorf(nil, "verification result publish request failed, pact cannot be searched.")
 		return &brokerpb.PublishVerificationResponse{
 			Response: pb.CreateResponse(pb.ErrInvalidParams, "pact cannot be searched."),
 		}, err
 	}
 	pactExists := false
-	for i := 0; i < len(pacts.Kvs); i++ {
-		pact := &brokerpb.Pact{}
-		err = json.Unmarshal(pacts.Kvs[i].Value.([]byte), &pact)
-		if err != nil {
-			PactLogger.Errorf(nil, "verification result publish request failed, pact cannot be searched.")
-			return &brokerpb.PublishVerificationResponse{
-				Response: pb.CreateResponse(pb.ErrInvalidParams, "pact cannot be searched."),
-			}, err
-		}
+	for _, pact := range pacts {
 		if pact.Id == in.PactId {
 			pactExists = true
 		}
@@ -666,11 +561,7 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		}, err
 	}
 	// Check if some verification results already exists
-	key = util.StringJoin([]string{GetBrokerVerificationKey(tenant), strconv.Itoa(int(pactVersion.Id))}, "/")
-	verifications, err := Store().Verification().Search(ctx,
-		client.WithStrKey(key),
-		client.WithPrefix())
-
+	verifications, err := datasource.Instance().ListVerifications(ctx, tenant, pactVersion.Id)
 	if err != nil {
 		PactLogger.Errorf(nil, "verification result publish request failed, verification result cannot be searched.")
 		return &brokerpb.PublishVerificationResponse{
@@ -678,19 +569,9 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		}, err
 	}
 	lastNumber := int32(math.MinInt32)
-	if len(verifications.Kvs) != 0 {
-		for i := 0; i < len(verifications.Kvs); i++ {
-			verification := &brokerpb.Verification{}
-			err = json.Unmarshal(verifications.Kvs[i].Value.([]byte), &verification)
-			if err != nil {
-				PactLogger.Errorf(nil, "verification result publish request failed, verification result unmarshall error.")
-				return &brokerpb.PublishVerificationResponse{
-					Response: pb.CreateResponse(pb.ErrInvalidParams, "verification result unmarshall error."),
-				}, err
-			}
-			if verification.Number > lastNumber {
-				lastNumber = verification.Number
-			}
+	for _, verification := range verifications {
+		if verification.Number > lastNumber {
+			lastNumber = verification.Number
 		}
 	}
 	if lastNumber < 0 {
@@ -699,15 +580,14 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		lastNumber++
 	}
 	verificationDate := time.Now().Format(time.RFC3339)
-	verificationKey := GenerateBrokerVerificationKey(tenant, pactVersion.Id, lastNumber)
-	id, err := GetData(ctx, GetBrokerLatestVerificationIDKey())
+	id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerVerification)
 	if err != nil {
 		return &brokerpb.PublishVerificationResponse{
 			Response: pb.CreateResponse(pb.ErrInternal, "get data error."),
 		}, err
 	}
 	verification := &brokerpb.Verification{
-		Id:               int32(id) + 1,
+		Id:               id + 1,
 		Number:           lastNumber,
 		PactVersionId:    pactVersion.Id,
 		Success:          in.Success,
@@ -715,7 +595,7 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		BuildUrl:         "",
 		VerificationDate: verificationDate,
 	}
-	response, err := CreateVerification(ctx, verificationKey, *verification)
+	response, err := CreateVerification(ctx, tenant, verification)
 	if err != nil {
 		return response, err
 	}
@@ -729,6 +609,22 @@ func (*Service) PublishVerificationResults(ctx context.Context, in *brokerpb.Pub
 		VerificationDate:           verification.VerificationDate,
 	}
 	PactLogger.Infof("Verification result published successfully ...")
+	providerParticipant, err := findParticipant(ctx, tenant, pactVersion.ProviderParticipantId)
+	if err != nil || providerParticipant == nil {
+		PactLogger.Errorf(err, "provider participant[%d] cannot be searched, webhooks are not triggered.",
+			pactVersion.ProviderParticipantId)
+	} else {
+		fireWebhooks(&webhookEvent{
+			tenant:          tenant,
+			name:            brokerpb.EventProviderVerificationPublished,
+			consumer:        consumerParticipant,
+			consumerVersion: version.Number,
+			provider:        providerParticipant,
+			providerVersion: verification.ProviderVersion,
+			pactID:          in.PactId,
+			verification:    verification,
+		})
+	}
 	return &brokerpb.PublishVerificationResponse{
 		Response:     pb.CreateResponse(pb.ResponseSuccess, "Verification result published successfully."),
 		Confirmation: verificationResponse,
@@ -744,7 +640,7 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 	}
 	tenant := GetDefaultTenantProject()
 
-	provider, err := serviceUtil.GetService(ctx, tenant, in.ProviderId)
+	provider, err := GetService(ctx, tenant, in.ProviderId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("pact publish failed, providerId is %s: provider not exist.", in.ProviderId))
@@ -758,7 +654,7 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 		}, err
 	}
 	PactLogger.Info(fmt.Sprintf("provider service found: (%s, %s, %s, %s)", provider.ServiceId, provider.AppId, provider.ServiceName, provider.Version))
-	consumer, err := serviceUtil.GetService(ctx, tenant, in.ConsumerId)
+	consumer, err := GetService(ctx, tenant, in.ConsumerId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("pact publish failed, consumerID is %s: consumer not exist.", in.ConsumerId))
@@ -782,7 +678,6 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 
 	PactLogger.Info(fmt.Sprintf("consumer service found: (%s, %s, %s, %s)", consumer.ServiceId, consumer.AppId, consumer.ServiceName, consumer.Version))
 	// Get or create provider participant
-	providerParticipantKey := GenerateBrokerParticipantKey(tenant, provider.AppId, provider.ServiceName)
 	providerParticipant, err := GetParticipant(ctx, tenant, provider.AppId, provider.ServiceName)
 	if err != nil {
 		PactLogger.Error(fmt.Sprintf("pact publish failed, provider[%s] participant cannot be searched.", in.ProviderId), nil)
@@ -791,21 +686,20 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 		}, err
 	}
 	if providerParticipant == nil {
-		id, err := GetData(ctx, GetBrokerLatestParticipantIDKey())
+		id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerParticipant)
 		if err != nil {
 			return &brokerpb.PublishPactResponse{
 				Response: pb.CreateResponse(pb.ErrInternal, "get data error."),
 			}, err
 		}
-		providerParticipant = &brokerpb.Participant{Id: int32(id) + 1, AppId: provider.AppId, ServiceName: provider.ServiceName}
-		response, err := CreateParticipant(ctx, providerParticipantKey, *providerParticipant)
+		providerParticipant = &brokerpb.Participant{Id: id + 1, AppId: provider.AppId, ServiceName: provider.ServiceName}
+		response, err := CreateParticipant(ctx, tenant, providerParticipant)
 		if err != nil {
 			return response, err
 		}
 	}
 	PactLogger.Infof("Provider participant found: (%d, %s, %s)", providerParticipant.Id, providerParticipant.AppId, providerParticipant.ServiceName)
 	// Get or create consumer participant
-	consumerParticipantKey := GenerateBrokerParticipantKey(tenant, consumer.AppId, consumer.ServiceName)
 	consumerParticipant, err := GetParticipant(ctx, tenant, consumer.AppId, consumer.ServiceName)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, consumer participant cannot be searched.", in.ConsumerId)
@@ -814,21 +708,20 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 		}, err
 	}
 	if consumerParticipant == nil {
-		id, err := GetData(ctx, GetBrokerLatestParticipantIDKey())
+		id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerParticipant)
 		if err != nil {
 			return &brokerpb.PublishPactResponse{
 				Response: pb.CreateResponse(pb.ErrInternal, "get data error."),
 			}, err
 		}
-		consumerParticipant = &brokerpb.Participant{Id: int32(id) + 1, AppId: consumer.AppId, ServiceName: consumer.ServiceName}
-		response, err := CreateParticipant(ctx, consumerParticipantKey, *consumerParticipant)
+		consumerParticipant = &brokerpb.Participant{Id: id + 1, AppId: consumer.AppId, ServiceName: consumer.ServiceName}
+		response, err := CreateParticipant(ctx, tenant, consumerParticipant)
 		if err != nil {
 			return response, err
 		}
 	}
 	PactLogger.Infof("Consumer participant found: (%d, %s, %s)", consumerParticipant.Id, consumerParticipant.AppId, consumerParticipant.ServiceName)
 	// Get or create version
-	versionKey := GenerateBrokerVersionKey(tenant, in.Version, consumerParticipant.Id)
 	version, err := GetVersion(ctx, tenant, in.Version, consumerParticipant.Id)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, version cannot be searched.")
@@ -840,14 +733,14 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 		order := GetLastestVersionNumberForParticipant(ctx, tenant, consumerParticipant.Id)
 		PactLogger.Infof("Old version order: %d", order)
 		order++
-		id, err := GetData(ctx, GetBrokerLatestVersionIDKey())
+		id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerVersion)
 		if err != nil {
 			return &brokerpb.PublishPactResponse{
 				Response: pb.CreateResponse(pb.ErrInternal, "get data error."),
 			}, err
 		}
-		version = &brokerpb.Version{Id: int32(id) + 1, Number: in.Version, ParticipantId: consumerParticipant.Id, Order: order}
-		response, err := CreateVersion(ctx, versionKey, *version)
+		version = &brokerpb.Version{Id: id + 1, Number: in.Version, ParticipantId: consumerParticipant.Id, Order: order}
+		response, err := CreateVersion(ctx, tenant, version)
 		if err != nil {
 			return response, err
 		}
@@ -856,7 +749,6 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 	// Get or create pact
 	sha1 := sha1.Sum(in.Pact)
 	var sha []byte = sha1[:]
-	pactKey := GenerateBrokerPactKey(tenant, consumerParticipant.Id, providerParticipant.Id, sha)
 	pact, err := GetPact(ctx, tenant, consumerParticipant.Id, providerParticipant.Id, sha)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, pact cannot be searched.")
@@ -864,23 +756,23 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 			Response: pb.CreateResponse(pb.ErrInvalidParams, "pact cannot be searched."),
 		}, err
 	}
+	contentChanged := pact == nil
 	if pact == nil {
-		id, err := GetData(ctx, GetBrokerLatestPactIDKey())
+		id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerPact)
 		if err != nil {
 			return &brokerpb.PublishPactResponse{
 				Response: pb.CreateResponse(pb.ErrInternal, "get data error."),
 			}, err
 		}
-		pact = &brokerpb.Pact{Id: int32(id) + 1, ConsumerParticipantId: consumerParticipant.Id,
+		pact = &brokerpb.Pact{Id: id + 1, ConsumerParticipantId: consumerParticipant.Id,
 			ProviderParticipantId: providerParticipant.Id, Sha: sha, Content: in.Pact}
-		response, err := CreatePact(ctx, pactKey, *pact)
+		response, err := CreatePact(ctx, tenant, pact)
 		if err != nil {
 			return response, err
 		}
 	}
 	PactLogger.Infof("Pact found/created: (%d, %d, %d, %s)", pact.Id, pact.ConsumerParticipantId, pact.ProviderParticipantId, pact.Sha)
 	// Get or create pact version
-	pactVersionKey := GenerateBrokerPactVersionKey(tenant, version.Id, pact.Id)
 	pactVersion, err := GetPactVersion(ctx, tenant, version.Id, pact.Id)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, pact version cannot be searched.")
@@ -889,20 +781,30 @@ func (*Service) PublishPact(ctx context.Context, in *brokerpb.PublishPactRequest
 		}, err
 	}
 	if pactVersion == nil {
-		id, err := GetData(ctx, GetBrokerLatestPactVersionIDKey())
+		id, err := datasource.Instance().GetLatestBrokerID(ctx, datasource.BrokerPactVersion)
 		if err != nil {
 			return &brokerpb.PublishPactResponse{
 				Response: pb.CreateResponse(pb.ErrInternal, "get data error."),
 			}, err
 		}
-		pactVersion = &brokerpb.PactVersion{Id: int32(id) + 1, VersionId: version.Id, PactId: pact.Id, ProviderParticipantId: providerParticipant.Id}
-		response, err := CreatePactVersion(ctx, pactVersionKey, *pactVersion)
+		pactVersion = &brokerpb.PactVersion{Id: id + 1, VersionId: version.Id, PactId: pact.Id, ProviderParticipantId: providerParticipant.Id}
+		response, err := CreatePactVersion(ctx, tenant, pactVersion)
 		if err != nil {
 			return response, err
 		}
 	}
 	PactLogger.Infof("PactVersion found/create: (%d, %d, %d, %d)", pactVersion.Id, pactVersion.VersionId, pactVersion.PactId, pactVersion.ProviderParticipantId)
 	PactLogger.Infof("Pact published successfully ...")
+	if contentChanged {
+		fireWebhooks(&webhookEvent{
+			tenant:          tenant,
+			name:            brokerpb.EventContractContentChanged,
+			consumer:        consumerParticipant,
+			consumerVersion: version.Number,
+			provider:        providerParticipant,
+			pactID:          pact.Id,
+		})
+	}
 	return &brokerpb.PublishPactResponse{
 		Response: pb.CreateResponse(pb.ResponseSuccess, "Pact published successfully."),
 	}, nil
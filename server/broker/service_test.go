@@ -19,9 +19,13 @@ package broker_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
 
+	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/pkg/util"
-	"github.com/apache/servicecomb-service-center/server/broker"
 	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
 	"github.com/apache/servicecomb-service-center/server/core"
 	pb "github.com/go-chassis/cari/discovery"
@@ -189,13 +193,13 @@ var _ = Describe("Controller", func() {
 			It("PublishVerificationResults", func() {
 				fmt.Println("UT===========PublishVerificationResults")
 
-				id, err := broker.GetData(context.Background(), broker.GetBrokerLatestPactIDKey())
+				id, err := datasource.Instance().GetLatestBrokerID(context.Background(), datasource.BrokerPact)
 				Expect(err).To(BeNil())
 				respResults, err := brokerResource.PublishVerificationResults(getContext(),
 					&brokerpb.PublishVerificationRequest{
 						ProviderId:                 providerServiceId,
 						ConsumerId:                 consumerServiceId,
-						PactId:                     int32(id),
+						PactId:                     id,
 						ProviderApplicationVersion: TEST_BROKER_PROVIDER_VERSION,
 					})
 
@@ -231,6 +235,181 @@ var _ = Describe("Controller", func() {
 				Expect(respProviderPact).NotTo(BeNil())
 				Expect(respProviderPact.Response.GetCode()).To(Equal(pb.ResponseSuccess))
 			})
+
+			It("CreateTag", func() {
+				fmt.Println("UT===========CreateTag")
+
+				respTag, _ := brokerResource.CreateTag(getContext(),
+					&brokerpb.PacticipantVersionRequest{
+						Pacticipant: consumerServiceId,
+						Version:     TEST_BROKER_CONSUMER_VERSION,
+						Tag:         "prod",
+					})
+
+				Expect(respTag).NotTo(BeNil())
+				Expect(respTag.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(respTag.Tags).To(ContainElement("prod"))
+			})
+
+			It("RecordDeployment", func() {
+				fmt.Println("UT===========RecordDeployment")
+
+				respDeployment, _ := brokerResource.RecordDeployment(getContext(),
+					&brokerpb.PacticipantVersionRequest{
+						Pacticipant: providerServiceId,
+						Version:     TEST_BROKER_PROVIDER_VERSION,
+						Environment: "production",
+					})
+
+				Expect(respDeployment).NotTo(BeNil())
+				Expect(respDeployment.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(respDeployment.Environments).To(ContainElement("production"))
+			})
+
+			It("Matrix", func() {
+				fmt.Println("UT===========Matrix")
+
+				respMatrix, _ := brokerResource.Matrix(getContext(), &brokerpb.MatrixRequest{
+					Selectors: []*brokerpb.MatrixSelector{
+						{Pacticipant: consumerServiceId, Version: TEST_BROKER_CONSUMER_VERSION},
+						{Pacticipant: providerServiceId, Environment: "production"},
+					},
+				})
+
+				Expect(respMatrix).NotTo(BeNil())
+				Expect(respMatrix.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(respMatrix.Matrix)).To(Equal(1))
+				Expect(respMatrix.Matrix[0].Provider.Version).To(Equal(TEST_BROKER_PROVIDER_VERSION))
+
+				respMatrix, _ = brokerResource.Matrix(getContext(), &brokerpb.MatrixRequest{})
+				Expect(respMatrix.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+			})
+
+			It("CanIDeploy", func() {
+				fmt.Println("UT===========CanIDeploy")
+
+				respMatrix, _ := brokerResource.Matrix(getContext(), &brokerpb.MatrixRequest{
+					Selectors: []*brokerpb.MatrixSelector{
+						{Pacticipant: consumerServiceId, Version: TEST_BROKER_CONSUMER_VERSION},
+					},
+					Environment: "production",
+				})
+
+				Expect(respMatrix).NotTo(BeNil())
+				Expect(respMatrix.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(respMatrix.Summary.Deployable).To(BeFalse())
+				Expect(respMatrix.Summary.Failed).To(Equal(1))
+
+				respMatrix, _ = brokerResource.Matrix(getContext(), &brokerpb.MatrixRequest{
+					Selectors: []*brokerpb.MatrixSelector{
+						{Pacticipant: consumerServiceId, Version: TEST_BROKER_CONSUMER_VERSION},
+					},
+					Environment: "test",
+				})
+				Expect(respMatrix.Summary.Deployable).To(BeFalse())
+				Expect(respMatrix.Summary.Unknown).To(Equal(1))
+			})
+
+			It("Webhook", func() {
+				fmt.Println("UT===========Webhook")
+
+				received := make(chan string, 1)
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, _ := ioutil.ReadAll(r.Body)
+					received <- string(body)
+				}))
+				defer server.Close()
+
+				respWebhook, _ := brokerResource.CreateWebhook(getContext(), &brokerpb.CreateWebhookRequest{
+					Provider: providerServiceId,
+					Events:   []string{brokerpb.EventProviderVerificationPublished},
+					Request: &brokerpb.WebhookRequest{
+						Url:  server.URL,
+						Body: "${pactbroker.consumerName}:${pactbroker.verificationResultSuccess}",
+					},
+				})
+				Expect(respWebhook).NotTo(BeNil())
+				Expect(respWebhook.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				webhookID := respWebhook.Webhook.Id
+
+				respWebhook, _ = brokerResource.CreateWebhook(getContext(), &brokerpb.CreateWebhookRequest{
+					Events:  []string{"unknown"},
+					Request: &brokerpb.WebhookRequest{Url: server.URL},
+				})
+				Expect(respWebhook.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+
+				respWebhooks, _ := brokerResource.ListWebhooks(getContext())
+				Expect(respWebhooks.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(respWebhooks.Webhooks)).To(Equal(1))
+
+				id, err := datasource.Instance().GetLatestBrokerID(context.Background(), datasource.BrokerPact)
+				Expect(err).To(BeNil())
+				respResults, _ := brokerResource.PublishVerificationResults(getContext(),
+					&brokerpb.PublishVerificationRequest{
+						ProviderId:                 providerServiceId,
+						ConsumerId:                 consumerServiceId,
+						PactId:                     id,
+						Success:                    true,
+						ProviderApplicationVersion: TEST_BROKER_PROVIDER_VERSION,
+					})
+				Expect(respResults.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Eventually(received, 5*time.Second).Should(Receive(Equal(TEST_BROKER_CONSUMER_NAME + ":true")))
+
+				Eventually(func() int {
+					respExecutions, _ := brokerResource.ListWebhookExecutions(getContext(), webhookID)
+					return len(respExecutions.Executions)
+				}, 5*time.Second).Should(Equal(1))
+
+				respDelete, _ := brokerResource.DeleteWebhook(getContext(), webhookID)
+				Expect(respDelete.GetCode()).To(Equal(pb.ResponseSuccess))
+			})
+
+			It("CanIDeploy-republishedPact", func() {
+				fmt.Println("UT===========CanIDeploy, republished pact")
+
+				respPublishPact, _ := brokerResource.PublishPact(getContext(), &brokerpb.PublishPactRequest{
+					ProviderId: providerServiceId,
+					ConsumerId: consumerServiceId,
+					Version:    TEST_BROKER_CONSUMER_VERSION,
+					Pact:       []byte("hello again"),
+				})
+				Expect(respPublishPact.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+
+				// the republished pact is not verified yet
+				respMatrix, _ := brokerResource.Matrix(getContext(), &brokerpb.MatrixRequest{
+					Selectors: []*brokerpb.MatrixSelector{
+						{Pacticipant: consumerServiceId, Version: TEST_BROKER_CONSUMER_VERSION},
+					},
+					Environment: "production",
+				})
+				Expect(respMatrix.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(respMatrix.Matrix)).To(Equal(1))
+				Expect(respMatrix.Summary.Deployable).To(BeFalse())
+				Expect(respMatrix.Summary.Unknown).To(Equal(1))
+
+				id, err := datasource.Instance().GetLatestBrokerID(context.Background(), datasource.BrokerPact)
+				Expect(err).To(BeNil())
+				respResults, _ := brokerResource.PublishVerificationResults(getContext(),
+					&brokerpb.PublishVerificationRequest{
+						ProviderId:                 providerServiceId,
+						ConsumerId:                 consumerServiceId,
+						PactId:                     id,
+						Success:                    true,
+						ProviderApplicationVersion: TEST_BROKER_PROVIDER_VERSION,
+					})
+				Expect(respResults.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+
+				// the older pact is ignored once the latest one is verified
+				respMatrix, _ = brokerResource.Matrix(getContext(), &brokerpb.MatrixRequest{
+					Selectors: []*brokerpb.MatrixSelector{
+						{Pacticipant: consumerServiceId, Version: TEST_BROKER_CONSUMER_VERSION},
+					},
+					Environment: "production",
+				})
+				Expect(len(respMatrix.Matrix)).To(Equal(1))
+				Expect(respMatrix.Summary.Deployable).To(BeTrue())
+				Expect(respMatrix.Summary.Success).To(Equal(1))
+			})
 		})
 	})
 })
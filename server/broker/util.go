@@ -19,17 +19,13 @@ package broker
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"net/url"
-	"strconv"
 	"strings"
 
 	"github.com/apache/servicecomb-service-center/datasource"
-	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
-	serviceUtil "github.com/apache/servicecomb-service-center/datasource/etcd/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
@@ -146,37 +142,22 @@ func GetBrokerHomeResponse(host string, scheme string) *brokerpb.BrokerHomeRespo
 
 func GetParticipant(ctx context.Context, domain string, appID string,
 	serviceName string) (*brokerpb.Participant, error) {
-	key := GenerateBrokerParticipantKey(domain, appID, serviceName)
-	participants, err := Store().Participant().Search(ctx, client.WithStrKey(key))
+	participant, err := datasource.Instance().GetParticipant(ctx, domain, appID, serviceName)
 	if err != nil {
 		return nil, err
 	}
-	if len(participants.Kvs) == 0 {
+	if participant == nil {
 		PactLogger.Info("GetParticipant found no participant")
 		return nil, nil
 	}
-	participant := &brokerpb.Participant{}
-	err = json.Unmarshal(participants.Kvs[0].Value.([]byte), participant)
-	if err != nil {
-		return nil, err
-	}
 	PactLogger.Infof("GetParticipant: (%d, %s, %s)", participant.Id, participant.AppId, participant.ServiceName)
 	return participant, nil
 }
 
 func GetVersion(ctx context.Context, domain string, number string,
 	participantID int32) (*brokerpb.Version, error) {
-	key := GenerateBrokerVersionKey(domain, number, participantID)
-	versions, err := Store().Version().Search(ctx, client.WithStrKey(key))
-	if err != nil {
-		return nil, err
-	}
-	if len(versions.Kvs) == 0 {
-		return nil, nil
-	}
-	version := &brokerpb.Version{}
-	err = json.Unmarshal(versions.Kvs[0].Value.([]byte), version)
-	if err != nil {
+	version, err := datasource.Instance().GetVersion(ctx, domain, number, participantID)
+	if err != nil || version == nil {
 		return nil, err
 	}
 	PactLogger.Infof("GetVersion: (%d, %s, %d, %d)", version.Id, version.Number, version.ParticipantId, version.Order)
@@ -184,17 +165,8 @@ func GetVersion(ctx context.Context, domain string, number string,
 }
 
 func GetPact(ctx context.Context, domain string, consumerParticipantID int32, producerParticipantID int32, sha []byte) (*brokerpb.Pact, error) {
-	key := GenerateBrokerPactKey(domain, consumerParticipantID, producerParticipantID, sha)
-	versions, err := Store().Pact().Search(ctx, client.WithStrKey(key))
-	if err != nil {
-		return nil, err
-	}
-	if len(versions.Kvs) == 0 {
-		return nil, nil
-	}
-	pact := &brokerpb.Pact{}
-	err = json.Unmarshal(versions.Kvs[0].Value.([]byte), pact)
-	if err != nil {
+	pact, err := datasource.Instance().GetPact(ctx, domain, consumerParticipantID, producerParticipantID, sha)
+	if err != nil || pact == nil {
 		return nil, err
 	}
 	PactLogger.Infof("GetPact: (%d, %d, %d, %s, %s)", pact.Id, pact.ConsumerParticipantId, pact.ProviderParticipantId, string(pact.Sha), string(pact.Content))
@@ -203,222 +175,114 @@ func GetPact(ctx context.Context, domain string, consumerParticipantID int32, pr
 
 func GetPactVersion(ctx context.Context, domain string, versionID int32,
 	pactID int32) (*brokerpb.PactVersion, error) {
-	key := GenerateBrokerPactVersionKey(domain, versionID, pactID)
-	versions, err := Store().PactVersion().Search(ctx, client.WithStrKey(key))
-	if err != nil {
-		return nil, err
-	}
-	if len(versions.Kvs) == 0 {
-		return nil, nil
-	}
-	pactVersion := &brokerpb.PactVersion{}
-	err = json.Unmarshal(versions.Kvs[0].Value.([]byte), pactVersion)
-	if err != nil {
+	pactVersion, err := datasource.Instance().GetPactVersion(ctx, domain, versionID, pactID)
+	if err != nil || pactVersion == nil {
 		return nil, err
 	}
 	PactLogger.Infof("GetPactVersion: (%d, %d, %d, %d)", pactVersion.Id, pactVersion.VersionId, pactVersion.PactId, pactVersion.ProviderParticipantId)
 	return pactVersion, nil
 }
 
-func GetData(ctx context.Context, key string) (int, error) {
-	values, err := Store().PactLatest().Search(ctx, client.WithStrKey(key))
-	if err != nil {
-		return -1, err
-	}
-	if len(values.Kvs) == 0 {
-		return -1, nil
-	}
-	id, err := strconv.Atoi(string(values.Kvs[0].Value.([]byte)))
-	if err != nil {
-		return -1, err
-	}
-	return id, nil
-}
-
-func CreateParticipant(ctx context.Context, participantKey string, participant brokerpb.Participant) (*brokerpb.PublishPactResponse, error) {
-	data, err := json.Marshal(participant)
+func CreateParticipant(ctx context.Context, tenant string, participant *brokerpb.Participant) (*brokerpb.PublishPactResponse, error) {
+	err := datasource.Instance().CreateParticipant(ctx, tenant, participant)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, participant cannot be created.")
 		return &brokerpb.PublishPactResponse{
 			Response: pb.CreateResponse(pb.ErrInternal, "participant cannot be created."),
 		}, err
 	}
-
-	_, err = client.Instance().Do(ctx, client.PUT,
-		client.WithStrKey(participantKey),
-		client.WithValue(data))
-
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, participant cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "participant cannot be created."),
-		}, err
-	}
-
-	k := GetBrokerLatestParticipantIDKey()
-	v := strconv.Itoa(int(participant.Id))
-	PactLogger.Infof("Inserting (%s, %s)", k, v)
-	err = client.Put(ctx, k, v)
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, participant cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "participant cannot be created."),
-		}, err
-	}
-	PactLogger.Infof("Participant created for key: %s", participantKey)
+	PactLogger.Infof("Participant created: (%d, %s, %s)", participant.Id, participant.AppId, participant.ServiceName)
 	return nil, nil
 }
 
-func CreateVersion(ctx context.Context, versionKey string,
-	version brokerpb.Version) (*brokerpb.PublishPactResponse, error) {
-	data, err := json.Marshal(version)
+func CreateVersion(ctx context.Context, tenant string,
+	version *brokerpb.Version) (*brokerpb.PublishPactResponse, error) {
+	err := datasource.Instance().CreateVersion(ctx, tenant, version)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, version cannot be created.")
 		return &brokerpb.PublishPactResponse{
 			Response: pb.CreateResponse(pb.ErrInternal, "version cannot be created."),
 		}, err
 	}
-
-	_, err = client.Instance().Do(ctx, client.PUT,
-		client.WithStrKey(versionKey),
-		client.WithValue(data))
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, version cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "version cannot be created."),
-		}, err
-	}
-	k := GetBrokerLatestVersionIDKey()
-	v := strconv.Itoa(int(version.Id))
-	PactLogger.Infof("Inserting (%s, %s)", k, v)
-	err = client.Put(ctx, k, v)
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, version cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "version cannot be created."),
-		}, err
-	}
-	PactLogger.Infof("Version created for key: %s", versionKey)
+	PactLogger.Infof("Version created: (%d, %s, %d)", version.Id, version.Number, version.ParticipantId)
 	return nil, nil
 }
 
 func CreatePact(ctx context.Context,
-	pactKey string, pact brokerpb.Pact) (*brokerpb.PublishPactResponse, error) {
-	data, err := json.Marshal(pact)
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, pact cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "pact cannot be created."),
-		}, err
-	}
-
-	_, err = client.Instance().Do(ctx,
-		client.PUT,
-		client.WithStrKey(pactKey),
-		client.WithValue(data))
-
+	tenant string, pact *brokerpb.Pact) (*brokerpb.PublishPactResponse, error) {
+	err := datasource.Instance().CreatePact(ctx, tenant, pact)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, pact cannot be created.")
 		return &brokerpb.PublishPactResponse{
 			Response: pb.CreateResponse(pb.ErrInternal, "pact cannot be created."),
 		}, err
 	}
-	k := GetBrokerLatestPactIDKey()
-	v := strconv.Itoa(int(pact.Id))
-	PactLogger.Infof("Inserting (%s, %s)", k, v)
-	err = client.Put(ctx, k, v)
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, pact cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "pact cannot be created."),
-		}, err
-	}
-	PactLogger.Infof("Pact created for key: %s", pactKey)
+	PactLogger.Infof("Pact created: (%d, %d, %d)", pact.Id, pact.ConsumerParticipantId, pact.ProviderParticipantId)
 	return nil, nil
 }
 
-func CreatePactVersion(ctx context.Context, pactVersionKey string, pactVersion brokerpb.PactVersion) (*brokerpb.PublishPactResponse, error) {
-	data, err := json.Marshal(pactVersion)
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, pact version cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "pact version cannot be created."),
-		}, err
-	}
-
-	_, err = client.Instance().Do(ctx,
-		client.PUT, client.WithValue(data), client.WithStrKey(pactVersionKey))
+func CreatePactVersion(ctx context.Context, tenant string, pactVersion *brokerpb.PactVersion) (*brokerpb.PublishPactResponse, error) {
+	err := datasource.Instance().CreatePactVersion(ctx, tenant, pactVersion)
 	if err != nil {
 		PactLogger.Errorf(nil, "pact publish failed, pact version cannot be created.")
 		return &brokerpb.PublishPactResponse{
 			Response: pb.CreateResponse(pb.ErrInternal, "pact version cannot be created."),
 		}, err
 	}
-	k := GetBrokerLatestPactVersionIDKey()
-	v := strconv.Itoa(int(pactVersion.Id))
-	PactLogger.Infof("Inserting (%s, %s)", k, v)
-	err = client.Put(ctx, k, v)
-	if err != nil {
-		PactLogger.Errorf(nil, "pact publish failed, pact version cannot be created.")
-		return &brokerpb.PublishPactResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "pact version cannot be created."),
-		}, err
-	}
-	PactLogger.Infof("Pact version created for key: %s", pactVersionKey)
+	PactLogger.Infof("Pact version created: (%d, %d, %d)", pactVersion.Id, pactVersion.VersionId, pactVersion.PactId)
 	return nil, nil
 }
 
 func CreateVerification(ctx context.Context,
-	verificationKey string, verification brokerpb.Verification) (*brokerpb.PublishVerificationResponse, error) {
-	data, err := json.Marshal(verification)
+	tenant string, verification *brokerpb.Verification) (*brokerpb.PublishVerificationResponse, error) {
+	err := datasource.Instance().CreateVerification(ctx, tenant, verification)
 	if err != nil {
-		PactLogger.Errorf(nil, "verification result publish failed, verification result marshal error.")
+		PactLogger.Errorf(nil, "verification result publish failed, verification result cannot be created.")
 		return &brokerpb.PublishVerificationResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "verification result marshal error."),
+			Response: pb.CreateResponse(pb.ErrInternal, "verification result cannot be created."),
 		}, err
 	}
+	PactLogger.Infof("Verification result created: (%d, %d, %d)", verification.Id, verification.PactVersionId, verification.Number)
+	return nil, nil
+}
 
-	_, err = client.Instance().Do(ctx, client.PUT,
-		client.WithStrKey(verificationKey),
-		client.WithValue(data))
+// GetService returns the micro-service in the tenant, datasource.ErrNoData if it does not exist
+func GetService(ctx context.Context, tenant string, serviceID string) (*pb.MicroService, error) {
+	resp, err := datasource.Instance().GetService(util.SetDomainProjectString(ctx, tenant),
+		&pb.GetServiceRequest{ServiceId: serviceID})
 	if err != nil {
-		PactLogger.Errorf(nil, "verification result publish failed, verification result cannot be created.")
-		return &brokerpb.PublishVerificationResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "verification result cannot be created."),
-		}, err
+		return nil, err
 	}
-	k := GetBrokerLatestVerificationIDKey()
-	v := strconv.Itoa(int(verification.Id))
-	PactLogger.Infof("Inserting (%s, %s)", k, v)
-	err = client.Put(ctx, k, v)
+	if resp.Response.GetCode() == pb.ErrServiceNotExists {
+		return nil, datasource.ErrNoData
+	}
+	return resp.Service, nil
+}
+
+// GetServiceID returns the id of the micro-service matching the key, empty if it does not exist
+func GetServiceID(ctx context.Context, key *pb.MicroServiceKey) (string, error) {
+	resp, err := datasource.Instance().ExistService(util.SetDomainProjectString(ctx, key.Tenant),
+		&pb.GetExistenceRequest{
+			Type:        "microservice",
+			Environment: key.Environment,
+			AppId:       key.AppId,
+			ServiceName: key.ServiceName,
+			Version:     key.Version,
+		})
 	if err != nil {
-		PactLogger.Errorf(nil, "verification result publish failed, verification result cannot be created.")
-		return &brokerpb.PublishVerificationResponse{
-			Response: pb.CreateResponse(pb.ErrInternal, "verification result cannot be created."),
-		}, err
+		return "", err
 	}
-	PactLogger.Infof("Verification result created for key: %s", verificationKey)
-	return nil, nil
+	return resp.ServiceId, nil
 }
 
 func GetLastestVersionNumberForParticipant(ctx context.Context,
 	tenant string, participantID int32) int32 {
-	key := util.StringJoin([]string{
-		GetBrokerVersionKey(tenant), ""}, "/")
-	versions, err := Store().Version().Search(ctx,
-		client.WithStrKey(key),
-		client.WithPrefix())
-
-	if err != nil || len(versions.Kvs) == 0 {
+	versions, err := datasource.Instance().ListVersions(ctx, tenant)
+	if err != nil || len(versions) == 0 {
 		return -1
 	}
 	order := int32(math.MinInt32)
-	for i := 0; i < len(versions.Kvs); i++ {
-		version := &brokerpb.Version{}
-		err = json.Unmarshal(versions.Kvs[i].Value.([]byte), &version)
-		if err != nil {
-			return -1
-		}
+	for _, version := range versions {
 		if version.ParticipantId != participantID {
 			continue
 		}
@@ -429,6 +293,20 @@ func GetLastestVersionNumberForParticipant(ctx context.Context,
 	return order
 }
 
+func listPactVersionsOfVersion(ctx context.Context, tenant string, versionID int32) ([]*brokerpb.PactVersion, error) {
+	pactVersions, err := datasource.Instance().ListPactVersions(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*brokerpb.PactVersion
+	for _, pactVersion := range pactVersions {
+		if pactVersion.VersionId == versionID {
+			matched = append(matched, pactVersion)
+		}
+	}
+	return matched, nil
+}
+
 func RetrieveProviderConsumerPact(ctx context.Context,
 	in *brokerpb.GetProviderConsumerVersionPactRequest) (*brokerpb.GetProviderConsumerVersionPactResponse, int32, error) {
 	if in == nil || len(in.ProviderId) == 0 || len(in.ConsumerId) == 0 || len(in.Version) == 0 {
@@ -439,7 +317,7 @@ func RetrieveProviderConsumerPact(ctx context.Context,
 	}
 	tenant := GetDefaultTenantProject()
 	// Get provider microservice
-	provider, err := serviceUtil.GetService(ctx, tenant, in.ProviderId)
+	provider, err := GetService(ctx, tenant, in.ProviderId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("pact retrieve failed, providerId is %s: provider not exist.", in.ProviderId))
@@ -453,7 +331,7 @@ func RetrieveProviderConsumerPact(ctx context.Context,
 		}, -1, err
 	}
 	// Get consumer microservice
-	consumer, err := serviceUtil.GetService(ctx, tenant, in.ConsumerId)
+	consumer, err := GetService(ctx, tenant, in.ConsumerId)
 	if err != nil {
 		if errors.Is(err, datasource.ErrNoData) {
 			PactLogger.Debug(fmt.Sprintf("pact retrieve failed, consumerId is %s: consumer not exist.", in.ConsumerId))
@@ -493,34 +371,15 @@ func RetrieveProviderConsumerPact(ctx context.Context,
 			Response: pb.CreateResponse(pb.ErrInternal, "version cannot be searched."),
 		}, -1, err
 	}
-	// Get all pactversions and filter using the provider participant id
-	pactVersionKey := util.StringJoin([]string{
-		GetBrokerPactVersionKey(tenant),
-		strconv.Itoa(int(version.Id))},
-		"/")
-	pactVersions, err := Store().PactVersion().Search(ctx,
-		client.WithPrefix(),
-		client.WithStrKey(pactVersionKey))
-
+	// Get all pactversions of the version
+	pactVersions, err := listPactVersionsOfVersion(ctx, tenant, version.Id)
 	if err != nil {
 		return nil, -1, err
 	}
-	if len(pactVersions.Kvs) == 0 {
-		PactLogger.Info("[RetrieveProviderPact] No pact version found, sorry")
-		return nil, -1, nil
-	}
 	pactIDs := make(map[int32]int32)
-	for i := 0; i < len(pactVersions.Kvs); i++ {
-		pactVersion := &brokerpb.PactVersion{}
-		err = json.Unmarshal(pactVersions.Kvs[i].Value.([]byte), pactVersion)
-		if err != nil {
-			return nil, -1, err
-		}
-		// Obviously true, but checking it anyways
-		if pactVersion.VersionId == version.Id {
-			pactid := pactVersion.PactId
-			pactIDs[pactid] = pactid
-		}
+	for _, pactVersion := range pactVersions {
+		pactid := pactVersion.PactId
+		pactIDs[pactid] = pactid
 	}
 	if len(pactIDs) == 0 {
 		PactLogger.Errorf(nil, "pact retrieve failed, pact cannot be found.")
@@ -528,27 +387,14 @@ func RetrieveProviderConsumerPact(ctx context.Context,
 			Response: pb.CreateResponse(pb.ErrInternal, "pact cannot be found."),
 		}, -1, err
 	}
-	pactKey := util.StringJoin([]string{
-		GetBrokerPactKey(tenant),
-		strconv.Itoa(int(consumerParticipant.Id)),
-		strconv.Itoa(int(providerParticipant.Id))},
-		"/")
-	pacts, err := Store().Pact().Search(ctx,
-		client.WithStrKey(pactKey),
-		client.WithPrefix())
-
+	pacts, err := datasource.Instance().ListPacts(ctx, tenant)
 	if err != nil {
 		return nil, -1, err
 	}
-	if len(pacts.Kvs) == 0 {
-		PactLogger.Info("[RetrieveProviderPact] No pact version found, sorry")
-		return nil, -1, nil
-	}
-	for i := 0; i < len(pacts.Kvs); i++ {
-		pactObj := &brokerpb.Pact{}
-		err = json.Unmarshal(pacts.Kvs[i].Value.([]byte), pactObj)
-		if err != nil {
-			return nil, -1, err
+	for _, pactObj := range pacts {
+		if pactObj.ConsumerParticipantId != consumerParticipant.Id ||
+			pactObj.ProviderParticipantId != providerParticipant.Id {
+			continue
 		}
 		if _, ok := pactIDs[pactObj.Id]; ok {
 			//PactLogger.Infof("pact retrieve succeeded, found pact: %s", string(pactObj.Content))
@@ -566,11 +412,7 @@ func RetrieveProviderConsumerPact(ctx context.Context,
 
 func DeletePactData(ctx context.Context,
 	in *brokerpb.BaseBrokerRequest) (*pb.Response, error) {
-	//tenant := util.ParseTenantProject(ctx)
-	allPactKey := GetBrokerRootKey() //GetBrokerVerificationKey("default") //util.StringJoin([]string{ apt.GetRootKey(), apt.REGISTRY_PACT_ROOT_KEY }, "/")
-
-	_, err := client.Instance().Do(ctx,
-		client.DEL, client.WithStrKey(allPactKey), client.WithPrefix())
+	err := datasource.Instance().DeleteBrokerData(ctx)
 	if err != nil {
 		return pb.CreateResponse(pb.ErrInternal, "error deleting pacts."), err
 	}
@@ -0,0 +1,319 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/queue"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	hook "github.com/apache/servicecomb-service-center/pkg/webhook"
+	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
+)
+
+// webhookQueue delivers the triggered webhooks asynchronously
+var webhookQueue = hook.NewQueue(func(sender *hook.Sender) queue.Worker {
+	return &webhookWorker{sender: sender}
+})
+
+// webhookEvent is the pact broker event triggering the webhooks
+type webhookEvent struct {
+	tenant          string
+	name            string
+	consumer        *brokerpb.Participant
+	consumerVersion string
+	provider        *brokerpb.Participant
+	providerVersion string
+	pactID          int32
+	verification    *brokerpb.Verification
+}
+
+// replacer returns the replacer of the placeholders in the webhook request
+func (e *webhookEvent) replacer() *strings.Replacer {
+	success := ""
+	if e.verification != nil {
+		success = strconv.FormatBool(e.verification.Success)
+	}
+	return strings.NewReplacer(
+		"${pactbroker.event}", e.name,
+		"${pactbroker.consumerName}", e.consumer.ServiceName,
+		"${pactbroker.consumerAppId}", e.consumer.AppId,
+		"${pactbroker.consumerVersionNumber}", e.consumerVersion,
+		"${pactbroker.providerName}", e.provider.ServiceName,
+		"${pactbroker.providerAppId}", e.provider.AppId,
+		"${pactbroker.providerVersionNumber}", e.providerVersion,
+		"${pactbroker.pactId}", strconv.Itoa(int(e.pactID)),
+		"${pactbroker.verificationResultSuccess}", success,
+	)
+}
+
+func (e *webhookEvent) match(webhook *brokerpb.Webhook) bool {
+	if webhook.ProviderParticipantId != 0 && webhook.ProviderParticipantId != e.provider.Id {
+		return false
+	}
+	if webhook.ConsumerParticipantId != 0 && webhook.ConsumerParticipantId != e.consumer.Id {
+		return false
+	}
+	return util.SliceHave(webhook.Events, e.name)
+}
+
+type webhookWorker struct {
+	sender *hook.Sender
+}
+
+// fireWebhooks triggers the webhooks registered on the event without blocking the caller
+func fireWebhooks(event *webhookEvent) {
+	webhookQueue.Do(context.Background(), queue.Task{Payload: event, Async: true})
+}
+
+func (w *webhookWorker) Handle(ctx context.Context, obj interface{}) {
+	event := obj.(*webhookEvent)
+	webhooks, err := datasource.Instance().ListWebhooks(ctx, event.tenant)
+	if err != nil {
+		PactLogger.Errorf(err, "webhooks of event[%s] cannot be searched.", event.name)
+		return
+	}
+	for _, webhook := range webhooks {
+		if !event.match(webhook) {
+			continue
+		}
+		execution := w.execute(ctx, webhook, event)
+		if err := saveWebhookExecution(ctx, event.tenant, execution); err != nil {
+			PactLogger.Errorf(err, "webhook[%s] execution cannot be saved.", webhook.Id)
+		}
+	}
+}
+
+// execute sends the webhook request and records the result in the execution
+func (w *webhookWorker) execute(ctx context.Context, webhook *brokerpb.Webhook, event *webhookEvent) *brokerpb.WebhookExecution {
+	replacer := event.replacer()
+	req := &hook.Request{
+		Method:  webhook.Request.Method,
+		URL:     replacer.Replace(webhook.Request.Url),
+		Headers: make(http.Header),
+		Body:    []byte(replacer.Replace(webhook.Request.Body)),
+	}
+	for key, value := range webhook.Request.Headers {
+		req.Headers.Set(key, replacer.Replace(value))
+	}
+
+	result := w.sender.Send(ctx, req)
+	execution := &brokerpb.WebhookExecution{
+		Id:         util.GenerateUUID(),
+		WebhookId:  webhook.Id,
+		Event:      event.name,
+		Url:        req.URL,
+		Success:    result.Success,
+		StatusCode: result.StatusCode,
+		Response:   result.Response,
+		Error:      result.Error,
+		Attempts:   result.Attempts,
+		ExecutedAt: result.DoneAt.Format(time.RFC3339),
+	}
+	PactLogger.Infof("webhook[%s] of event[%s] executed: %s, success %t",
+		webhook.Id, event.name, req.URL, execution.Success)
+	return execution
+}
+
+type webhookExecutions []*brokerpb.WebhookExecution
+
+func (e webhookExecutions) Len() int          { return len(e) }
+func (e webhookExecutions) Swap(i, j int)     { e[i], e[j] = e[j], e[i] }
+func (e webhookExecutions) ID(i int) string   { return e[i].Id }
+func (e webhookExecutions) Time(i int) string { return e[i].ExecutedAt }
+
+// saveWebhookExecution saves the execution and removes the oldest ones beyond the limit
+func saveWebhookExecution(ctx context.Context, tenant string, execution *brokerpb.WebhookExecution) error {
+	return hook.Save(ctx, func(ctx context.Context) error {
+		return datasource.Instance().CreateWebhookExecution(ctx, tenant, execution)
+	}, func(ctx context.Context) (hook.Records, error) {
+		executions, err := datasource.Instance().ListWebhookExecutions(ctx, tenant, execution.WebhookId)
+		return webhookExecutions(executions), err
+	}, func(ctx context.Context, id string) error {
+		return datasource.Instance().DeleteWebhookExecution(ctx, tenant, execution.WebhookId, id)
+	})
+}
+
+// listWebhookExecutions returns the executions of the webhook, the latest first
+func listWebhookExecutions(ctx context.Context, tenant, webhookID string) ([]*brokerpb.WebhookExecution, error) {
+	executions, err := datasource.Instance().ListWebhookExecutions(ctx, tenant, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	hook.SortRecords(webhookExecutions(executions))
+	return executions, nil
+}
+
+func (*Service) CreateWebhook(ctx context.Context, in *brokerpb.CreateWebhookRequest) (*brokerpb.WebhookResponse, error) {
+	if message := invalidWebhook(in); len(message) > 0 {
+		PactLogger.Errorf(nil, "webhook create request failed: %s", message)
+		return &brokerpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, message),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	webhook := &brokerpb.Webhook{
+		Id:          util.GenerateUUID(),
+		Description: in.Description,
+		Events:      in.Events,
+		Request:     in.Request,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	}
+	providerID, resp, err := resolveWebhookParticipant(ctx, tenant, in.Provider)
+	if resp != nil {
+		return resp, err
+	}
+	consumerID, resp, err := resolveWebhookParticipant(ctx, tenant, in.Consumer)
+	if resp != nil {
+		return resp, err
+	}
+	webhook.ProviderParticipantId, webhook.ConsumerParticipantId = providerID, consumerID
+	err = datasource.Instance().PutWebhook(ctx, tenant, webhook)
+	if err != nil {
+		PactLogger.Errorf(err, "webhook create failed.")
+		return &brokerpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhook cannot be created."),
+		}, err
+	}
+	PactLogger.Infof("Webhook created: (%s, %v, %s)", webhook.Id, webhook.Events, webhook.Request.Url)
+	return &brokerpb.WebhookResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Webhook created successfully."),
+		Webhook:  webhook,
+	}, nil
+}
+
+func (*Service) ListWebhooks(ctx context.Context) (*brokerpb.ListWebhooksResponse, error) {
+	webhooks, err := datasource.Instance().ListWebhooks(ctx, GetDefaultTenantProject())
+	if err != nil {
+		PactLogger.Errorf(err, "webhooks cannot be searched.")
+		return &brokerpb.ListWebhooksResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhooks cannot be searched."),
+		}, err
+	}
+	return &brokerpb.ListWebhooksResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Webhooks retrieved successfully."),
+		Webhooks: webhooks,
+	}, nil
+}
+
+func (*Service) DeleteWebhook(ctx context.Context, webhookID string) (*pb.Response, error) {
+	if len(webhookID) == 0 {
+		return pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."), nil
+	}
+	tenant := GetDefaultTenantProject()
+	webhook, err := datasource.Instance().GetWebhook(ctx, tenant, webhookID)
+	if err == nil && webhook == nil {
+		return pb.CreateResponse(pb.ErrInvalidParams, "Webhook does not exist."), nil
+	}
+	if err == nil {
+		err = datasource.Instance().DeleteWebhook(ctx, tenant, webhookID)
+	}
+	if err != nil {
+		PactLogger.Errorf(err, "webhook[%s] delete failed.", webhookID)
+		return pb.CreateResponse(pb.ErrInternal, "webhook cannot be deleted."), err
+	}
+	PactLogger.Infof("Webhook deleted: %s", webhookID)
+	return pb.CreateResponse(pb.ResponseSuccess, "Webhook deleted successfully."), nil
+}
+
+// ListWebhookExecutions returns the delivery logs of the webhook, the latest first
+func (*Service) ListWebhookExecutions(ctx context.Context, webhookID string) (*brokerpb.ListWebhookExecutionsResponse, error) {
+	if len(webhookID) == 0 {
+		return &brokerpb.ListWebhookExecutionsResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	executions, err := listWebhookExecutions(ctx, GetDefaultTenantProject(), webhookID)
+	if err != nil {
+		PactLogger.Errorf(err, "webhook[%s] executions cannot be searched.", webhookID)
+		return &brokerpb.ListWebhookExecutionsResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhook executions cannot be searched."),
+		}, err
+	}
+	return &brokerpb.ListWebhookExecutionsResponse{
+		Response:   pb.CreateResponse(pb.ResponseSuccess, "Webhook executions retrieved successfully."),
+		Executions: executions,
+	}, nil
+}
+
+// findParticipant returns the participant of the id, nil if not exist
+func findParticipant(ctx context.Context, tenant string, participantID int32) (*brokerpb.Participant, error) {
+	participants, err := datasource.Instance().ListParticipants(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	for _, participant := range participants {
+		if participant.Id == participantID {
+			return participant, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveWebhookParticipant returns the participant id of the pacticipant, 0 if the pacticipant is empty,
+// the response is not nil if it can not be found
+func resolveWebhookParticipant(ctx context.Context, tenant, pacticipant string) (int32, *brokerpb.WebhookResponse, error) {
+	if len(pacticipant) == 0 {
+		return 0, nil, nil
+	}
+	participant, err := ResolvePacticipant(ctx, tenant, pacticipant, true)
+	if errors.Is(err, ErrPacticipantAmbiguous) {
+		return 0, &brokerpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	if err != nil {
+		PactLogger.Errorf(err, "webhook create failed, pacticipant[%s] cannot be searched.", pacticipant)
+		return 0, &brokerpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "Pacticipant cannot be searched."),
+		}, err
+	}
+	if participant == nil {
+		return 0, &brokerpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, fmt.Sprintf("Pacticipant %s does not exist.", pacticipant)),
+		}, nil
+	}
+	return participant.Id, nil, nil
+}
+
+func invalidWebhook(in *brokerpb.CreateWebhookRequest) string {
+	if in == nil || in.Request == nil || len(in.Events) == 0 {
+		return "Request format invalid."
+	}
+	for _, event := range in.Events {
+		if event != brokerpb.EventContractContentChanged && event != brokerpb.EventProviderVerificationPublished {
+			return fmt.Sprintf("Unknown event %s.", event)
+		}
+	}
+	// the placeholders are allowed in the url, just check the scheme and host
+	u, err := url.Parse(in.Request.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return "Webhook url is invalid."
+	}
+	return ""
+}
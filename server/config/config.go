@@ -20,8 +20,10 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/go-chassis/go-archaius"
@@ -37,6 +39,7 @@ const (
 
 	defaultServiceClearInterval = 12 * time.Hour //0.5 day
 	defaultServiceTTL           = 24 * time.Hour //1 day
+	defaultDependencyTTL        = 30 * 24 * time.Hour
 
 	minServiceClearInterval = 30 * time.Second
 	minServiceTTL           = 30 * time.Second
@@ -50,62 +53,88 @@ var (
 	Server = NewServerConfig()
 	//App is application root config
 	App = &AppConfig{Server: Server}
+
+	// configLock guards App against the reloading in background
+	configLock sync.RWMutex
 )
 
 //GetProfile return active profile
 func GetProfile() *ServerConfig {
-	return App.Server
+	configLock.RLock()
+	defer configLock.RUnlock()
+	profile := *App.Server
+	return &profile
 }
 
 //GetGov return governance configs
 func GetGov() *Gov {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Gov
 }
 
 //GetServer return the http server configs
 func GetServer() ServerConfigDetail {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Server.Config
 }
 
 //GetSSL return the ssl configs
 func GetSSL() ServerConfigDetail {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Server.Config
 }
 
 //GetLog return the log configs
 func GetLog() ServerConfigDetail {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Server.Config
 }
 
 //GetRegistry return the registry configs
 func GetRegistry() ServerConfigDetail {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Server.Config
 }
 
 //GetPlugin return the plugin configs
 func GetPlugin() ServerConfigDetail {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Server.Config
 }
 
 //GetRBAC return the rbac configs
 func GetRBAC() ServerConfigDetail {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return App.Server.Config
 }
 
 //GetMetrics return the metrics configs
 func GetMetrics() Metrics {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return *App.Metrics
 }
 
 func Init() {
 	setCPUs()
 
-	err := archaius.Init(archaius.WithMemorySource(), archaius.WithENVSource(),
-		archaius.WithOptionalFiles([]string{filepath.Join(util.GetAppRoot(), "conf", "app.yaml")}))
+	err := archaius.Init(archaius.WithMemorySource(), archaius.WithENVSource())
 	if err != nil {
 		log.Fatal("can not init archaius", err)
 	}
 
+	err = initAppSource(filepath.Join(util.GetAppRoot(), "conf", "app.yaml"))
+	if err != nil {
+		log.Fatal("can not load app.yaml", err)
+	}
+
 	err = Reload()
 	if err != nil {
 		log.Fatal("reload configs failed", err)
@@ -118,16 +147,47 @@ func Init() {
 
 //Reload reload the all configurations
 func Reload() error {
-	err := archaius.UnmarshalConfig(App)
+	// build the new configs aside and then publish them under the lock,
+	// the getters may be called concurrently
+	cfg := &AppConfig{Server: &ServerConfig{}}
+	err := archaius.UnmarshalConfig(cfg)
 	if err != nil {
 		return err
 	}
-	*Server = loadServerConfig()
+	server := loadServerConfig()
+
+	configLock.Lock()
+	if len(Server.Version) > 0 {
+		// the version is maintained by datasource
+		server.Version = Server.Version
+	}
+	*Server = server
+	App.Gov = cfg.Gov
+	App.Metrics = cfg.Metrics
+	configLock.Unlock()
+
 	body, _ := json.MarshalIndent(archaius.GetConfigs(), "", "  ")
 	log.Info(fmt.Sprintf("finish to reload configurations\n%s", body))
 	return nil
 }
 
+func initAppSource(path string) error {
+	app = nil
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		log.Warnf("%s not exist", path)
+		return nil
+	}
+	s, err := newAppSource(path)
+	if err != nil {
+		return err
+	}
+	if err := archaius.AddSource(s); err != nil {
+		return err
+	}
+	app = s
+	return nil
+}
+
 func loadServerConfig() ServerConfig {
 	serviceClearInterval := GetDuration("registry.service.clearInterval", defaultServiceClearInterval, WithENV("SERVICE_CLEAR_INTERVAL"))
 	if serviceClearInterval < minServiceClearInterval || serviceClearInterval > maxServiceClearInterval {
@@ -137,6 +197,14 @@ func loadServerConfig() ServerConfig {
 	if serviceTTL < minServiceTTL || serviceTTL > maxServiceTTL {
 		serviceTTL = defaultServiceTTL
 	}
+	dependencyClearInterval := GetDuration("registry.dependency.clearInterval", defaultServiceClearInterval, WithENV("DEPENDENCY_CLEAR_INTERVAL"))
+	if dependencyClearInterval < minServiceClearInterval || dependencyClearInterval > maxServiceClearInterval {
+		dependencyClearInterval = defaultServiceClearInterval
+	}
+	dependencyTTL := GetDuration("registry.dependency.clearTTL", defaultDependencyTTL, WithENV("DEPENDENCY_TTL"))
+	if dependencyTTL < minServiceTTL || dependencyTTL > maxServiceTTL {
+		dependencyTTL = defaultDependencyTTL
+	}
 	cacheTTL := GetDuration("registry.cache.ttl", minCacheTTL, WithENV("CACHE_TTL"), WithStandby("cache_ttl"))
 	if cacheTTL < minCacheTTL {
 		cacheTTL = minCacheTTL
@@ -194,8 +262,13 @@ func loadServerConfig() ServerConfig {
 			GlobalVisible:        GetString("registry.service.globalVisible", "", WithENV("CSE_SHARED_SERVICES")),
 			InstanceTTL:          GetInt64("registry.instance.ttl", 0, WithENV("INSTANCE_TTL")),
 
-			SchemaDisable:  GetBool("registry.schema.disable", false, WithENV("SCHEMA_DISABLE")),
-			SchemaEditable: GetBool("registry.schema.editable", false, WithENV("SCHEMA_EDITABLE")),
+			DependencyClearEnabled:  GetBool("registry.dependency.clearEnable", false, WithENV("DEPENDENCY_CLEAR_ENABLED")),
+			DependencyClearInterval: dependencyClearInterval,
+			DependencyTTL:           dependencyTTL,
+
+			SchemaDisable:     GetBool("registry.schema.disable", false, WithENV("SCHEMA_DISABLE")),
+			SchemaEditable:    GetBool("registry.schema.editable", false, WithENV("SCHEMA_EDITABLE")),
+			SchemaHistorySize: GetInt("registry.schema.historySize", 10, WithENV("SCHEMA_HISTORY_SIZE")),
 
 			EnableRBAC: GetBool("rbac.enable", false, WithStandby("rbac_enabled")),
 		},
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+const defaultWatchInterval = 10 * time.Second
+
+// ChangeFunc is called after reloading, changed are the keys under the
+// prefixes the callback registered with
+type ChangeFunc func(changed []string) error
+
+type changeCallback struct {
+	name     string
+	prefixes []string
+	fn       ChangeFunc
+}
+
+func (c *changeCallback) match(keys []string) []string {
+	if len(c.prefixes) == 0 {
+		return keys
+	}
+	var matched []string
+	for _, key := range keys {
+		for _, prefix := range c.prefixes {
+			if strings.HasPrefix(key, prefix) {
+				matched = append(matched, key)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// CallbackResult is the result of one change callback
+type CallbackResult struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// ReloadResult is the result of reloading conf/app.yaml
+type ReloadResult struct {
+	Changed   []string         `json:"changed,omitempty"`
+	Callbacks []CallbackResult `json:"callbacks,omitempty"`
+}
+
+var (
+	app       *appSource
+	reloadMux sync.Mutex
+	callbacks []*changeCallback
+)
+
+// RegisterChangeCallback registers a callback to be notified when the keys
+// with the prefixes changed, an empty prefixes means any key
+func RegisterChangeCallback(name string, fn ChangeFunc, prefixes ...string) {
+	reloadMux.Lock()
+	callbacks = append(callbacks, &changeCallback{name: name, prefixes: prefixes, fn: fn})
+	reloadMux.Unlock()
+}
+
+// Refresh re-reads conf/app.yaml, reloads the configurations and then
+// notifies the change callbacks if any key changed
+func Refresh() (*ReloadResult, error) {
+	reloadMux.Lock()
+	defer reloadMux.Unlock()
+
+	result := &ReloadResult{}
+	if app == nil {
+		return result, nil
+	}
+	changed, err := app.Refresh()
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return result, nil
+	}
+	sort.Strings(changed)
+	result.Changed = changed
+
+	if err := Reload(); err != nil {
+		return nil, err
+	}
+	for _, cb := range callbacks {
+		keys := cb.match(changed)
+		if len(keys) == 0 {
+			continue
+		}
+		r := CallbackResult{Name: cb.name}
+		if err := invoke(cb, keys); err != nil {
+			log.Errorf(err, "config change callback[%s] failed", cb.name)
+			r.Error = err.Error()
+		}
+		result.Callbacks = append(result.Callbacks, r)
+	}
+	log.Infof("reload configurations, changed: %v", changed)
+	return result, nil
+}
+
+func invoke(cb *changeCallback, keys []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Panic(r)
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return cb.fn(keys)
+}
+
+// Watch polls conf/app.yaml and refreshes the configurations when it changed,
+// it is disabled when config.watch.interval is 0
+func Watch() {
+	interval := GetDuration("config.watch.interval", defaultWatchInterval, WithENV("CONFIG_WATCH_INTERVAL"))
+	if interval <= 0 || app == nil {
+		log.Info("config file watching is disabled")
+		return
+	}
+	gopool.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := Refresh(); err != nil {
+					log.Errorf(err, "watch %s failed", app.path)
+				}
+			}
+		}
+	})
+	log.Infof("watching %s every %s", app.path, interval)
+}
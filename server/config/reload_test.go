@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/go-chassis/go-archaius"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefresh(t *testing.T) {
+	defer archaius.Clean()
+	dir := filepath.Join(util.GetAppRoot(), "conf")
+	defer os.Remove(dir)
+	os.Mkdir(dir, 0750)
+	file := filepath.Join(dir, "app.yaml")
+	defer os.Remove(file)
+	err := ioutil.WriteFile(file, []byte("log:\n  level: INFO\nquota:\n  cap:\n    service:\n      limit: 10\n"), 0640)
+	assert.NoError(t, err)
+	config.Init()
+	assert.Equal(t, "INFO", config.GetLog().LogLevel)
+
+	var logKeys, quotaKeys []string
+	config.RegisterChangeCallback("log", func(changed []string) error {
+		logKeys = changed
+		return nil
+	}, "log.")
+	config.RegisterChangeCallback("quota", func(changed []string) error {
+		quotaKeys = changed
+		return errors.New("quota failed")
+	}, "quota.")
+
+	t.Run("not changed, should do nothing", func(t *testing.T) {
+		result, err := config.Refresh()
+		assert.NoError(t, err)
+		assert.Empty(t, result.Changed)
+		assert.Empty(t, result.Callbacks)
+	})
+
+	t.Run("log level changed, should notify the log callback", func(t *testing.T) {
+		err := ioutil.WriteFile(file, []byte("log:\n  level: DEBUG\nquota:\n  cap:\n    service:\n      limit: 10\n"), 0640)
+		assert.NoError(t, err)
+		result, err := config.Refresh()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"log.level"}, result.Changed)
+		assert.Equal(t, []config.CallbackResult{{Name: "log"}}, result.Callbacks)
+		assert.Equal(t, []string{"log.level"}, logKeys)
+		assert.Nil(t, quotaKeys)
+		assert.Equal(t, "DEBUG", config.GetLog().LogLevel)
+		assert.Equal(t, "DEBUG", config.GetString("log.level", ""))
+	})
+
+	t.Run("quota removed, should report the callback error", func(t *testing.T) {
+		logKeys = nil
+		err := ioutil.WriteFile(file, []byte("log:\n  level: DEBUG\n"), 0640)
+		assert.NoError(t, err)
+		result, err := config.Refresh()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"quota.cap.service.limit"}, result.Changed)
+		assert.Equal(t, []config.CallbackResult{{Name: "quota", Error: "quota failed"}}, result.Callbacks)
+		assert.Nil(t, logKeys)
+		assert.Equal(t, 50, config.GetInt("quota.cap.service.limit", 50))
+	})
+
+	t.Run("invalid file, should keep the configurations", func(t *testing.T) {
+		err := ioutil.WriteFile(file, []byte("log: [\n"), 0640)
+		assert.NoError(t, err)
+		_, err = config.Refresh()
+		assert.Error(t, err)
+		assert.Equal(t, "DEBUG", config.GetString("log.level", ""))
+	})
+}
+
+func TestRefreshConcurrently(t *testing.T) {
+	defer archaius.Clean()
+	dir := filepath.Join(util.GetAppRoot(), "conf")
+	defer os.Remove(dir)
+	os.Mkdir(dir, 0750)
+	file := filepath.Join(dir, "app.yaml")
+	defer os.Remove(file)
+	err := ioutil.WriteFile(file, []byte("log:\n  level: INFO\n"), 0640)
+	assert.NoError(t, err)
+	config.Init()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					level := config.GetRegistry().LogLevel
+					assert.Contains(t, []string{"INFO", "DEBUG"}, level)
+				}
+			}
+		}()
+	}
+	levels := []string{"DEBUG", "INFO"}
+	for i := 0; i < 20; i++ {
+		content := fmt.Sprintf("log:\n  level: %s\n", levels[i%len(levels)])
+		assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0640))
+		_, err := config.Refresh()
+		assert.NoError(t, err)
+	}
+	close(done)
+	wg.Wait()
+	assert.Equal(t, "INFO", config.GetRegistry().LogLevel)
+}
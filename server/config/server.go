@@ -77,9 +77,17 @@ type ServerConfigDetail struct {
 	SchemaDisable bool `json:"schemaDisable"`
 	// if want disable modification of Schema in production environment, SchemaEditable set false
 	SchemaEditable bool `json:"-"`
+	// the max revisions of a schema kept in history, 0 means disable the history
+	SchemaHistorySize int `json:"schemaHistorySize"`
 
 	// instance ttl in seconds
 	InstanceTTL int64 `json:"-"`
+
+	//clear the dependencies which the consumers have not found the providers for a long time
+	DependencyClearEnabled  bool          `json:"dependencyClearEnabled"`
+	DependencyClearInterval time.Duration `json:"dependencyClearInterval"`
+	//if a dependency has not been seen for this duration, it can be cleared
+	DependencyTTL time.Duration `json:"dependencyTTL"`
 }
 
 func (si *ServerConfig) IsDev() bool {
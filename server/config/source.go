@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"sync"
+
+	"github.com/go-chassis/go-archaius/event"
+	"github.com/go-chassis/go-archaius/source"
+	"github.com/go-chassis/go-archaius/source/util"
+)
+
+const (
+	appSourceName = "AppFileSource"
+	// same as the archaius file source, lower than the ENV source
+	appSourcePriority = 4
+)
+
+var ErrKeyNotExist = errors.New("key does not exist")
+
+// appSource is the archaius config source of conf/app.yaml,
+// unlike the buildin file source it can be reloaded on demand
+type appSource struct {
+	path     string
+	priority int
+
+	lock    sync.RWMutex
+	content []byte
+	configs map[string]interface{}
+	handler source.EventHandler
+}
+
+func newAppSource(path string) (*appSource, error) {
+	s := &appSource{path: path, priority: appSourcePriority}
+	if _, err := s.Refresh(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh re-reads the file and returns the keys changed since last read,
+// the events are also dispatched to archaius once the source is added
+func (s *appSource) Refresh() ([]string, error) {
+	content, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	if s.configs != nil && bytes.Equal(content, s.content) {
+		s.lock.Unlock()
+		return nil, nil
+	}
+	configs, err := util.Convert2JavaProps(s.path, content)
+	if err != nil {
+		s.lock.Unlock()
+		return nil, err
+	}
+	events, err := event.PopulateEvents(appSourceName, s.configs, configs)
+	if err != nil {
+		s.lock.Unlock()
+		return nil, err
+	}
+	s.content, s.configs = content, configs
+	handler := s.handler
+	s.lock.Unlock()
+
+	keys := make([]string, 0, len(events))
+	for _, e := range events {
+		keys = append(keys, e.Key)
+	}
+	if handler == nil || len(events) == 0 {
+		return keys, nil
+	}
+	for _, e := range events {
+		handler.OnEvent(e)
+	}
+	handler.OnModuleEvent(events)
+	return keys, nil
+}
+
+func (s *appSource) GetConfigurations() (map[string]interface{}, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	configs := make(map[string]interface{}, len(s.configs))
+	for k, v := range s.configs {
+		configs[k] = v
+	}
+	return configs, nil
+}
+
+func (s *appSource) GetConfigurationByKey(key string) (interface{}, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	v, ok := s.configs[key]
+	if !ok {
+		return nil, ErrKeyNotExist
+	}
+	return v, nil
+}
+
+func (s *appSource) Watch(handler source.EventHandler) error {
+	s.lock.Lock()
+	s.handler = handler
+	s.lock.Unlock()
+	return nil
+}
+
+func (s *appSource) GetPriority() int {
+	return s.priority
+}
+
+func (s *appSource) SetPriority(priority int) {
+	s.priority = priority
+}
+
+func (s *appSource) GetSourceName() string {
+	return appSourceName
+}
+
+func (s *appSource) Set(key string, value interface{}) error {
+	return nil
+}
+
+func (s *appSource) Delete(key string) error {
+	return nil
+}
+
+func (s *appSource) Cleanup() error {
+	s.lock.Lock()
+	s.content, s.configs, s.handler = nil, nil, nil
+	s.lock.Unlock()
+	return nil
+}
+
+func (s *appSource) AddDimensionInfo(labels map[string]string) error {
+	return nil
+}
@@ -18,6 +18,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/apache/servicecomb-service-center/pkg/plugin"
 )
 
@@ -46,6 +48,8 @@ func (c *AppConfig) GetImplName(kind plugin.Kind) string {
 	return GetString(kind.String()+".kind", plugin.Buildin, WithStandby(kind.String()+"_plugin"))
 }
 func (c *AppConfig) GetPluginDir() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return c.Server.Config.PluginsDir
 }
 
@@ -53,5 +57,16 @@ func (c *AppConfig) GetPluginDir() string {
 type Metrics struct {
 	Enable   bool   `yaml:"enable"`
 	Interval string `yaml:"interval"`
+	// Exporter is the comma separated list of prometheus, otlp, statsd and dogstatsd
 	Exporter string `yaml:"exporter"`
 }
+
+// HasExporter returns true if the exporter is enabled
+func (m Metrics) HasExporter(name string) bool {
+	for _, e := range strings.Split(m.Exporter, ",") {
+		if strings.TrimSpace(e) == name {
+			return true
+		}
+	}
+	return false
+}
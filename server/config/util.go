@@ -89,6 +89,18 @@ func GetInt64(key string, def int64, opts ...Option) int64 {
 	return beego.AppConfig.DefaultInt64(options.Standby, def)
 }
 
+// GetFloat64 return the float64 type value by specified key
+func GetFloat64(key string, def float64, opts ...Option) float64 {
+	options := newOptions(key, opts)
+	if archaius.Exist(options.ENV) {
+		return archaius.GetFloat64(options.ENV, def)
+	}
+	if archaius.Exist(key) {
+		return archaius.GetFloat64(key, def)
+	}
+	return beego.AppConfig.DefaultFloat(options.Standby, def)
+}
+
 // GetDuration return the time.Duration type value by specified key
 func GetDuration(key string, def time.Duration, opts ...Option) time.Duration {
 	str := strings.TrimSpace(GetString(key, "", opts...))
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package evws streams the registry change feed to the websocket clients
+package evws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/connection"
+	"github.com/apache/servicecomb-service-center/server/metrics"
+)
+
+const (
+	Websocket = "Websocket"
+	// CloseRevisionCompacted is the close code telling the client the revision to resume
+	// from is no longer kept, it should list the resources again and watch from now on
+	CloseRevisionCompacted = 4410
+)
+
+type client struct {
+	conn          *websocket.Conn
+	remoteAddr    string
+	domainProject string
+}
+
+// Stream sends the registry events of the domain project after the revision rev
+// to the client, blocks until the client closed or the watching failed
+func Stream(ctx context.Context, conn *websocket.Conn, rev int64) {
+	domainProject := util.ParseDomainProject(ctx)
+	domain := util.ParseDomain(ctx)
+	c := &client{
+		conn:          conn,
+		remoteAddr:    conn.RemoteAddr().String(),
+		domainProject: domainProject,
+	}
+	log.Infof("new a registry event stream with subscriber[%s], domain project: %s, revision: %d",
+		c.remoteAddr, domainProject, rev)
+
+	metrics.ReportSubscriber(domain, Websocket, 1)
+	defer metrics.ReportSubscriber(domain, Websocket, -1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go c.handleMessage(cancel)
+	go c.heartbeat(ctx)
+
+	err := datasource.Instance().WatchRegistryEvents(ctx, domainProject, rev, c.send)
+	if ctx.Err() != nil {
+		return
+	}
+	code := websocket.CloseInternalServerErr
+	if err == datasource.ErrRevisionCompacted {
+		code = CloseRevisionCompacted
+	}
+	if err != nil {
+		log.Error(fmt.Sprintf("subscriber[%s] watch registry events failed", c.remoteAddr), err)
+	} else {
+		err = fmt.Errorf("watching is stopped")
+	}
+	c.sendClose(code, err.Error())
+}
+
+func (c *client) send(evt *datasource.RegistryEvent) error {
+	message, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	if err = c.conn.SetWriteDeadline(time.Now().Add(connection.SendTimeout)); err != nil {
+		return err
+	}
+	if err = c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		log.Error(fmt.Sprintf("send the event of revision %d to subscriber[%s] failed", evt.Revision, c.remoteAddr), err)
+	}
+	return err
+}
+
+func (c *client) sendClose(code int, text string) {
+	var message []byte
+	if code != websocket.CloseNoStatusReceived {
+		message = websocket.FormatCloseMessage(code, text)
+	}
+	err := c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(connection.SendTimeout))
+	if err != nil {
+		log.Error(fmt.Sprintf("subscriber[%s] catch an err", c.remoteAddr), err)
+	}
+}
+
+func (c *client) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(connection.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(connection.SendTimeout))
+			if err != nil {
+				log.Error(fmt.Sprintf("send 'Ping' message to subscriber[%s] failed", c.remoteAddr), err)
+				return
+			}
+		}
+	}
+}
+
+// handleMessage reads the control messages until the client closed
+func (c *client) handleMessage(cancel context.CancelFunc) {
+	defer cancel()
+
+	c.conn.SetReadLimit(connection.ReadMaxBody)
+	resetDeadline := func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(connection.ReadTimeout))
+	}
+	if err := resetDeadline(""); err != nil {
+		log.Error("", err)
+	}
+	c.conn.SetPongHandler(resetDeadline)
+	c.conn.SetPingHandler(func(message string) error {
+		if err := resetDeadline(message); err != nil {
+			return err
+		}
+		return c.conn.WriteControl(websocket.PongMessage, []byte(message), time.Now().Add(connection.SendTimeout))
+	})
+	c.conn.SetCloseHandler(func(code int, text string) error {
+		log.Info(fmt.Sprintf("subscriber[%s] active closed, code: %d, message: '%s'", c.remoteAddr, code, text))
+		c.sendClose(code, "")
+		return nil
+	})
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Error("", err)
+			}
+			return
+		}
+	}
+}
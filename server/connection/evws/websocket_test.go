@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/connection/evws"
+)
+
+const latestRev = 100
+
+type fakeDataSource struct {
+	datasource.DataSource
+}
+
+func (ds *fakeDataSource) WatchRegistryEvents(ctx context.Context, domainProject string, rev int64,
+	f datasource.RegistryEventFunc) error {
+	if rev > 0 && rev < latestRev {
+		return datasource.ErrRevisionCompacted
+	}
+	err := f(&datasource.RegistryEvent{Revision: latestRev + 1, Action: pb.EVT_CREATE,
+		Resource: datasource.ResourceService, ServiceID: domainProject})
+	if err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func init() {
+	datasource.Install("evws_test", func(opts datasource.Options) (datasource.DataSource, error) {
+		return &fakeDataSource{}, nil
+	})
+	if err := datasource.Init(datasource.Options{Kind: "evws_test"}); err != nil {
+		panic(err)
+	}
+}
+
+func dial(t *testing.T, rev int64) *websocket.Conn {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		evws.Stream(util.SetDomainProject(r.Context(), "default", "default"), conn, rev)
+	}))
+	t.Cleanup(s.Close)
+	conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(s.URL, "http://", "ws://", 1), nil)
+	assert.NoError(t, err)
+	return conn
+}
+
+func TestStream(t *testing.T) {
+	t.Run("stream from now on, should receive the events", func(t *testing.T) {
+		conn := dial(t, 0)
+		defer conn.Close()
+
+		var evt datasource.RegistryEvent
+		assert.NoError(t, conn.ReadJSON(&evt))
+		assert.Equal(t, int64(latestRev+1), evt.Revision)
+		assert.Equal(t, pb.EVT_CREATE, evt.Action)
+		assert.Equal(t, "default/default", evt.ServiceID)
+	})
+
+	t.Run("resume from a compacted revision, should be closed with the compacted code", func(t *testing.T) {
+		conn := dial(t, latestRev-1)
+		defer conn.Close()
+
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, evws.CloseRevisionCompacted), err)
+	})
+}
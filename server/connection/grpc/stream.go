@@ -63,6 +63,7 @@ func Handle(watcher *event.InstanceSubscriber, stream proto.ServiceInstanceCtrlW
 				watcher.SetError(err)
 				return
 			}
+			watcher.Delivered(job)
 			util.ResetTimer(timer, connection.HeartbeatInterval)
 		}
 	}
@@ -70,6 +70,9 @@ func (b *Broker) write(evt *event.InstanceEvent) error {
 	}
 	err = b.consumer.WriteTextMessage(data)
 	metrics.ReportPublishCompleted(evt, err)
+	if err == nil {
+		b.producer.Delivered(evt)
+	}
 	return err
 }
 
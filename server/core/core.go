@@ -43,4 +43,19 @@ func initLogger() {
 		LogRotateSize:  int(config.GetLog().LogRotateSize),
 		LogBackupCount: int(config.GetLog().LogBackupCount),
 	})
+	config.RegisterChangeCallback("log", reloadLogLevel, "log.level")
+}
+
+// reloadLogLevel only changes the level, the other log configs take effect
+// after restarting
+func reloadLogLevel(_ []string) error {
+	level := config.GetLog().LogLevel
+	if len(level) == 0 {
+		level = "DEBUG"
+	}
+	if err := log.SetLevel(level); err != nil {
+		return err
+	}
+	log.Infof("log level changed to %s", level)
+	return nil
 }
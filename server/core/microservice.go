@@ -29,7 +29,7 @@ import (
 )
 
 var (
-	ServiceAPI         proto.ServiceCtrlServer
+	ServiceAPI         proto.ServiceCtrlServerEx
 	InstanceAPI        proto.ServiceInstanceCtrlServerEx
 	Service            *discovery.MicroService
 	Instance           *discovery.MicroServiceInstance
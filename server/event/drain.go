@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	pb "github.com/go-chassis/cari/discovery"
+)
+
+var drainTasks util.ConcurrentMap
+
+// DrainTask tracks the consumer watchers notified of a draining instance,
+// the watchers are counted per service center node, only the ones connected
+// to this node are tracked
+type DrainTask struct {
+	ServiceID     string
+	InstanceID    string
+	DomainProject string
+	Deadline      time.Time
+
+	mux      sync.RWMutex
+	revision int64
+	// notified and delivered are the maps of subscriber id to consumer service id
+	notified  map[string]string
+	delivered map[string]string
+	done      chan struct{}
+	once      sync.Once
+}
+
+func (t *DrainTask) match(evt *InstanceEvent) bool {
+	resp := evt.Response
+	return evt.Subject() == t.DomainProject && resp.Instance.ServiceId == t.ServiceID &&
+		resp.Instance.InstanceId == t.InstanceID && resp.Action == string(pb.EVT_UPDATE) && resp.Instance.Status == pb.MSI_OUTOFSERVICE
+}
+
+func (t *DrainTask) notify(subscriberID, consumerID string, evt *InstanceEvent) {
+	t.mux.Lock()
+	if t.revision == 0 || evt.Revision < t.revision {
+		t.revision = evt.Revision
+	}
+	t.notified[subscriberID] = consumerID
+	t.mux.Unlock()
+}
+
+func (t *DrainTask) deliver(subscriberID, consumerID string) {
+	t.mux.Lock()
+	t.delivered[subscriberID] = consumerID
+	t.mux.Unlock()
+}
+
+// Done is closed when the task is finished
+func (t *DrainTask) Done() <-chan struct{} {
+	return t.done
+}
+
+func (t *DrainTask) Status() *proto.DrainStatus {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	status := &proto.DrainStatus{
+		ServiceId:  t.ServiceID,
+		InstanceId: t.InstanceID,
+		Revision:   t.revision,
+		Deadline:   t.Deadline.Unix(),
+		Notified:   len(t.notified),
+		Delivered:  len(t.delivered),
+	}
+	consumers := make(map[string]struct{}, len(t.delivered))
+	for _, consumerID := range t.delivered {
+		if _, ok := consumers[consumerID]; ok {
+			continue
+		}
+		consumers[consumerID] = struct{}{}
+		status.Consumers = append(status.Consumers, consumerID)
+	}
+	sort.Strings(status.Consumers)
+	return status
+}
+
+// StartDrain creates a drain task of the instance, returns the exist one
+// and false if the instance is already draining
+func StartDrain(domainProject, serviceID, instanceID string, deadline time.Time) (*DrainTask, bool) {
+	task := &DrainTask{
+		ServiceID:     serviceID,
+		InstanceID:    instanceID,
+		DomainProject: domainProject,
+		Deadline:      deadline,
+		notified:      make(map[string]string),
+		delivered:     make(map[string]string),
+		done:          make(chan struct{}),
+	}
+	if exist := drainTasks.PutIfAbsent(drainKey(domainProject, serviceID, instanceID), task).(*DrainTask); exist != task {
+		return exist, false
+	}
+	return task, true
+}
+
+func GetDrain(domainProject, serviceID, instanceID string) (*DrainTask, bool) {
+	v, ok := drainTasks.Get(drainKey(domainProject, serviceID, instanceID))
+	if !ok {
+		return nil, false
+	}
+	return v.(*DrainTask), true
+}
+
+func FinishDrain(domainProject, serviceID, instanceID string) {
+	key := drainKey(domainProject, serviceID, instanceID)
+	v, ok := drainTasks.Get(key)
+	if !ok {
+		return
+	}
+	drainTasks.Remove(key)
+	task := v.(*DrainTask)
+	task.once.Do(func() { close(task.done) })
+}
+
+// instance id may be specified by client, so the key must contain the
+// domain project and service id to isolate the tasks of tenants
+func drainKey(domainProject, serviceID, instanceID string) string {
+	return util.StringJoin([]string{domainProject, serviceID, instanceID}, "/")
+}
+
+func drainOf(evt *InstanceEvent) (*DrainTask, bool) {
+	if evt.Response == nil || evt.Response.Instance == nil {
+		return nil, false
+	}
+	instance := evt.Response.Instance
+	task, ok := GetDrain(evt.Subject(), instance.ServiceId, instance.InstanceId)
+	return task, ok && task.match(evt)
+}
+
+func onDrainNotify(w *InstanceSubscriber, evt *InstanceEvent) {
+	if task, ok := drainOf(evt); ok {
+		task.notify(w.ID(), w.Group(), evt)
+	}
+}
+
+func onDrainDelivered(w *InstanceSubscriber, evt *InstanceEvent) {
+	if task, ok := drainOf(evt); ok {
+		task.deliver(w.ID(), w.Group())
+	}
+}
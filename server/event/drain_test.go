@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainTask(t *testing.T) {
+	task, ok := StartDrain("default/default", "svc", "inst", time.Now().Add(time.Minute))
+	assert.True(t, ok)
+	defer FinishDrain("default/default", "svc", "inst")
+
+	exist, ok := StartDrain("default/default", "svc", "inst", time.Now().Add(time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, task, exist)
+
+	w1 := NewInstanceSubscriber("consumer1", "default/default")
+	w2 := NewInstanceSubscriber("consumer2", "default/default")
+	evt := NewInstanceEvent("consumer1", "default/default", 10, &pb.WatchInstanceResponse{
+		Action:   string(pb.EVT_UPDATE),
+		Instance: &pb.MicroServiceInstance{ServiceId: "svc", InstanceId: "inst", Status: pb.MSI_OUTOFSERVICE},
+	})
+	other := NewInstanceEvent("consumer1", "default/default", 11, &pb.WatchInstanceResponse{
+		Action:   string(pb.EVT_UPDATE),
+		Instance: &pb.MicroServiceInstance{ServiceId: "svc", InstanceId: "other", Status: pb.MSI_OUTOFSERVICE},
+	})
+
+	w1.OnMessage(evt)
+	w1.OnMessage(other)
+	w2.OnMessage(evt)
+	w1.Delivered(evt)
+	w1.Delivered(other)
+
+	status := task.Status()
+	assert.Equal(t, int64(10), status.Revision)
+	assert.Equal(t, 2, status.Notified)
+	assert.Equal(t, 1, status.Delivered)
+	assert.Equal(t, []string{"consumer1"}, status.Consumers)
+
+	FinishDrain("default/default", "svc", "inst")
+	_, ok = GetDrain("other/default", "svc", "inst")
+	assert.False(t, ok)
+	_, ok = GetDrain("default/default", "svc", "inst")
+	assert.False(t, ok)
+	select {
+	case <-task.Done():
+	default:
+		t.Fatal("drain task should be done")
+	}
+}
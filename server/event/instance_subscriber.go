@@ -65,6 +65,7 @@ func (w *InstanceSubscriber) sendMessage(evt *InstanceEvent) {
 	defer log.Recover()
 
 	metrics.ReportPendingCompleted(evt)
+	onDrainNotify(w, evt)
 
 	select {
 	case w.Job <- evt:
@@ -76,6 +77,12 @@ func (w *InstanceSubscriber) sendMessage(evt *InstanceEvent) {
 	}
 }
 
+// Delivered is called when the event is written to the watcher connection
+// successfully, it does not mean the consumer has applied the event
+func (w *InstanceSubscriber) Delivered(evt *InstanceEvent) {
+	onDrainDelivered(w, evt)
+}
+
 func (w *InstanceSubscriber) cleanup() {
 	for {
 		select {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/servicecomb-service-center/server/config"
@@ -35,7 +36,7 @@ import (
 // Handler records access log.
 // Make sure to complete the initialization before handling the request.
 type Handler struct {
-	logger        *log.Logger
+	logger        atomic.Value        // *log.Logger, nil means disabled
 	whiteListAPIs map[string]struct{} // not record access log
 }
 
@@ -54,8 +55,24 @@ func (h *Handler) ShouldIgnoreAPI(api string) bool {
 	return ok
 }
 
+// SetLogger replaces the access logger, nil disables the access log
+func (h *Handler) SetLogger(l *log.Logger) {
+	h.logger.Store(l)
+}
+
+// Logger returns the access logger
+func (h *Handler) Logger() *log.Logger {
+	l, _ := h.logger.Load().(*log.Logger)
+	return l
+}
+
 // Handle handles the request
 func (h *Handler) Handle(i *chain.Invocation) {
+	logger := h.Logger()
+	if logger == nil {
+		i.Next()
+		return
+	}
 	matchPattern := i.Context().Value(rest.CtxMatchPattern).(string)
 	if h.ShouldIgnoreAPI(matchPattern) {
 		i.Next()
@@ -75,7 +92,7 @@ func (h *Handler) Handle(i *chain.Invocation) {
 		statusCode := i.Context().Value(rest.CtxResponseStatus).(int)
 		// format:  remoteIp requestReceiveTime "method requestUri proto" statusCode requestBodySize delay(ms)
 		// example: 127.0.0.1 2006-01-02T15:04:05.000Z07:00 "GET /v4/default/registry/microservices HTTP/1.1" 200 0 0
-		h.logger.Infof("%s %s \"%s %s %s\" %d %d %s",
+		logger.Infof("%s %s \"%s %s %s\" %d %d %s",
 			util.GetIPFromContext(i.Context()),
 			startTimeStr,
 			r.Method,
@@ -89,17 +106,17 @@ func (h *Handler) Handle(i *chain.Invocation) {
 
 // NewAccessLogHandler creates a Handler
 func NewAccessLogHandler(l *log.Logger) *Handler {
-	return &Handler{
-		logger:        l,
+	h := &Handler{
 		whiteListAPIs: make(map[string]struct{})}
+	h.SetLogger(l)
+	return h
 }
 
-// RegisterHandlers registers an access log handler to the handler chain
-func RegisterHandlers() {
+func newAccessLogger() *log.Logger {
 	if !config.GetLog().EnableAccessLog {
-		return
+		return nil
 	}
-	logger := log.NewLogger(log.Config{
+	return log.NewLogger(log.Config{
 		LoggerFile:     os.ExpandEnv(config.GetLog().AccessLogFile),
 		LogFormatText:  true,
 		LogRotateSize:  int(config.GetLog().LogRotateSize),
@@ -108,7 +125,21 @@ func RegisterHandlers() {
 		NoTime:         true,
 		NoLevel:        true,
 	})
-	h := NewAccessLogHandler(logger)
+}
+
+// RegisterHandlers registers an access log handler to the handler chain,
+// the handler does nothing until the access log is enabled
+func RegisterHandlers() {
+	h := NewAccessLogHandler(newAccessLogger())
+	config.RegisterChangeCallback("accesslog", func(_ []string) error {
+		old := h.Logger()
+		h.SetLogger(newAccessLogger())
+		if old != nil {
+			old.Sync()
+		}
+		log.Infof("access log enabled: %v", config.GetLog().EnableAccessLog)
+		return nil
+	}, "log.accessEnable", "log.accessFile")
 	// no access log for heartbeat
 	h.AddWhiteListAPIs(
 		"/v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat",
@@ -59,6 +59,10 @@ func (c *Handler) commonQueryToContext(i *chain.Invocation) {
 
 	i.WithContext(util.CtxRemoteIP, util.GetRealIP(r))
 
+	if consumerID := r.Header.Get(util.HeaderConsumerID); len(consumerID) > 0 {
+		i.WithContext(util.CtxConsumerID, consumerID)
+	}
+
 	global := util.StringTRUE(query.Get(queryGlobal))
 	if global && r.Method == http.MethodGet {
 		i.WithContext(util.CtxGlobal, "1")
@@ -39,6 +39,7 @@ func (h *Handler) Handle(i *chain.Invocation) {
 		w, r := i.Context().Value(rest.CtxResponse).(http.ResponseWriter),
 			i.Context().Value(rest.CtxRequest).(*http.Request)
 		metrics.ReportRequestCompleted(w, r, start)
+		metrics.ReportTenantRequest(i.Context())
 		log.NilOrWarnf(start, "%s %s", r.Method, r.RequestURI)
 	}))
 }
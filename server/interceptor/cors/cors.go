@@ -20,22 +20,63 @@ package cors
 import (
 	"errors"
 	"net/http"
+	"strings"
+	"sync/atomic"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/rs/cors"
 )
 
-var CORS *cors.Cors
+const (
+	defaultAllowedHeaders = "Origin,Accept,Content-Type,X-Domain-Name,X-ConsumerId"
+	defaultAllowedMethods = "GET,POST,PUT,DELETE,UPDATE"
+)
+
+var CORS atomic.Value // *cors.Cors
 
 func init() {
-	CORS = cors.New(cors.Options{
-		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Domain-Name", "X-ConsumerId"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "UPDATE"},
+	CORS.Store(newCORS("", defaultAllowedHeaders, defaultAllowedMethods))
+}
+
+// Init loads the CORS options from configs and reloads them when changed
+func Init() {
+	CORS.Store(New())
+	config.RegisterChangeCallback("cors", func(_ []string) error {
+		CORS.Store(New())
+		log.Info("CORS options reloaded")
+		return nil
+	}, "server.cors.")
+}
+
+// New creates the CORS with the server.cors options, empty allowedOrigins
+// means all origins are allowed
+func New() *cors.Cors {
+	return newCORS(config.GetString("server.cors.allowedOrigins", ""),
+		config.GetString("server.cors.allowedHeaders", defaultAllowedHeaders),
+		config.GetString("server.cors.allowedMethods", defaultAllowedMethods))
+}
+
+func newCORS(origins, headers, methods string) *cors.Cors {
+	return cors.New(cors.Options{
+		AllowedOrigins: split(origins),
+		AllowedHeaders: split(headers),
+		AllowedMethods: split(methods),
 	})
 }
 
+func split(s string) []string {
+	var arr []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			arr = append(arr, v)
+		}
+	}
+	return arr
+}
+
 func Intercept(w http.ResponseWriter, r *http.Request) (err error) {
-	CORS.HandlerFunc(w, r)
+	CORS.Load().(*cors.Cors).HandlerFunc(w, r)
 	if r.Method == "OPTIONS" {
 		log.Debugf("identify the current request is a CORS, url: %s", r.RequestURI)
 		err = errors.New("Handle the preflight request")
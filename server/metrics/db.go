@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	helper "github.com/apache/servicecomb-service-center/pkg/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	mongoCommandLatency = helper.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: "db",
+			Name:      "mongo_command_durations_seconds",
+			Help:      "Latency of mongo commands by collection",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"instance", "collection", "command", "status"})
+
+	mongoCommandErrors = helper.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: "db",
+			Name:      "mongo_command_errors_total",
+			Help:      "Counter of failed mongo commands by collection",
+		}, []string{"instance", "collection", "command"})
+)
+
+// ReportMongoCommand records the latency of the mongo command, the collection
+// is empty if the command does not operate on a collection, e.g. ping
+func ReportMongoCommand(collection, command string, failed bool, elapsed time.Duration) {
+	instance := metrics.InstanceName()
+	status := success
+	if failed {
+		status = failure
+		mongoCommandErrors.WithLabelValues(instance, collection, command).Inc()
+	}
+	mongoCommandLatency.WithLabelValues(instance, collection, command, status).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	helper "github.com/apache/servicecomb-service-center/pkg/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	selfPreservationEnabled = helper.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: metrics.SubSystem,
+			Name:      "self_preservation_enabled",
+			Help:      "Gauge of the self preservation status, 1 means enabled",
+		}, []string{"instance"})
+
+	selfPreservationDeferred = helper.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: metrics.SubSystem,
+			Name:      "self_preservation_deferred_events",
+			Help:      "Gauge of the instance DELETE events deferred by self preservation",
+		}, []string{"instance"})
+
+	selfPreservationPercentage = helper.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: metrics.SubSystem,
+			Name:      "self_preservation_percentage",
+			Help:      "Gauge of the DELETE events percentage which enables self preservation",
+		}, []string{"instance"})
+
+	selfPreservationMaxTTL = helper.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: metrics.SubSystem,
+			Name:      "self_preservation_max_ttl_seconds",
+			Help:      "Gauge of the max seconds a DELETE event can be deferred",
+		}, []string{"instance"})
+
+	selfPreservationInitCount = helper.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: metrics.SubSystem,
+			Name:      "self_preservation_init_count",
+			Help:      "Gauge of the min instances count which enables self preservation",
+		}, []string{"instance"})
+)
+
+func ReportSelfPreservationThresholds(percentage float64, maxTTL int32, initCount int) {
+	instance := metrics.InstanceName()
+	selfPreservationPercentage.WithLabelValues(instance).Set(percentage)
+	selfPreservationMaxTTL.WithLabelValues(instance).Set(float64(maxTTL))
+	selfPreservationInitCount.WithLabelValues(instance).Set(float64(initCount))
+}
+
+func ReportSelfPreservation(enabled bool, deferred int) {
+	instance := metrics.InstanceName()
+	var v float64
+	if enabled {
+		v = 1
+	}
+	selfPreservationEnabled.WithLabelValues(instance).Set(v)
+	selfPreservationDeferred.WithLabelValues(instance).Set(float64(deferred))
+}
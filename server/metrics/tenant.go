@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	helper "github.com/apache/servicecomb-service-center/pkg/prometheus"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// the dimensions of top talkers
+const (
+	TalkerDomain   = "domain"
+	TalkerConsumer = "consumer"
+	TalkerIP       = "ip"
+)
+
+const (
+	defaultMaxTenants   = 100
+	defaultTalkerWindow = 5 * time.Minute
+	talkerBuckets       = 30
+	maxTalkersPerBucket = 1000
+)
+
+var (
+	tenantRequests = helper.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: "http",
+			Name:      "tenant_request_total",
+			Help:      "Counter of requests received by domain and project, the tenants beyond the limit are counted as other",
+		}, []string{"instance", "domain", "project"})
+
+	tenantOnce sync.Once
+	tenants    *tenantSet
+	talkers    map[string]*metrics.SlidingCounter
+)
+
+// tenantSet admits the first max tenants as the metric labels to bound the cardinality
+type tenantSet struct {
+	lock sync.RWMutex
+	max  int
+	set  map[string]struct{}
+}
+
+func (s *tenantSet) admit(key string) bool {
+	s.lock.RLock()
+	_, ok := s.set[key]
+	s.lock.RUnlock()
+	if ok {
+		return true
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.set[key]; ok {
+		return true
+	}
+	if len(s.set) >= s.max {
+		return false
+	}
+	s.set[key] = struct{}{}
+	return true
+}
+
+func initTenants() {
+	tenants = &tenantSet{
+		max: config.GetInt("metrics.tenant.limit", defaultMaxTenants),
+		set: make(map[string]struct{}),
+	}
+	window := config.GetDuration("metrics.talkers.window", defaultTalkerWindow)
+	talkers = map[string]*metrics.SlidingCounter{
+		TalkerDomain:   metrics.NewSlidingCounter(window, talkerBuckets, maxTalkersPerBucket),
+		TalkerConsumer: metrics.NewSlidingCounter(window, talkerBuckets, maxTalkersPerBucket),
+		TalkerIP:       metrics.NewSlidingCounter(window, talkerBuckets, maxTalkersPerBucket),
+	}
+}
+
+// ReportTenantRequest counts the request by domain/project, consumer service id and source ip
+func ReportTenantRequest(ctx context.Context) {
+	tenantOnce.Do(initTenants)
+
+	domain, project := util.ParseDomain(ctx), util.ParseProject(ctx)
+	if len(domain) == 0 {
+		return
+	}
+	domainProject := domain + util.SPLIT + project
+	if !tenants.admit(domainProject) {
+		domain, project = metrics.OtherKey, metrics.OtherKey
+	}
+	tenantRequests.WithLabelValues(metrics.InstanceName(), domain, project).Inc()
+
+	talkers[TalkerDomain].Add(domainProject, 1)
+	if consumerID, ok := ctx.Value(util.CtxConsumerID).(string); ok && len(consumerID) > 0 {
+		talkers[TalkerConsumer].Add(consumerID, 1)
+	}
+	if ip, ok := ctx.Value(util.CtxRemoteIP).(string); ok && len(ip) > 0 {
+		talkers[TalkerIP].Add(ip, 1)
+	}
+}
+
+// TopTalkers returns the n heaviest talkers of the dimension in the window
+func TopTalkers(dimension string, n int) ([]metrics.KeyCount, time.Duration, bool) {
+	tenantOnce.Do(initTenants)
+	c, ok := talkers[dimension]
+	if !ok {
+		return nil, 0, false
+	}
+	return c.Top(n), c.Window, true
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote calls the auditlog plugin running in another process through gRPC
+package remote
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/plugin/remote"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/plugin/auditlog"
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: auditlog.AUDITLOG, Name: remote.ProtocolGRPC, New: New})
+}
+
+type RecordRequest struct {
+	Request        *remote.HTTPRequest `json:"request"`
+	ResponseHeader http.Header         `json:"responseHeader,omitempty"`
+}
+
+type RecordResponse struct {
+}
+
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: remote.ServiceName(auditlog.AUDITLOG),
+	HandlerType: (*auditlog.AuditLogger)(nil),
+	Methods: []grpc.MethodDesc{
+		remote.NewMethod("Record", newRequest, func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+			in := req.(*RecordRequest)
+			if in.Request == nil {
+				return &RecordResponse{}, nil
+			}
+			r, err := in.Request.Request(ctx)
+			if err != nil {
+				return nil, err
+			}
+			srv.(auditlog.AuditLogger).Record(r, in.ResponseHeader)
+			return &RecordResponse{}, nil
+		}),
+	},
+}
+
+func newRequest() interface{} {
+	return &RecordRequest{}
+}
+
+// Serve is called by the auditlog plugin process
+func Serve(impl auditlog.AuditLogger) error {
+	return remote.Serve(&ServiceDesc, impl)
+}
+
+func New() plugin.Instance {
+	path := config.GetString("auditlog.grpc.path", remote.DefaultPath(auditlog.AUDITLOG))
+	return &AuditLogger{client: remote.NewClient(auditlog.AUDITLOG, path)}
+}
+
+type AuditLogger struct {
+	client *remote.Client
+}
+
+func (a *AuditLogger) Record(r *http.Request, responseHeaders http.Header) {
+	in := &RecordRequest{Request: remote.NewHTTPRequest(r), ResponseHeader: responseHeaders}
+	if err := a.client.Invoke(r.Context(), "Record", in, &RecordResponse{}); err != nil {
+		log.Errorf(err, "record audit log failed, %s %s", r.Method, r.RequestURI)
+	}
+}
@@ -50,6 +50,10 @@ var (
 	APIServiceInfo      = "/v4/:project/registry/microservices/:serviceId"
 	APIProConDependency = "/v4/:project/registry/microservices/:providerId/consumers"
 	APIConProDependency = "/v4/:project/registry/microservices/:consumerId/providers"
+	// APIImpact apply by service key or serviceId
+	// - /v4/:project/registry/impact?env=xxx&appId=xxx&serviceName=xxx&version=xxx
+	// - /v4/:project/registry/impact?serviceId=xxx
+	APIImpact = "/v4/:project/registry/impact"
 	// APIDiscovery apply by service key
 	APIDiscovery = "/v4/:project/registry/instances"
 	// APIBatchDiscovery apply by request body
@@ -76,6 +80,7 @@ func init() {
 	})
 	RegisterParseFunc(APIDiscovery, ByServiceKey)
 	RegisterParseFunc(APIServiceExistence, ByServiceKey)
+	RegisterParseFunc(APIImpact, ByServiceKey)
 	RegisterParseFunc(APIGovServicesList, ApplyAll)
 	RegisterParseFunc(APIServicesList, ByRequestBody)
 	RegisterParseFunc(APIBatchDiscovery, ByDiscoveryRequestBody)
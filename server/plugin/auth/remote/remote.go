@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote calls the auth plugin running in another process through gRPC
+package remote
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/plugin/remote"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	authHandler "github.com/apache/servicecomb-service-center/server/handler/auth"
+	"github.com/apache/servicecomb-service-center/server/plugin/auth"
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: auth.AUTH, Name: remote.ProtocolGRPC, New: New})
+}
+
+type IdentifyResponse struct {
+	// Labels are the resource labels matched by the permissions, nil means all allowed
+	Labels []map[string]string `json:"labels,omitempty"`
+	Error  *remote.Error       `json:"error,omitempty"`
+}
+
+type ResourceScopesResponse struct {
+	Scope *auth.ResourceScope `json:"scope,omitempty"`
+}
+
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: remote.ServiceName(auth.AUTH),
+	HandlerType: (*auth.Authenticate)(nil),
+	Methods: []grpc.MethodDesc{
+		remote.NewMethod("Identify", newRequest, func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+			r, err := req.(*remote.HTTPRequest).Request(ctx)
+			if err != nil {
+				return nil, err
+			}
+			err = srv.(auth.Authenticate).Identify(r)
+			// the implementation sets the labels like the buildin one
+			labels, _ := r.Context().Value(authHandler.CtxResourceLabels).([]map[string]string)
+			return &IdentifyResponse{Labels: labels, Error: remote.NewError(err)}, nil
+		}),
+		remote.NewMethod("ResourceScopes", newRequest, func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+			r, err := req.(*remote.HTTPRequest).Request(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &ResourceScopesResponse{Scope: srv.(auth.Authenticate).ResourceScopes(r)}, nil
+		}),
+	},
+}
+
+func newRequest() interface{} {
+	return &remote.HTTPRequest{}
+}
+
+// Serve is called by the auth plugin process
+func Serve(impl auth.Authenticate) error {
+	return remote.Serve(&ServiceDesc, impl)
+}
+
+func New() plugin.Instance {
+	path := config.GetString("auth.grpc.path", remote.DefaultPath(auth.AUTH))
+	return &Authenticator{client: remote.NewClient(auth.AUTH, path)}
+}
+
+// Authenticator passes the request without the body to the plugin
+type Authenticator struct {
+	client *remote.Client
+}
+
+func (a *Authenticator) Identify(r *http.Request) error {
+	resp := &IdentifyResponse{}
+	if err := a.client.Invoke(r.Context(), "Identify", remote.NewHTTPRequest(r), resp); err != nil {
+		log.Errorf(err, "call auth plugin failed, %s %s", r.Method, r.RequestURI)
+		return err
+	}
+	if err := resp.Error.Err(); err != nil {
+		return err
+	}
+	if len(resp.Labels) > 0 {
+		util.SetRequestContext(r, authHandler.CtxResourceLabels, resp.Labels)
+	}
+	return nil
+}
+
+func (a *Authenticator) ResourceScopes(r *http.Request) *auth.ResourceScope {
+	resp := &ResourceScopesResponse{}
+	if err := a.client.Invoke(r.Context(), "ResourceScopes", remote.NewHTTPRequest(r), resp); err != nil {
+		log.Errorf(err, "call auth plugin failed, %s %s", r.Method, r.RequestURI)
+		return nil
+	}
+	return resp.Scope
+}
@@ -66,6 +66,17 @@ var (
 	DefaultRoleQuota     = defaultRoleLimit
 )
 
+func init() {
+	config.RegisterChangeCallback("quota", func(_ []string) error {
+		Init()
+		log.Infof("quota reloaded, service: %d, instance: %d, schema: %d/service, tag: %d/service, rule: %d/service"+
+			", account: %d, role: %d",
+			DefaultServiceQuota, DefaultInstanceQuota, DefaultSchemaQuota, DefaultTagQuota, DefaultRuleQuota,
+			DefaultAccountQuota, DefaultRoleQuota)
+		return nil
+	}, "quota.cap.")
+}
+
 func Init() {
 	DefaultServiceQuota = config.GetInt("quota.cap.service.limit", defaultServiceLimit, config.WithENV("QUOTA_SERVICE"))
 	DefaultInstanceQuota = config.GetInt("quota.cap.instance.limit", defaultInstanceLimit, config.WithENV("QUOTA_INSTANCE"))
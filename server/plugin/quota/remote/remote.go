@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote calls the quota plugin running in another process through gRPC
+package remote
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/plugin/remote"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/plugin/quota"
+	"github.com/apache/servicecomb-service-center/server/plugin/quota/buildin"
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: quota.QUOTA, Name: remote.ProtocolGRPC, New: New})
+}
+
+type Request struct {
+	Domain  string             `json:"domain"`
+	Project string             `json:"project"`
+	Type    quota.ResourceType `json:"type"`
+}
+
+type GetQuotaResponse struct {
+	Quota int64 `json:"quota"`
+}
+
+type RemandQuotasResponse struct {
+}
+
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: remote.ServiceName(quota.QUOTA),
+	HandlerType: (*quota.Manager)(nil),
+	Methods: []grpc.MethodDesc{
+		remote.NewMethod("GetQuota", newRequest, func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+			in := req.(*Request)
+			ctx = util.SetDomainProject(ctx, in.Domain, in.Project)
+			return &GetQuotaResponse{Quota: srv.(quota.Manager).GetQuota(ctx, in.Type)}, nil
+		}),
+		remote.NewMethod("RemandQuotas", newRequest, func(srv interface{}, ctx context.Context, req interface{}) (interface{}, error) {
+			in := req.(*Request)
+			ctx = util.SetDomainProject(ctx, in.Domain, in.Project)
+			srv.(quota.Manager).RemandQuotas(ctx, in.Type)
+			return &RemandQuotasResponse{}, nil
+		}),
+	},
+}
+
+func newRequest() interface{} {
+	return &Request{}
+}
+
+// Serve is called by the quota plugin process
+func Serve(impl quota.Manager) error {
+	return remote.Serve(&ServiceDesc, impl)
+}
+
+func New() plugin.Instance {
+	path := config.GetString("quota.grpc.path", remote.DefaultPath(quota.QUOTA))
+	return &Quota{
+		fallback: buildin.New().(quota.Manager),
+		client:   remote.NewClient(quota.QUOTA, path),
+	}
+}
+
+// Quota falls back to the configured quotas if the plugin is unavailable
+type Quota struct {
+	fallback quota.Manager
+	client   *remote.Client
+}
+
+func (q *Quota) GetQuota(ctx context.Context, t quota.ResourceType) int64 {
+	resp := &GetQuotaResponse{}
+	if err := q.client.Invoke(ctx, "GetQuota", newQuotaRequest(ctx, t), resp); err != nil {
+		log.Errorf(err, "get %s quota from plugin failed, use the configured quota", t)
+		return q.fallback.GetQuota(ctx, t)
+	}
+	return resp.Quota
+}
+
+func (q *Quota) RemandQuotas(ctx context.Context, t quota.ResourceType) {
+	if err := q.client.Invoke(ctx, "RemandQuotas", newQuotaRequest(ctx, t), &RemandQuotasResponse{}); err != nil {
+		log.Errorf(err, "remand %s quotas failed", t)
+	}
+}
+
+func newQuotaRequest(ctx context.Context, t quota.ResourceType) *Request {
+	return &Request{Domain: util.ParseDomain(ctx), Project: util.ParseProject(ctx), Type: t}
+}
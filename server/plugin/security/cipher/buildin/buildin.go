@@ -18,19 +18,53 @@
 package plain
 
 import (
+	"os"
+
+	"github.com/apache/servicecomb-service-center/pkg/keyring"
+	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/plugin/security/cipher"
 )
 
+const (
+	// RootKeyEnv is the env holds the root keys, see keyring.Parse for the format
+	RootKeyEnv = "CIPHER_ROOT_KEY"
+	// RootKeyFileEnv is the env holds the path of root key file
+	RootKeyFileEnv = "CIPHER_ROOT_KEY_FILE"
+)
+
 func init() {
 	plugin.RegisterPlugin(plugin.Plugin{Kind: cipher.CIPHER, Name: "buildin", New: New})
 }
 
 func New() plugin.Instance {
-	return &DefaultCipher{}
+	return newCipher(os.Getenv(RootKeyEnv),
+		config.GetString("cipher.rootKeyFile", "", config.WithENV(RootKeyFileEnv)))
 }
 
+func newCipher(env, file string) *DefaultCipher {
+	kr, err := keyring.Load(env, file)
+	if err == nil && kr.Len() == 0 && (len(env) > 0 || len(file) > 0) {
+		err = keyring.ErrNoKey
+	}
+	if err != nil {
+		// never store the values in plain text if the root keys are configured
+		log.Error("load cipher root keys failed, the values can not be encrypted or decrypted", err)
+		return &DefaultCipher{loadErr: err}
+	}
+	if kr.Len() > 0 {
+		log.Infof("AES-256-GCM cipher enabled with %d root key(s)", kr.Len())
+	}
+	return &DefaultCipher{keyring: kr}
+}
+
+// DefaultCipher prefers the dynamic plugin functions and uses the AES-256-GCM keyring otherwise,
+// the values are not encrypted if no root key is configured, and
+// fails with the load error if the configured root keys are invalid
 type DefaultCipher struct {
+	keyring *keyring.Keyring
+	loadErr error
 }
 
 func (c *DefaultCipher) Encrypt(src string) (string, error) {
@@ -38,7 +72,13 @@ func (c *DefaultCipher) Encrypt(src string) (string, error) {
 	if ok {
 		return df(src)
 	}
-	return src, nil
+	if c.loadErr != nil {
+		return "", c.loadErr
+	}
+	if c.keyring == nil || c.keyring.Len() == 0 {
+		return src, nil
+	}
+	return c.keyring.Encrypt(src)
 }
 
 func (c *DefaultCipher) Decrypt(src string) (string, error) {
@@ -46,5 +86,15 @@ func (c *DefaultCipher) Decrypt(src string) (string, error) {
 	if ok {
 		return df(src)
 	}
-	return src, nil
+	if c.loadErr != nil {
+		return "", c.loadErr
+	}
+	if !keyring.IsEncrypted(src) {
+		// the plain values are allowed
+		return src, nil
+	}
+	if c.keyring == nil || c.keyring.Len() == 0 {
+		return "", keyring.ErrNoKey
+	}
+	return c.keyring.Decrypt(src)
 }
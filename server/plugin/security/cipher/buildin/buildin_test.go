@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/pkg/keyring"
+)
+
+func TestDefaultCipher(t *testing.T) {
+	c := &DefaultCipher{}
+	s, err := c.Encrypt("x")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", s)
+	_, err = c.Decrypt(keyring.Prefix + "k1:eA==")
+	assert.Equal(t, keyring.ErrNoKey, err)
+
+	k1, _ := keyring.GenerateKey("k1")
+	kr, _ := keyring.Parse(k1)
+	c = &DefaultCipher{keyring: kr}
+	s, err = c.Encrypt("x")
+	assert.NoError(t, err)
+	assert.True(t, keyring.IsEncrypted(s))
+	s, err = c.Decrypt(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "x", s)
+	s, err = c.Decrypt("plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", s)
+}
+
+func TestNewCipher(t *testing.T) {
+	t.Run("invalid root key, should not fall back to plain text", func(t *testing.T) {
+		c := newCipher("k1", "")
+		_, err := c.Encrypt("x")
+		assert.Error(t, err)
+		_, err = c.Decrypt("plain")
+		assert.Error(t, err)
+	})
+	t.Run("root key file not exist, should not fall back to plain text", func(t *testing.T) {
+		c := newCipher("", "not-exist.key")
+		_, err := c.Encrypt("x")
+		assert.Error(t, err)
+	})
+	t.Run("no root key configured, should keep plain text", func(t *testing.T) {
+		c := newCipher("", "")
+		s, err := c.Encrypt("x")
+		assert.NoError(t, err)
+		assert.Equal(t, "x", s)
+	})
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote calls the cipher plugin running in another process through gRPC
+package remote
+
+import (
+	"context"
+
+	"github.com/go-chassis/cari/security"
+	"google.golang.org/grpc"
+
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/plugin/remote"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/plugin/security/cipher"
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: cipher.CIPHER, Name: remote.ProtocolGRPC, New: New})
+}
+
+type Request struct {
+	Src string `json:"src"`
+}
+
+type Response struct {
+	Dst   string        `json:"dst"`
+	Error *remote.Error `json:"error,omitempty"`
+}
+
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: remote.ServiceName(cipher.CIPHER),
+	HandlerType: (*security.Cipher)(nil),
+	Methods: []grpc.MethodDesc{
+		remote.NewMethod("Encrypt", newRequest, func(srv interface{}, _ context.Context, req interface{}) (interface{}, error) {
+			dst, err := srv.(security.Cipher).Encrypt(req.(*Request).Src)
+			return &Response{Dst: dst, Error: remote.NewError(err)}, nil
+		}),
+		remote.NewMethod("Decrypt", newRequest, func(srv interface{}, _ context.Context, req interface{}) (interface{}, error) {
+			dst, err := srv.(security.Cipher).Decrypt(req.(*Request).Src)
+			return &Response{Dst: dst, Error: remote.NewError(err)}, nil
+		}),
+	},
+}
+
+func newRequest() interface{} {
+	return &Request{}
+}
+
+// Serve is called by the cipher plugin process
+func Serve(impl security.Cipher) error {
+	return remote.Serve(&ServiceDesc, impl)
+}
+
+func New() plugin.Instance {
+	path := config.GetString("cipher.grpc.path", remote.DefaultPath(cipher.CIPHER))
+	return NewCipher(remote.NewClient(cipher.CIPHER, path))
+}
+
+func NewCipher(client *remote.Client) *Cipher {
+	return &Cipher{client: client}
+}
+
+type Cipher struct {
+	client *remote.Client
+}
+
+func (c *Cipher) Encrypt(src string) (string, error) {
+	return c.call("Encrypt", src)
+}
+
+func (c *Cipher) Decrypt(src string) (string, error) {
+	return c.call("Decrypt", src)
+}
+
+func (c *Cipher) call(method, src string) (string, error) {
+	resp := &Response{}
+	if err := c.client.Invoke(context.Background(), method, &Request{Src: src}, resp); err != nil {
+		return "", err
+	}
+	return resp.Dst, resp.Error.Err()
+}
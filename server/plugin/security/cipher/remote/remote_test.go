@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pluginremote "github.com/apache/servicecomb-service-center/pkg/plugin/remote"
+	"github.com/apache/servicecomb-service-center/server/plugin/security/cipher"
+	"github.com/apache/servicecomb-service-center/server/plugin/security/cipher/remote"
+)
+
+type reverseCipher struct {
+}
+
+func (c *reverseCipher) Encrypt(src string) (string, error) {
+	return "enc:" + src, nil
+}
+
+func (c *reverseCipher) Decrypt(src string) (string, error) {
+	if !strings.HasPrefix(src, "enc:") {
+		return "", errors.New("not encrypted")
+	}
+	return strings.TrimPrefix(src, "enc:"), nil
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginremote.MagicCookieKey) == pluginremote.MagicCookieValue {
+		if err := remote.Serve(&reverseCipher{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestCipher(t *testing.T) {
+	client := pluginremote.NewClient(cipher.CIPHER, os.Args[0])
+	defer client.Close()
+	c := remote.NewCipher(client)
+
+	dst, err := c.Encrypt("abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "enc:abc", dst)
+
+	src, err := c.Decrypt(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", src)
+
+	_, err = c.Decrypt("abc")
+	assert.EqualError(t, err, "not encrypted")
+}
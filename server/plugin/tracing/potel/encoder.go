@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// the field numbers of the OTLP trace protocol messages,
+// see opentelemetry/proto/collector/trace/v1/trace_service.proto
+const (
+	fieldExportResourceSpans protowire.Number = 1
+
+	fieldResourceSpansResource   protowire.Number = 1
+	fieldResourceSpansScopeSpans protowire.Number = 2
+
+	fieldScopeSpansScope protowire.Number = 1
+	fieldScopeSpansSpans protowire.Number = 2
+
+	fieldSpanTraceID      protowire.Number = 1
+	fieldSpanSpanID       protowire.Number = 2
+	fieldSpanTraceState   protowire.Number = 3
+	fieldSpanParentSpanID protowire.Number = 4
+	fieldSpanName         protowire.Number = 5
+	fieldSpanKind         protowire.Number = 6
+	fieldSpanStartTime    protowire.Number = 7
+	fieldSpanEndTime      protowire.Number = 8
+	fieldSpanAttributes   protowire.Number = 9
+	fieldSpanStatus       protowire.Number = 15
+
+	fieldStatusMessage protowire.Number = 2
+	fieldStatusCode    protowire.Number = 3
+)
+
+// Resource describes the process which produces the spans
+type Resource = otlp.Resource
+
+// EncodeExportRequest encodes the spans as the OTLP ExportTraceServiceRequest message,
+// the service center does not depend on the generated OTLP protobuf packages
+func EncodeExportRequest(res *Resource, spans []*Span) []byte {
+	var scopeSpans []byte
+	scopeSpans = otlp.AppendMessage(scopeSpans, fieldScopeSpansScope, res.EncodeScope())
+	for _, span := range spans {
+		scopeSpans = otlp.AppendMessage(scopeSpans, fieldScopeSpansSpans, encodeSpan(span))
+	}
+
+	var resourceSpans []byte
+	resourceSpans = otlp.AppendMessage(resourceSpans, fieldResourceSpansResource, res.Encode())
+	resourceSpans = otlp.AppendMessage(resourceSpans, fieldResourceSpansScopeSpans, scopeSpans)
+
+	return otlp.AppendMessage(nil, fieldExportResourceSpans, resourceSpans)
+}
+
+func encodeSpan(span *Span) []byte {
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	var b []byte
+	b = otlp.AppendBytes(b, fieldSpanTraceID, span.TraceID[:])
+	b = otlp.AppendBytes(b, fieldSpanSpanID, span.SpanID[:])
+	b = otlp.AppendString(b, fieldSpanTraceState, span.TraceState)
+	if span.ParentSpanID.IsValid() {
+		b = otlp.AppendBytes(b, fieldSpanParentSpanID, span.ParentSpanID[:])
+	}
+	b = otlp.AppendString(b, fieldSpanName, span.Name)
+	b = otlp.AppendVarint(b, fieldSpanKind, uint64(span.Kind))
+	b = otlp.AppendFixed64(b, fieldSpanStartTime, uint64(span.StartTime.UnixNano()))
+	b = otlp.AppendFixed64(b, fieldSpanEndTime, uint64(span.EndTime.UnixNano()))
+	for _, attr := range span.Attributes {
+		b = otlp.AppendKeyValue(b, fieldSpanAttributes, attr)
+	}
+
+	var status []byte
+	status = otlp.AppendString(status, fieldStatusMessage, span.StatusMessage)
+	if span.StatusCode != StatusCodeUnset {
+		status = otlp.AppendVarint(status, fieldStatusCode, uint64(span.StatusCode))
+	}
+	return otlp.AppendMessage(b, fieldSpanStatus, status)
+}
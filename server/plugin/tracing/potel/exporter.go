@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+)
+
+// BatchProcessor queues the ended spans and exports them in batches,
+// the spans are dropped when the queue is full
+type BatchProcessor struct {
+	exporter  otlp.Exporter
+	resource  *Resource
+	batchSize int
+	interval  time.Duration
+	timeout   time.Duration
+
+	queue   chan *Span
+	flushCh chan chan struct{}
+	once    sync.Once
+}
+
+func NewBatchProcessor(exporter otlp.Exporter, resource *Resource, batchSize int, interval, timeout time.Duration) *BatchProcessor {
+	return &BatchProcessor{
+		exporter:  exporter,
+		resource:  resource,
+		batchSize: batchSize,
+		interval:  interval,
+		timeout:   timeout,
+		queue:     make(chan *Span, batchSize*4),
+		flushCh:   make(chan chan struct{}),
+	}
+}
+
+func (p *BatchProcessor) OnEnd(span *Span) {
+	select {
+	case p.queue <- span:
+	default:
+		log.Warnf("tracing queue is full, drop span '%s'", span.Name)
+	}
+}
+
+func (p *BatchProcessor) Start() {
+	p.once.Do(func() {
+		gopool.Go(p.run)
+	})
+}
+
+// Flush exports the queued spans and waits until they are sent
+func (p *BatchProcessor) Flush() {
+	done := make(chan struct{})
+	p.flushCh <- done
+	<-done
+}
+
+func (p *BatchProcessor) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	batch := make([]*Span, 0, p.batchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		p.export(batch)
+		batch = make([]*Span, 0, p.batchSize)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			export()
+			if err := p.exporter.Shutdown(); err != nil {
+				log.Error("shutdown tracing exporter failed", err)
+			}
+			return
+		case span := <-p.queue:
+			batch = append(batch, span)
+			if len(batch) >= p.batchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case done := <-p.flushCh:
+			for n := len(p.queue); n > 0; n-- {
+				batch = append(batch, <-p.queue)
+			}
+			export()
+			close(done)
+		}
+	}
+}
+
+func (p *BatchProcessor) export(spans []*Span) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	if err := p.exporter.Export(ctx, EncodeExportRequest(p.resource, spans)); err != nil {
+		log.Errorf(err, "export %d spans failed", len(spans))
+	}
+}
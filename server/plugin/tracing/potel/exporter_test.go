@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"github.com/apache/servicecomb-service-center/pkg/otlp/otlptest"
+	"github.com/stretchr/testify/assert"
+)
+
+// spanNames decodes ExportTraceServiceRequest.resource_spans.scope_spans.spans.name
+func spanNames(t *testing.T, c *otlptest.Collector) []string {
+	var names []string
+	for _, request := range c.Requests(otlp.Traces) {
+		values, err := otlptest.Strings(request, fieldExportResourceSpans,
+			fieldResourceSpansScopeSpans, fieldScopeSpansSpans, fieldSpanName)
+		assert.NoError(t, err)
+		names = append(names, values...)
+	}
+	return names
+}
+
+func testExport(t *testing.T, protocol, endpoint string, c *otlptest.Collector) {
+	exporter, err := otlp.NewExporter(protocol, endpoint, otlp.Traces)
+	assert.NoError(t, err)
+	processor := NewBatchProcessor(exporter, &Resource{
+		Attributes: []Attribute{StringAttribute("service.name", "servicecenter")},
+		ScopeName:  scopeName,
+	}, 2, time.Minute, 5*time.Second)
+	processor.Start()
+	tracer := NewTracer(processor, 1)
+
+	root := tracer.Start("api", SpanKindServer, nil)
+	root.SetAttributes(IntAttribute("http.status_code", http.StatusOK))
+	child := tracer.Start("etcd", SpanKindClient, &root.SpanContext)
+	child.SetStatus(StatusCodeError, "timeout")
+	child.End()
+	root.End()
+	tracer.Start("other", SpanKindServer, nil).End()
+	processor.Flush()
+
+	assert.Equal(t, []string{"etcd", "api", "other"}, spanNames(t, c))
+}
+
+func TestBatchProcessor_HTTP(t *testing.T) {
+	c := otlptest.NewCollector()
+	defer c.Stop()
+	testExport(t, otlp.ProtocolHTTP, c.StartHTTP(), c)
+}
+
+func TestBatchProcessor_GRPC(t *testing.T) {
+	c := otlptest.NewCollector()
+	defer c.Stop()
+	endpoint, err := c.StartGRPC()
+	assert.NoError(t, err)
+	testExport(t, otlp.ProtocolGRPC, endpoint, c)
+}
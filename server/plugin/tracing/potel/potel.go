@@ -0,0 +1,251 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/apache/servicecomb-service-center/server/plugin/tracing"
+)
+
+const (
+	scopeName = "github.com/apache/servicecomb-service-center"
+
+	defaultGRPCEndpoint = "127.0.0.1:4317"
+	defaultHTTPEndpoint = "http://127.0.0.1:4318"
+	defaultBatchSize    = 512
+	defaultInterval     = 5 * time.Second
+	defaultTimeout      = 10 * time.Second
+	defaultSamplerRatio = 1
+)
+
+var (
+	once          sync.Once
+	defaultTracer *Tracer
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: tracing.TRACING, Name: "otel", New: New})
+}
+
+func New() plugin.Instance {
+	log.SetTraceFunc(traceIDs)
+	return &OTel{}
+}
+
+// traceIDs returns the ids of the span in the context for logging
+func traceIDs(ctx context.Context) (string, string) {
+	span, ok := ctx.Value(tracing.CtxTraceSpan).(*Span)
+	if !ok || span == nil {
+		return "", ""
+	}
+	return hex.EncodeToString(span.TraceID[:]), hex.EncodeToString(span.SpanID[:])
+}
+
+// SpanProcessor handles the ended spans
+type SpanProcessor interface {
+	OnEnd(span *Span)
+}
+
+// Tracer creates the spans, a span is exported only if it is sampled
+type Tracer struct {
+	processor SpanProcessor
+	ratio     float64
+}
+
+func NewTracer(processor SpanProcessor, ratio float64) *Tracer {
+	return &Tracer{processor: processor, ratio: ratio}
+}
+
+// Start creates a span, it is a root span if parent is nil and inherits the sampling decision of parent otherwise
+func (t *Tracer) Start(name string, kind int, parent *SpanContext) *Span {
+	span := &Span{
+		Name:      name,
+		Kind:      kind,
+		StartTime: time.Now(),
+		tracer:    t,
+	}
+	span.SpanID = newSpanID()
+	if parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+		span.Sampled = parent.Sampled
+		span.TraceState = parent.TraceState
+	} else {
+		span.TraceID = newTraceID()
+		span.Sampled = shouldSample(span.TraceID, t.ratio)
+	}
+	if t.processor == nil {
+		span.Sampled = false
+	}
+	return span
+}
+
+func initTracer() {
+	protocol := config.GetString("tracing.otel.exporter.protocol", otlp.ProtocolGRPC,
+		config.WithENV("OTEL_EXPORTER_OTLP_PROTOCOL"))
+	defaultEndpoint := defaultGRPCEndpoint
+	if protocol != otlp.ProtocolGRPC {
+		defaultEndpoint = defaultHTTPEndpoint
+	}
+	endpoint := config.GetString("tracing.otel.exporter.endpoint", defaultEndpoint,
+		config.WithENV("OTEL_EXPORTER_OTLP_ENDPOINT"))
+	ratio := config.GetFloat64("tracing.otel.sampler.ratio", defaultSamplerRatio,
+		config.WithENV("OTEL_TRACES_SAMPLER_ARG"))
+
+	exporter, err := otlp.NewExporter(protocol, endpoint, otlp.Traces)
+	if err != nil {
+		log.Errorf(err, "new OTLP exporter failed, spans will not be exported")
+		defaultTracer = NewTracer(nil, ratio)
+		return
+	}
+	processor := NewBatchProcessor(exporter, &Resource{
+		Attributes: []Attribute{
+			StringAttribute("service.name", strings.ToLower(core.Service.ServiceName)),
+			StringAttribute("service.version", core.Service.Version),
+			StringAttribute("service.instance.id", metrics.InstanceName()),
+		},
+		ScopeName: scopeName,
+	},
+		config.GetInt("tracing.otel.batch.size", defaultBatchSize),
+		config.GetDuration("tracing.otel.batch.interval", defaultInterval),
+		config.GetDuration("tracing.otel.exporter.timeout", defaultTimeout))
+	processor.Start()
+	defaultTracer = NewTracer(processor, ratio)
+	log.Infof("export spans to OTLP %s endpoint %s, sampler ratio %v", protocol, endpoint, ratio)
+}
+
+func GetTracer() *Tracer {
+	once.Do(initTracer)
+	return defaultTracer
+}
+
+// OTel is the tracing plugin which exports spans by OTLP and
+// propagates the W3C trace context
+type OTel struct {
+	tracer *Tracer
+}
+
+func (o *OTel) getTracer() *Tracer {
+	if o.tracer != nil {
+		return o.tracer
+	}
+	return GetTracer()
+}
+
+func (o *OTel) ServerBegin(operationName string, itf tracing.Request) tracing.Span {
+	r, ok := itf.(*http.Request)
+	if !ok {
+		return nil
+	}
+	var parent *SpanContext
+	if sc, err := ParseTraceParent(r.Header.Get(TraceParentHeader)); err == nil {
+		sc.TraceState = r.Header.Get(TraceStateHeader)
+		parent = &sc
+	}
+	span := o.getTracer().Start(operationName, SpanKindServer, parent)
+	span.SetAttributes(
+		StringAttribute("http.method", r.Method),
+		StringAttribute("http.target", r.URL.Path),
+		StringAttribute("http.url", util.ParseRequestURL(r)),
+		StringAttribute("net.peer.ip", util.GetRealIP(r)),
+	)
+
+	_ = util.SetContext(r.Context(), tracing.CtxTraceSpan, span)
+	return span
+}
+
+func (o *OTel) ServerEnd(itf tracing.Span, code int, message string) {
+	span, ok := itf.(*Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(IntAttribute("http.status_code", int64(code)))
+	if code >= http.StatusInternalServerError {
+		span.SetStatus(StatusCodeError, message)
+	}
+	span.End()
+}
+
+func (o *OTel) ClientBegin(operationName string, itf tracing.Request) tracing.Span {
+	switch r := itf.(type) {
+	case *http.Request:
+		parent, ok := r.Context().Value(tracing.CtxTraceSpan).(*Span)
+		if !ok {
+			return nil
+		}
+		span := o.getTracer().Start(operationName, SpanKindClient, &parent.SpanContext)
+		span.SetAttributes(
+			StringAttribute("http.method", r.Method),
+			StringAttribute("http.url", util.ParseRequestURL(r)),
+		)
+		r.Header.Set(TraceParentHeader, span.TraceParent())
+		if len(span.TraceState) > 0 {
+			r.Header.Set(TraceStateHeader, span.TraceState)
+		}
+		return span
+	case *tracing.Operation:
+		parent, ok := r.Ctx.Value(tracing.CtxTraceSpan).(*Span)
+		if !ok {
+			return nil
+		}
+		span := o.getTracer().Start(operationName, SpanKindClient, &parent.SpanContext)
+		attrs := []Attribute{
+			StringAttribute("db.operation", r.Options.Method()),
+			StringAttribute("db.statement", r.Options.URL()),
+		}
+		if s, ok := r.Options.(interface{ System() string }); ok {
+			attrs = append(attrs, StringAttribute("db.system", s.System()))
+		}
+		if u, err := url.Parse(r.Endpoint); err == nil && len(u.Host) > 0 {
+			attrs = append(attrs, StringAttribute("net.peer.name", u.Host))
+		} else {
+			attrs = append(attrs, StringAttribute("net.peer.name", r.Endpoint))
+		}
+		span.SetAttributes(attrs...)
+		// inject context
+		_ = util.SetContext(r.Ctx, util.CtxKey(TraceParentHeader), span.TraceParent())
+		return span
+	default:
+		return nil
+	}
+}
+
+func (o *OTel) ClientEnd(itf tracing.Span, code int, message string) {
+	span, ok := itf.(*Span)
+	if !ok {
+		return
+	}
+	if code >= http.StatusBadRequest {
+		span.SetStatus(StatusCodeError, message)
+	}
+	span.End()
+}
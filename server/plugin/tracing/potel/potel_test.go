@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client/remote"
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+	"github.com/apache/servicecomb-service-center/pkg/otlp/otlptest"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/plugin/tracing"
+)
+
+func TestOTel_Propagation(t *testing.T) {
+	c := otlptest.NewCollector()
+	defer c.Stop()
+	processor := NewBatchProcessor(otlp.NewHTTPExporter(c.StartHTTP(), otlp.Traces), &Resource{}, 10, time.Minute, 5*time.Second)
+	processor.Start()
+	o := &OTel{tracer: NewTracer(processor, 1)}
+
+	assert.Nil(t, o.ServerBegin("x", nil))
+	assert.Nil(t, o.ClientBegin("x", nil))
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/v4/default/registry/microservices", nil)
+	req = req.WithContext(util.NewStringContext(context.Background()))
+	req.Header.Set(TraceParentHeader, traceParent)
+	req.Header.Set(TraceStateHeader, "k=v")
+
+	t.Run("server span should continue the remote trace", func(t *testing.T) {
+		span := o.ServerBegin("api", req).(*Span)
+		parent, _ := ParseTraceParent(traceParent)
+		assert.Equal(t, parent.TraceID, span.TraceID)
+		assert.Equal(t, parent.SpanID, span.ParentSpanID)
+		assert.Equal(t, "k=v", span.TraceState)
+		assert.Equal(t, span, req.Context().Value(tracing.CtxTraceSpan))
+	})
+
+	server0 := req.Context().Value(tracing.CtxTraceSpan).(*Span)
+
+	t.Run("logs should be correlated with the server span", func(t *testing.T) {
+		traceID, spanID := traceIDs(req.Context())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+		assert.Equal(t, hex.EncodeToString(server0.SpanID[:]), spanID)
+
+		traceID, _ = traceIDs(context.Background())
+		assert.Empty(t, traceID)
+	})
+
+	t.Run("client span should inject the traceparent header", func(t *testing.T) {
+		out, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:30100", nil)
+		assert.Nil(t, o.ClientBegin("x", out))
+
+		out = out.WithContext(req.Context())
+		span := o.ClientBegin("x", out).(*Span)
+		assert.Equal(t, server0.TraceID, span.TraceID)
+		assert.Equal(t, server0.SpanID, span.ParentSpanID)
+		assert.Equal(t, span.TraceParent(), out.Header.Get(TraceParentHeader))
+		assert.Equal(t, "k=v", out.Header.Get(TraceStateHeader))
+		o.ClientEnd(span, http.StatusOK, "")
+	})
+
+	t.Run("datasource span should be the child of server span", func(t *testing.T) {
+		span := o.ClientBegin("etcd", &tracing.Operation{
+			Ctx:      req.Context(),
+			Options:  &remote.EtcdOptions{PluginOp: client.OpGet(client.WithStrKey("/x"))},
+			Endpoint: "http://127.0.0.1:2379",
+		}).(*Span)
+		assert.Equal(t, server0.SpanID, span.ParentSpanID)
+		assert.Contains(t, span.Attributes, StringAttribute("db.system", "etcd"))
+		assert.Contains(t, span.Attributes, StringAttribute("net.peer.name", "127.0.0.1:2379"))
+		o.ClientEnd(span, http.StatusInternalServerError, "timeout")
+		assert.Equal(t, StatusCodeError, span.StatusCode)
+	})
+
+	o.ServerEnd(server0, http.StatusOK, "")
+	assert.Equal(t, StatusCodeUnset, server0.StatusCode)
+	processor.Flush()
+	assert.Equal(t, []string{"x", "etcd", "api"}, spanNames(t, c))
+}
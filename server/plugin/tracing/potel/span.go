@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
+)
+
+const (
+	// TraceParentHeader and TraceStateHeader are the W3C trace context headers
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+
+	traceParentVersion = "00"
+	flagSampled        = 0x01
+)
+
+// the span kinds defined by OTLP
+const (
+	SpanKindServer = 2
+	SpanKindClient = 3
+)
+
+// the status codes defined by OTLP
+const (
+	StatusCodeUnset = 0
+	StatusCodeError = 2
+)
+
+var ErrInvalidTraceParent = errors.New("invalid traceparent")
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// SpanContext is the part of span propagated across process boundaries
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Sampled    bool
+	TraceState string
+}
+
+// TraceParent formats the span context as the W3C traceparent header value
+func (sc SpanContext) TraceParent() string {
+	flags := byte(0)
+	if sc.Sampled {
+		flags = flagSampled
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion,
+		hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), flags)
+}
+
+// ParseTraceParent parses the W3C traceparent header value
+func ParseTraceParent(s string) (sc SpanContext, err error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, ErrInvalidTraceParent
+	}
+	if parts[0] == traceParentVersion && len(parts) != 4 {
+		return sc, ErrInvalidTraceParent
+	}
+	if _, err = hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, ErrInvalidTraceParent
+	}
+	if _, err = hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, ErrInvalidTraceParent
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, ErrInvalidTraceParent
+	}
+	if !sc.TraceID.IsValid() || !sc.SpanID.IsValid() {
+		return sc, ErrInvalidTraceParent
+	}
+	sc.Sampled = flags[0]&flagSampled != 0
+	return sc, nil
+}
+
+type Attribute = otlp.KeyValue
+
+func StringAttribute(key, value string) Attribute {
+	return otlp.String(key, value)
+}
+
+func IntAttribute(key string, value int64) Attribute {
+	return otlp.Int(key, value)
+}
+
+type Span struct {
+	SpanContext
+	ParentSpanID  SpanID
+	Name          string
+	Kind          int
+	StartTime     time.Time
+	EndTime       time.Time
+	Attributes    []Attribute
+	StatusCode    int
+	StatusMessage string
+
+	lock   sync.Mutex
+	ended  bool
+	tracer *Tracer
+}
+
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	s.lock.Lock()
+	s.Attributes = append(s.Attributes, attrs...)
+	s.lock.Unlock()
+}
+
+func (s *Span) SetStatus(code int, message string) {
+	s.lock.Lock()
+	s.StatusCode, s.StatusMessage = code, message
+	s.lock.Unlock()
+}
+
+// End records the end time and exports the sampled span, it is safe to call End more than once
+func (s *Span) End() {
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	s.lock.Unlock()
+	if s.Sampled && s.tracer != nil {
+		s.tracer.processor.OnEnd(s)
+	}
+}
+
+func newTraceID() (t TraceID) {
+	_, _ = rand.Read(t[:])
+	return
+}
+
+func newSpanID() (s SpanID) {
+	_, _ = rand.Read(s[:])
+	return
+}
+
+// shouldSample is the trace id ratio based sampler, the same trace id gets the same result
+func shouldSample(traceID TraceID, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	bound := uint64(ratio * (1 << 63))
+	return binary.BigEndian.Uint64(traceID[8:16])>>1 < bound
+}
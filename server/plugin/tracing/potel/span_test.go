@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package potel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	sc, err := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+	assert.True(t, sc.Sampled)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sc.TraceParent())
+
+	sc, err = ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.NoError(t, err)
+	assert.False(t, sc.Sampled)
+
+	for _, s := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		_, err = ParseTraceParent(s)
+		assert.Equal(t, ErrInvalidTraceParent, err, s)
+	}
+}
+
+func TestTracer_Start(t *testing.T) {
+	tracer := NewTracer(&recorder{}, 1)
+	root := tracer.Start("root", SpanKindServer, nil)
+	assert.True(t, root.TraceID.IsValid())
+	assert.True(t, root.Sampled)
+	assert.False(t, root.ParentSpanID.IsValid())
+
+	child := tracer.Start("child", SpanKindClient, &root.SpanContext)
+	assert.Equal(t, root.TraceID, child.TraceID)
+	assert.Equal(t, root.SpanID, child.ParentSpanID)
+	assert.NotEqual(t, root.SpanID, child.SpanID)
+
+	tracer = NewTracer(&recorder{}, 0)
+	assert.False(t, tracer.Start("root", SpanKindServer, nil).Sampled)
+	// inherits the decision of the remote parent
+	assert.True(t, tracer.Start("child", SpanKindServer, &root.SpanContext).Sampled)
+
+	tracer = NewTracer(nil, 1)
+	assert.False(t, tracer.Start("root", SpanKindServer, nil).Sampled)
+}
+
+func TestSpan_End(t *testing.T) {
+	r := &recorder{}
+	tracer := NewTracer(r, 1)
+	span := tracer.Start("x", SpanKindServer, nil)
+	span.End()
+	span.End()
+	assert.Equal(t, 1, len(r.spans))
+	assert.False(t, span.EndTime.Before(span.StartTime))
+
+	tracer = NewTracer(r, 0)
+	tracer.Start("x", SpanKindServer, nil).End()
+	assert.Equal(t, 1, len(r.spans))
+}
+
+type recorder struct {
+	spans []*Span
+}
+
+func (r *recorder) OnEnd(span *Span) {
+	r.spans = append(r.spans, span)
+}
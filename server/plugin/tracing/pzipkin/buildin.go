@@ -19,6 +19,7 @@ package pzipkin
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"sync"
@@ -30,6 +31,7 @@ import (
 	"github.com/apache/servicecomb-service-center/server/plugin/tracing"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	zipkin "github.com/openzipkin/zipkin-go-opentracing"
 	"github.com/openzipkin/zipkin-go-opentracing/thrift/gen-go/zipkincore"
 )
 
@@ -40,9 +42,23 @@ func init() {
 }
 
 func New() plugin.Instance {
+	log.SetTraceFunc(traceIDs)
 	return &Zipkin{}
 }
 
+// traceIDs returns the ids of the span in the context for logging
+func traceIDs(ctx context.Context) (string, string) {
+	span, ok := ctx.Value(tracing.CtxTraceSpan).(opentracing.Span)
+	if !ok || span == nil {
+		return "", ""
+	}
+	sc, ok := span.Context().(zipkin.SpanContext)
+	if !ok {
+		return "", ""
+	}
+	return sc.TraceID.ToHex(), fmt.Sprintf("%016x", sc.SpanID)
+}
+
 type Zipkin struct {
 }
 
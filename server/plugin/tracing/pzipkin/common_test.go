@@ -30,9 +30,10 @@ func TestGetFilePath(t *testing.T) {
 	if f != filepath.Join(wd, "a") {
 		t.Fatalf("TestGetFilePath failed, %v", f)
 	}
-	archaius.Set(fileCollectorPath, "trace.log")
+	path := filepath.Join(t.TempDir(), "trace.log")
+	archaius.Set(fileCollectorPath, path)
 	f = GetFilePath("a")
-	if f != "trace.log" {
+	if f != path {
 		t.Fatalf("TestGetFilePath failed, %v", f)
 	}
 }
@@ -70,6 +71,7 @@ func TestNewCollector(t *testing.T) {
 		t.Fatalf("TestNewCollector failed")
 	}
 	archaius.Set(collectorType, "file")
+	archaius.Set(fileCollectorPath, filepath.Join(t.TempDir(), "trace.log"))
 	tracer, err = newCollector()
 	if err != nil {
 		t.Fatalf("TestNewCollector failed")
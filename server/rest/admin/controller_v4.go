@@ -18,9 +18,15 @@
 package admin
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/go-chassis/cari/discovery"
 
 	"strings"
 
@@ -38,6 +44,15 @@ func (ctrl *ControllerV4) URLPatterns() []rest.Route {
 		{Method: http.MethodDelete, Path: "/v4/:project/admin/alarms", Func: ctrl.ClearAlarm},
 		{Method: http.MethodGet, Path: "/v4/:project/admin/dump", Func: ctrl.Dump},
 		{Method: http.MethodGet, Path: "/v4/:project/admin/clusters", Func: ctrl.Clusters},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/microservices/:serviceId/weight", Func: ctrl.UpdateWeight},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/microservices/:serviceId/instances/:instanceId/weight", Func: ctrl.UpdateWeight},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/traffic", Func: ctrl.SplitTraffic},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/microservices/:serviceId/leases", Func: ctrl.Leases},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/microservices/:serviceId/instances/:instanceId/lease", Func: ctrl.UpdateLease},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/talkers", Func: ctrl.TopTalkers},
+		{Method: http.MethodPost, Path: "/v4/:project/admin/config/reload", Func: ctrl.ReloadConfig},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/loggers", Func: ctrl.Loggers},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/loggers/:name", Func: ctrl.UpdateLogger},
 	}
 }
 
@@ -75,3 +90,142 @@ func (ctrl *ControllerV4) ClearAlarm(w http.ResponseWriter, r *http.Request) {
 	resp, _ := AdminServiceAPI.ClearAlarm(ctx, request)
 	rest.WriteResponse(w, r, resp.Response, nil)
 }
+
+func (ctrl *ControllerV4) UpdateWeight(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	request := &dump.UpdateWeightRequest{
+		ServiceID:  query.Get(":serviceId"),
+		InstanceID: query.Get(":instanceId"),
+	}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, discovery.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	resp, err := AdminServiceAPI.UpdateWeight(r.Context(), request)
+	if err != nil {
+		log.Errorf(err, "can not update weight")
+		rest.WriteError(w, discovery.ErrInternal, "can not update weight")
+		return
+	}
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) SplitTraffic(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	request := &dump.SplitTrafficRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, discovery.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	resp, err := AdminServiceAPI.SplitTraffic(r.Context(), request)
+	if err != nil {
+		log.Errorf(err, "can not split traffic")
+		rest.WriteError(w, discovery.ErrInternal, "can not split traffic")
+		return
+	}
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) Leases(w http.ResponseWriter, r *http.Request) {
+	request := &dump.LeasesRequest{
+		ServiceID: r.URL.Query().Get(":serviceId"),
+	}
+	resp, err := AdminServiceAPI.Leases(r.Context(), request)
+	if err != nil {
+		log.Errorf(err, "can not list leases")
+		rest.WriteError(w, discovery.ErrInternal, "can not list leases")
+		return
+	}
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) UpdateLease(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	request := &dump.UpdateLeaseRequest{
+		ServiceID:  query.Get(":serviceId"),
+		InstanceID: query.Get(":instanceId"),
+	}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, discovery.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	resp, err := AdminServiceAPI.UpdateLease(r.Context(), request)
+	if err != nil {
+		log.Errorf(err, "can not update lease")
+		rest.WriteError(w, discovery.ErrInternal, "can not update lease")
+		return
+	}
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) TopTalkers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &dump.TopTalkersRequest{
+		By: query.Get("by"),
+	}
+	if s := query.Get("top"); len(s) > 0 {
+		top, err := strconv.Atoi(s)
+		if err != nil {
+			rest.WriteError(w, discovery.ErrInvalidParams, "top must be an integer")
+			return
+		}
+		request.Top = top
+	}
+	resp, _ := AdminServiceAPI.TopTalkers(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	request := &dump.ReloadConfigRequest{}
+	resp, _ := AdminServiceAPI.ReloadConfig(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) Loggers(w http.ResponseWriter, r *http.Request) {
+	request := &dump.LoggersRequest{}
+	resp, _ := AdminServiceAPI.Loggers(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) UpdateLogger(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	request := &dump.UpdateLoggerRequest{
+		Name: r.URL.Query().Get(":name"),
+	}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, discovery.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	resp, _ := AdminServiceAPI.UpdateLogger(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
@@ -19,15 +19,23 @@ package admin
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/alarm"
+	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/apache/servicecomb-service-center/server/metrics"
 	"github.com/apache/servicecomb-service-center/version"
 	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
 	"github.com/go-chassis/go-archaius"
 )
 
@@ -78,10 +86,13 @@ func (service *Service) dump(ctx context.Context, option string, resp *dump.Resp
 		resp.AppConfig = archaius.GetConfigs()
 	case "cache":
 		resp.Cache = datasource.Instance().DumpCache(ctx)
+	case "preservation":
+		resp.SelfPreservation = datasource.Instance().DumpSelfPreservation(ctx)
 	case "all":
 		service.dump(ctx, "info", resp)
 		service.dump(ctx, "config", resp)
 		service.dump(ctx, "cache", resp)
+		service.dump(ctx, "preservation", resp)
 	}
 }
 
@@ -106,3 +117,302 @@ func (service *Service) ClearAlarm(ctx context.Context, in *dump.ClearAlarmReque
 	log.Infof("service center alarms are cleared")
 	return &dump.ClearAlarmResponse{}, nil
 }
+
+// UpdateWeight updates the weight property of the instance,
+// or all instances of the service if the instance id is empty
+func (service *Service) UpdateWeight(ctx context.Context, in *dump.UpdateWeightRequest) (*dump.UpdateWeightResponse, error) {
+	if len(in.ServiceID) == 0 {
+		return &dump.UpdateWeightResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, "ServiceID is required"),
+		}, nil
+	}
+	if in.Weight < 0 || in.Weight > lb.MaxWeight {
+		return &dump.UpdateWeightResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams,
+				fmt.Sprintf("Weight must be an integer between 0 and %d", lb.MaxWeight)),
+		}, nil
+	}
+
+	instancesResp, err := datasource.Instance().GetInstances(ctx, &discovery.GetInstancesRequest{
+		ProviderServiceId: in.ServiceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if instancesResp.Response != nil && !instancesResp.Response.IsSucceed() {
+		return &dump.UpdateWeightResponse{Response: instancesResp.Response}, nil
+	}
+
+	resp := &dump.UpdateWeightResponse{}
+	for _, instance := range instancesResp.Instances {
+		if len(in.InstanceID) > 0 && instance.InstanceId != in.InstanceID {
+			continue
+		}
+		if updateResp, err := updateInstanceWeight(ctx, instance, in.Weight); err != nil || !updateResp.IsSucceed() {
+			return &dump.UpdateWeightResponse{Response: updateResp}, err
+		}
+		resp.Updated = append(resp.Updated, instance.InstanceId)
+	}
+
+	if len(in.InstanceID) > 0 && len(resp.Updated) == 0 {
+		return &dump.UpdateWeightResponse{
+			Response: discovery.CreateResponse(discovery.ErrInstanceNotExists, "Service instance does not exist."),
+		}, nil
+	}
+	log.Infof("update service[%s] instances%v weight to %d", in.ServiceID, resp.Updated, in.Weight)
+	resp.Response = discovery.CreateResponse(discovery.ResponseSuccess, "Update weight successfully.")
+	return resp, nil
+}
+
+func (service *Service) Leases(ctx context.Context, in *dump.LeasesRequest) (*dump.LeasesResponse, error) {
+	leases, err := datasource.Instance().ListLeases(ctx, in.ServiceID)
+	if err != nil {
+		return toLeaseErrorResponse(err)
+	}
+	return &dump.LeasesResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "List leases successfully."),
+		Leases:   leases,
+	}, nil
+}
+
+// UpdateLease force expires or extends the instance lease
+func (service *Service) UpdateLease(ctx context.Context, in *dump.UpdateLeaseRequest) (*dump.LeasesResponse, error) {
+	var (
+		lease *dump.Lease
+		err   error
+	)
+	switch in.Action {
+	case dump.LeaseActionExpire:
+		err = datasource.Instance().ExpireLease(ctx, in.ServiceID, in.InstanceID)
+	case dump.LeaseActionExtend:
+		lease, err = datasource.Instance().ExtendLease(ctx, in.ServiceID, in.InstanceID)
+	default:
+		return &dump.LeasesResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams,
+				fmt.Sprintf("Action must be one of '%s' and '%s'", dump.LeaseActionExpire, dump.LeaseActionExtend)),
+		}, nil
+	}
+	if err != nil {
+		return toLeaseErrorResponse(err)
+	}
+	resp := &dump.LeasesResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Update lease successfully."),
+	}
+	if lease != nil {
+		resp.Leases = []*dump.Lease{lease}
+	}
+	return resp, nil
+}
+
+func toLeaseErrorResponse(err error) (*dump.LeasesResponse, error) {
+	scErr, ok := err.(*errsvc.Error)
+	if !ok {
+		return nil, err
+	}
+	resp := &dump.LeasesResponse{
+		Response: discovery.CreateResponseWithSCErr(scErr),
+	}
+	if scErr.InternalError() {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// SplitTraffic updates the weights of the instances of each service version,
+// makes the traffic of the version proportional to its percentage, the
+// versions not in the request are not changed
+func (service *Service) SplitTraffic(ctx context.Context, in *dump.SplitTrafficRequest) (*dump.UpdateWeightResponse, error) {
+	if len(in.AppID) == 0 || len(in.ServiceName) == 0 || len(in.Percents) == 0 {
+		return &dump.UpdateWeightResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, "AppId, serviceName and percents are required"),
+		}, nil
+	}
+	sum := 0
+	versions := make([]string, 0, len(in.Percents))
+	for version, percent := range in.Percents {
+		if percent < 0 || percent > 100 {
+			sum = -1
+			break
+		}
+		sum += percent
+		versions = append(versions, version)
+	}
+	if sum != 100 {
+		return &dump.UpdateWeightResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, "The sum of percents must be 100"),
+		}, nil
+	}
+	sort.Strings(versions)
+
+	resp := &dump.UpdateWeightResponse{}
+	for _, version := range versions {
+		existResp, err := datasource.Instance().ExistService(ctx, &discovery.GetExistenceRequest{
+			Type:        discovery.ExistenceMicroservice,
+			Environment: in.Environment,
+			AppId:       in.AppID,
+			ServiceName: in.ServiceName,
+			Version:     version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !existResp.Response.IsSucceed() {
+			return &dump.UpdateWeightResponse{Response: existResp.Response}, nil
+		}
+		instancesResp, err := datasource.Instance().GetInstances(ctx, &discovery.GetInstancesRequest{
+			ProviderServiceId: existResp.ServiceId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if instancesResp.Response != nil && !instancesResp.Response.IsSucceed() {
+			return &dump.UpdateWeightResponse{Response: instancesResp.Response}, nil
+		}
+
+		weights := make([]int, 0, len(instancesResp.Instances))
+		for _, instance := range instancesResp.Instances {
+			w, err := lb.ParseWeight(instance.Properties)
+			if err != nil {
+				w = lb.DefaultWeight
+			}
+			weights = append(weights, w)
+		}
+		for i, w := range lb.SplitWeights(in.Percents[version], weights) {
+			instance := instancesResp.Instances[i]
+			if updateResp, err := updateInstanceWeight(ctx, instance, w); err != nil || !updateResp.IsSucceed() {
+				return &dump.UpdateWeightResponse{Response: updateResp}, err
+			}
+			resp.Updated = append(resp.Updated, instance.InstanceId)
+		}
+	}
+	log.Infof("split service[%s/%s/%s] traffic %v, instances%v are updated",
+		in.Environment, in.AppID, in.ServiceName, in.Percents, resp.Updated)
+	resp.Response = discovery.CreateResponse(discovery.ResponseSuccess, "Split traffic successfully.")
+	return resp, nil
+}
+
+func updateInstanceWeight(ctx context.Context, instance *discovery.MicroServiceInstance, weight int) (*discovery.Response, error) {
+	properties := make(map[string]string, len(instance.Properties)+1)
+	for k, v := range instance.Properties {
+		properties[k] = v
+	}
+	properties[lb.PropWeight] = strconv.Itoa(weight)
+	resp, err := datasource.Instance().UpdateInstanceProperties(ctx, &discovery.UpdateInstancePropsRequest{
+		ServiceId:  instance.ServiceId,
+		InstanceId: instance.InstanceId,
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Response, nil
+}
+
+const (
+	defaultTopTalkers = 10
+	maxTopTalkers     = 100
+)
+
+// TopTalkers lists the heaviest domains, consumers and source ips in the sliding window
+func (service *Service) TopTalkers(ctx context.Context, in *dump.TopTalkersRequest) (*dump.TopTalkersResponse, error) {
+	top := in.Top
+	if top <= 0 {
+		top = defaultTopTalkers
+	}
+	if top > maxTopTalkers {
+		top = maxTopTalkers
+	}
+	dimensions := []string{metrics.TalkerDomain, metrics.TalkerConsumer, metrics.TalkerIP}
+	if len(in.By) > 0 {
+		dimensions = []string{in.By}
+	}
+
+	resp := &dump.TopTalkersResponse{}
+	for _, dimension := range dimensions {
+		counts, window, ok := metrics.TopTalkers(dimension, top)
+		if !ok {
+			return &dump.TopTalkersResponse{
+				Response: discovery.CreateResponse(discovery.ErrInvalidParams,
+					fmt.Sprintf("By must be one of '%s', '%s' and '%s'",
+						metrics.TalkerDomain, metrics.TalkerConsumer, metrics.TalkerIP)),
+			}, nil
+		}
+		talkers := make([]*dump.Talker, 0, len(counts))
+		for _, c := range counts {
+			talkers = append(talkers, &dump.Talker{Key: c.Key, Count: c.Count})
+		}
+		switch dimension {
+		case metrics.TalkerDomain:
+			resp.Domains = talkers
+		case metrics.TalkerConsumer:
+			resp.Consumers = talkers
+		case metrics.TalkerIP:
+			resp.IPs = talkers
+		}
+		resp.Window = window.String()
+	}
+	resp.Response = discovery.CreateResponse(discovery.ResponseSuccess, "List top talkers successfully.")
+	return resp, nil
+}
+
+// ReloadConfig reloads conf/app.yaml and notifies the subsystems of the changed configs
+func (service *Service) ReloadConfig(ctx context.Context, in *dump.ReloadConfigRequest) (*dump.ReloadConfigResponse, error) {
+	result, err := config.Refresh()
+	if err != nil {
+		log.Errorf(err, "reload configs failed")
+		return &dump.ReloadConfigResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	resp := &dump.ReloadConfigResponse{Changed: result.Changed}
+	for _, cb := range result.Callbacks {
+		resp.Callbacks = append(resp.Callbacks, &dump.ConfigCallback{Name: cb.Name, Error: cb.Error})
+	}
+	resp.Response = discovery.CreateResponse(discovery.ResponseSuccess, "Reload configs successfully.")
+	return resp, nil
+}
+
+// Loggers lists the levels of the global logger and the module loggers
+func (service *Service) Loggers(ctx context.Context, in *dump.LoggersRequest) (*dump.LoggersResponse, error) {
+	resp := &dump.LoggersResponse{}
+	for _, l := range log.Levels() {
+		logger := &dump.Logger{Name: l.Name, Level: l.Level, Inherited: l.Inherited}
+		if !l.RevertAt.IsZero() {
+			logger.RevertAt = l.RevertAt.Format(time.RFC3339)
+		}
+		resp.Loggers = append(resp.Loggers, logger)
+	}
+	resp.Response = discovery.CreateResponse(discovery.ResponseSuccess, "List loggers successfully.")
+	return resp, nil
+}
+
+// UpdateLogger changes the level of a logger at runtime, the level reverts after the duration if specified
+func (service *Service) UpdateLogger(ctx context.Context, in *dump.UpdateLoggerRequest) (*dump.LoggersResponse, error) {
+	var (
+		d   time.Duration
+		err error
+	)
+	if len(in.Duration) > 0 {
+		d, err = time.ParseDuration(in.Duration)
+		if err != nil || d <= 0 {
+			return &dump.LoggersResponse{
+				Response: discovery.CreateResponse(discovery.ErrInvalidParams, "Invalid duration."),
+			}, nil
+		}
+	}
+	err = log.SetModuleLevel(in.Name, in.Level, d)
+	if err == log.ErrModuleNotFound {
+		return &dump.LoggersResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, fmt.Sprintf("Logger '%s' not found.", in.Name)),
+		}, nil
+	}
+	if err != nil {
+		return &dump.LoggersResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	log.Warnf("logger[%s] level changed to '%s', duration: '%s'", in.Name, in.Level, in.Duration)
+	resp, _ := service.Loggers(ctx, &dump.LoggersRequest{})
+	resp.Response = discovery.CreateResponse(discovery.ResponseSuccess, "Update logger successfully.")
+	return resp, nil
+}
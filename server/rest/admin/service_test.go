@@ -22,7 +22,9 @@ import (
 	"testing"
 
 	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/metrics"
 	"github.com/apache/servicecomb-service-center/server/rest/admin"
 	_ "github.com/apache/servicecomb-service-center/test"
 	"github.com/astaxie/beego"
@@ -46,6 +48,114 @@ func TestAdminService_Dump(t *testing.T) {
 	assert.Equal(t, discovery.ErrForbidden, resp.Response.GetCode())
 }
 
+func TestAdminService_SplitTraffic(t *testing.T) {
+	t.Log("execute 'split traffic' operation,when the sum of percents is not 100,should be failed")
+	resp, err := admin.AdminServiceAPI.SplitTraffic(getContext(), &dump.SplitTrafficRequest{
+		AppID:       "default",
+		ServiceName: "split_traffic",
+		Percents:    map[string]int{"1.0.0": 90, "2.0.0": 5},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+
+	t.Log("execute 'split traffic' operation,when the version does not exist,should be failed")
+	resp, err = admin.AdminServiceAPI.SplitTraffic(getContext(), &dump.SplitTrafficRequest{
+		AppID:       "default",
+		ServiceName: "split_traffic",
+		Percents:    map[string]int{"1.0.0": 95, "2.0.0": 5},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrServiceNotExists, resp.Response.GetCode())
+}
+
 func getContext() context.Context {
 	return util.WithNoCache(util.SetDomainProject(context.Background(), "default", "default"))
 }
+
+func TestAdminService_TopTalkers(t *testing.T) {
+	ctx := util.SetContext(util.SetDomainProject(context.Background(), "talker", "talker"),
+		util.CtxConsumerID, "consumer")
+	ctx = util.SetContext(ctx, util.CtxRemoteIP, "127.0.0.2")
+	for i := 0; i < 3; i++ {
+		metrics.ReportTenantRequest(ctx)
+	}
+
+	t.Log("execute 'top talkers' operation,when list all dimensions,should be passed")
+	resp, err := admin.AdminServiceAPI.TopTalkers(getContext(), &dump.TopTalkersRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, resp.Response.GetCode())
+	assert.Contains(t, resp.Domains, &dump.Talker{Key: "talker/talker", Count: 3})
+	assert.Contains(t, resp.Consumers, &dump.Talker{Key: "consumer", Count: 3})
+	assert.Contains(t, resp.IPs, &dump.Talker{Key: "127.0.0.2", Count: 3})
+
+	t.Log("execute 'top talkers' operation,when list by ip,should be passed")
+	resp, err = admin.AdminServiceAPI.TopTalkers(getContext(), &dump.TopTalkersRequest{By: "ip", Top: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.IPs))
+	assert.Empty(t, resp.Domains)
+
+	t.Log("execute 'top talkers' operation,when the dimension is invalid,should be failed")
+	resp, err = admin.AdminServiceAPI.TopTalkers(getContext(), &dump.TopTalkersRequest{By: "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+}
+
+func TestAdminService_UpdateWeight(t *testing.T) {
+	t.Log("execute 'update weight' operation,when the service id is empty,should be failed")
+	resp, err := admin.AdminServiceAPI.UpdateWeight(getContext(), &dump.UpdateWeightRequest{Weight: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+
+	t.Log("execute 'update weight' operation,when the weight is out of range,should be failed")
+	resp, err = admin.AdminServiceAPI.UpdateWeight(getContext(), &dump.UpdateWeightRequest{ServiceID: "x", Weight: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+}
+
+func TestAdminService_ReloadConfig(t *testing.T) {
+	t.Log("execute 'reload config' operation,when the configs not changed,should be passed")
+	resp, err := admin.AdminServiceAPI.ReloadConfig(getContext(), &dump.ReloadConfigRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, resp.Response.GetCode())
+	assert.Empty(t, resp.Changed)
+	assert.Empty(t, resp.Callbacks)
+}
+
+func TestAdminService_UpdateLogger(t *testing.T) {
+	t.Log("execute 'update logger' operation,when the module exists,should be passed")
+	resp, err := admin.AdminServiceAPI.UpdateLogger(getContext(), &dump.UpdateLoggerRequest{
+		Name: log.ModuleDatasource, Level: "DEBUG", Duration: "1m",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, resp.Response.GetCode())
+	for _, l := range resp.Loggers {
+		if l.Name == log.ModuleDatasource {
+			assert.Equal(t, "DEBUG", l.Level)
+			assert.False(t, l.Inherited)
+			assert.NotEmpty(t, l.RevertAt)
+		}
+	}
+
+	t.Log("execute 'update logger' operation,when reset the level,should inherit the global level")
+	resp, err = admin.AdminServiceAPI.UpdateLogger(getContext(), &dump.UpdateLoggerRequest{Name: log.ModuleDatasource})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, resp.Response.GetCode())
+	for _, l := range resp.Loggers {
+		if l.Name == log.ModuleDatasource {
+			assert.True(t, l.Inherited)
+			assert.Empty(t, l.RevertAt)
+		}
+	}
+
+	t.Log("execute 'update logger' operation,when the module does not exist,should be failed")
+	resp, err = admin.AdminServiceAPI.UpdateLogger(getContext(), &dump.UpdateLoggerRequest{Name: "x", Level: "DEBUG"})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+
+	t.Log("execute 'update logger' operation,when the duration is invalid,should be failed")
+	resp, err = admin.AdminServiceAPI.UpdateLogger(getContext(), &dump.UpdateLoggerRequest{
+		Name: log.ModuleDatasource, Level: "DEBUG", Duration: "-1s",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+}
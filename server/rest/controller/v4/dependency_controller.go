@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/core"
@@ -38,6 +39,7 @@ func (s *DependencyService) URLPatterns() []rest.Route {
 		{Method: http.MethodPut, Path: "/v4/:project/registry/dependencies", Func: s.CreateDependenciesForMicroServices},
 		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:consumerId/providers", Func: s.GetConProDependencies},
 		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:providerId/consumers", Func: s.GetProConDependencies},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/impact", Func: s.GetImpact},
 	}
 }
 
@@ -110,3 +112,16 @@ func (s *DependencyService) GetProConDependencies(w http.ResponseWriter, r *http
 	resp, _ := core.ServiceAPI.GetProviderDependencies(r.Context(), request)
 	rest.WriteResponse(w, r, resp.Response, resp)
 }
+
+func (s *DependencyService) GetImpact(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &proto.ImpactRequest{
+		ServiceId:   query.Get("serviceId"),
+		Environment: query.Get("env"),
+		AppId:       query.Get("appId"),
+		ServiceName: query.Get("serviceName"),
+		VersionRule: query.Get("version"),
+	}
+	resp, _ := core.ServiceAPI.GetImpact(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v4
+
+import (
+	"net/http"
+	"strconv"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/server/connection/evws"
+	"github.com/apache/servicecomb-service-center/server/handler/exception"
+)
+
+const APIRegistryEvents = "/v4/:project/registry/events"
+
+func init() {
+	exception.RegisterWhitelist(http.MethodGet, APIRegistryEvents)
+}
+
+type EventWatchService struct {
+	//
+}
+
+func (s *EventWatchService) URLPatterns() []rest.Route {
+	return []rest.Route{
+		{Method: http.MethodGet, Path: APIRegistryEvents, Func: s.Watch},
+	}
+}
+
+// Watch streams the registry events after the revision in query 'rev',
+// or from now on if it is not specified
+func (s *EventWatchService) Watch(w http.ResponseWriter, r *http.Request) {
+	var rev int64
+	if v := r.URL.Query().Get("rev"); len(v) > 0 {
+		var err error
+		rev, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || rev < 0 {
+			rest.WriteError(w, pb.ErrInvalidParams, "invalid revision "+v)
+			return
+		}
+	}
+
+	conn, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	evws.Stream(r.Context(), conn, rev)
+}
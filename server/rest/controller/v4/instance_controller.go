@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/core"
@@ -47,6 +49,8 @@ func (s *MicroServiceInstanceService) URLPatterns() []rest.Route {
 		{Method: http.MethodPut, Path: "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/status", Func: s.UpdateStatus},
 		{Method: http.MethodPut, Path: "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat", Func: s.Heartbeat},
 		{Method: http.MethodPut, Path: "/v4/:project/registry/heartbeats", Func: s.HeartbeatSet},
+		{Method: http.MethodPost, Path: "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/drain", Func: s.Drain},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/drain", Func: s.GetDrainStatus},
 	}
 }
 func (s *MicroServiceInstanceService) RegisterInstance(w http.ResponseWriter, r *http.Request) {
@@ -171,6 +175,40 @@ func (s *MicroServiceInstanceService) InstancesAction(w http.ResponseWriter, r *
 		ctx := util.SetTargetDomainProject(r.Context(), r.Header.Get("X-Domain-Name"), r.URL.Query().Get(":project"))
 		resp, _ := core.InstanceAPI.BatchFind(ctx, request)
 		rest.WriteResponse(w, r, resp.Response, resp)
+	case "register":
+		request := &proto.BatchRegisterInstancesRequest{}
+		err = json.Unmarshal(message, request)
+		if err != nil {
+			log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+			rest.WriteError(w, pb.ErrInvalidParams, "Unmarshal error")
+			return
+		}
+		resp, err := core.InstanceAPI.BatchRegister(r.Context(), request)
+		if err != nil {
+			log.Errorf(err, "batch register instances failed")
+		}
+		if resp == nil {
+			rest.WriteError(w, pb.ErrInternal, "batch register instances failed")
+			return
+		}
+		rest.WriteResponse(w, r, resp.Response, resp)
+	case "unregister":
+		request := &proto.BatchUnregisterInstancesRequest{}
+		err = json.Unmarshal(message, request)
+		if err != nil {
+			log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+			rest.WriteError(w, pb.ErrInvalidParams, "Unmarshal error")
+			return
+		}
+		resp, err := core.InstanceAPI.BatchUnregister(r.Context(), request)
+		if err != nil {
+			log.Errorf(err, "batch unregister instances failed")
+		}
+		if resp == nil {
+			rest.WriteError(w, pb.ErrInternal, "batch unregister instances failed")
+			return
+		}
+		rest.WriteResponse(w, r, resp.Response, resp)
 	default:
 		err = fmt.Errorf("Invalid action: %s", action)
 		log.Errorf(err, "invalid request")
@@ -269,3 +307,31 @@ func (s *MicroServiceInstanceService) UpdateMetadata(w http.ResponseWriter, r *h
 	}
 	rest.WriteResponse(w, r, resp.Response, nil)
 }
+
+func (s *MicroServiceInstanceService) Drain(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &proto.DrainInstanceRequest{
+		ServiceId:  query.Get(":serviceId"),
+		InstanceId: query.Get(":instanceId"),
+	}
+	if v := query.Get("timeout"); len(v) > 0 {
+		timeout, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			rest.WriteError(w, pb.ErrInvalidParams, "invalid timeout")
+			return
+		}
+		request.Timeout = timeout
+	}
+	resp, _ := core.InstanceAPI.Drain(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *MicroServiceInstanceService) GetDrainStatus(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &proto.GetDrainStatusRequest{
+		ServiceId:  query.Get(":serviceId"),
+		InstanceId: query.Get(":instanceId"),
+	}
+	resp, _ := core.InstanceAPI.GetDrainStatus(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
@@ -22,9 +22,11 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/config"
@@ -44,6 +46,10 @@ func (s *SchemaService) URLPatterns() []rest.Route {
 		{Method: http.MethodDelete, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", Func: s.DeleteSchemas},
 		{Method: http.MethodPost, Path: "/v4/:project/registry/microservices/:serviceId/schemas", Func: s.ModifySchemas},
 		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/schemas", Func: s.GetAllSchemas},
+		{Method: http.MethodPost, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId/compatibility", Func: s.CheckCompatibility},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId/revisions", Func: s.ListRevisions},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId/revisions/:revision", Func: s.GetRevision},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId/diff", Func: s.DiffRevisions},
 	}
 
 	if !config.GetRegistry().SchemaDisable {
@@ -123,6 +129,82 @@ func (s *SchemaService) ModifySchemas(w http.ResponseWriter, r *http.Request) {
 	rest.WriteResponse(w, r, resp.Response, nil)
 }
 
+func (s *SchemaService) CheckCompatibility(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request := &proto.CheckSchemaCompatibilityRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	request.ServiceId = query.Get(":serviceId")
+	request.SchemaId = query.Get(":schemaId")
+	resp, err := core.ServiceAPI.CheckSchemaCompatibility(r.Context(), request)
+	if err != nil {
+		log.Errorf(err, "can not check schema compatibility")
+		rest.WriteError(w, pb.ErrInternal, "can not check schema compatibility")
+		return
+	}
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *SchemaService) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &proto.ListSchemaRevisionsRequest{
+		ServiceId: query.Get(":serviceId"),
+		SchemaId:  query.Get(":schemaId"),
+	}
+	resp, _ := core.ServiceAPI.ListSchemaRevisions(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *SchemaService) GetRevision(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	revision, err := strconv.ParseInt(query.Get(":revision"), 10, 64)
+	if err != nil {
+		rest.WriteError(w, pb.ErrInvalidParams, "parameter revision must be an integer")
+		return
+	}
+	request := &proto.GetSchemaRevisionRequest{
+		ServiceId: query.Get(":serviceId"),
+		SchemaId:  query.Get(":schemaId"),
+		Revision:  revision,
+	}
+	resp, _ := core.ServiceAPI.GetSchemaRevision(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *SchemaService) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &proto.DiffSchemaRevisionsRequest{
+		ServiceId: query.Get(":serviceId"),
+		SchemaId:  query.Get(":schemaId"),
+	}
+	var err error
+	if v := query.Get("from"); len(v) > 0 {
+		if request.From, err = strconv.ParseInt(v, 10, 64); err != nil {
+			rest.WriteError(w, pb.ErrInvalidParams, "parameter from must be an integer")
+			return
+		}
+	}
+	if v := query.Get("to"); len(v) > 0 {
+		if request.To, err = strconv.ParseInt(v, 10, 64); err != nil {
+			rest.WriteError(w, pb.ErrInvalidParams, "parameter to must be an integer")
+			return
+		}
+	}
+	resp, _ := core.ServiceAPI.DiffSchemaRevisions(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
 func (s *SchemaService) DeleteSchemas(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	request := &pb.DeleteSchemaRequest{
@@ -34,4 +34,6 @@ func initRouter() {
 	roa.RegisterServant(&RuleService{})
 	roa.RegisterServant(&MicroServiceInstanceService{})
 	roa.RegisterServant(&WatchService{})
+	roa.RegisterServant(&EventWatchService{})
+	roa.RegisterServant(&WebhookService{})
 }
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v4
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/webhook"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+type WebhookService struct {
+	//
+}
+
+func (s *WebhookService) URLPatterns() []rest.Route {
+	return []rest.Route{
+		{Method: http.MethodPost, Path: "/v4/:project/registry/webhooks", Func: s.CreateWebhook},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/webhooks", Func: s.ListWebhooks},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/webhooks/:webhookId", Func: s.GetWebhook},
+		{Method: http.MethodPut, Path: "/v4/:project/registry/webhooks/:webhookId", Func: s.UpdateWebhook},
+		{Method: http.MethodDelete, Path: "/v4/:project/registry/webhooks/:webhookId", Func: s.DeleteWebhook},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/webhooks/:webhookId/deliveries", Func: s.ListDeliveries},
+	}
+}
+
+func (s *WebhookService) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	request := &webhookpb.CreateWebhookRequest{}
+	if !readWebhookRequest(w, r, request) {
+		return
+	}
+	resp, _ := webhook.ServiceAPI.CreateWebhook(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *WebhookService) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, _ := webhook.ServiceAPI.ListWebhooks(r.Context())
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *WebhookService) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	resp, _ := webhook.ServiceAPI.GetWebhook(r.Context(), r.URL.Query().Get(":webhookId"))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *WebhookService) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	request := &webhookpb.UpdateWebhookRequest{}
+	if !readWebhookRequest(w, r, request) {
+		return
+	}
+	request.WebhookId = r.URL.Query().Get(":webhookId")
+	resp, _ := webhook.ServiceAPI.UpdateWebhook(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (s *WebhookService) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	resp, _ := webhook.ServiceAPI.DeleteWebhook(r.Context(), r.URL.Query().Get(":webhookId"))
+	rest.WriteResponse(w, r, resp, nil)
+}
+
+// ListDeliveries returns the delivery logs of the webhook, the latest first
+func (s *WebhookService) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	resp, _ := webhook.ServiceAPI.ListDeliveries(r.Context(), r.URL.Query().Get(":webhookId"))
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func readWebhookRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return false
+	}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return false
+	}
+	return true
+}
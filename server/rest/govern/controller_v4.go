@@ -19,6 +19,7 @@ package govern
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	pb "github.com/go-chassis/cari/discovery"
@@ -49,8 +50,27 @@ func (governService *ResourceV4) URLPatterns() []rest.Route {
 func (governService *ResourceV4) GetGraph(w http.ResponseWriter, r *http.Request) {
 	var (
 		graph      Graph
-		withShared = util.StringTRUE(r.URL.Query().Get("withShared"))
+		query      = r.URL.Query()
+		withShared = util.StringTRUE(query.Get("withShared"))
+		format     = query.Get("format")
+		appID      = query.Get("appId")
+		env        = query.Get("env")
+		serviceID  = query.Get("serviceId")
+		hops       int
 	)
+	if format != "" && format != GraphFormatJSON && format != GraphFormatDOT &&
+		format != GraphFormatMermaid && format != GraphFormatGraphML {
+		rest.WriteError(w, pb.ErrInvalidParams, "parameter format must be json, dot, mermaid or graphml")
+		return
+	}
+	if h := query.Get("hops"); len(h) > 0 {
+		var err error
+		hops, err = strconv.Atoi(h)
+		if err != nil || hops < 0 {
+			rest.WriteError(w, pb.ErrInvalidParams, "parameter hops must be a non-negative integer")
+			return
+		}
+	}
 	request := &pb.GetServicesRequest{}
 	ctx := r.Context()
 	domainProject := util.ParseDomainProject(ctx)
@@ -61,12 +81,17 @@ func (governService *ResourceV4) GetGraph(w http.ResponseWriter, r *http.Request
 		return
 	}
 	services := resp.Services
-	if len(services) <= 0 {
+	if len(services) <= 0 && (format == "" || format == GraphFormatJSON) {
 		return
 	}
+	isSkipped := func(service *pb.MicroService) bool {
+		return governService.isSkipped(withShared, domainProject, service) ||
+			(len(appID) > 0 && service.AppId != appID) ||
+			(len(env) > 0 && service.Environment != env)
+	}
 	nodes := make([]Node, 0, len(services))
 	for _, service := range services {
-		if governService.isSkipped(withShared, domainProject, service) {
+		if isSkipped(service) {
 			continue
 		}
 
@@ -90,12 +115,42 @@ func (governService *ResourceV4) GetGraph(w http.ResponseWriter, r *http.Request
 			return
 		}
 
-		providers := proResp.Providers
+		providers := make([]*pb.MicroService, 0, len(proResp.Providers))
+		for _, provider := range proResp.Providers {
+			if provider != nil && (len(appID) == 0 || provider.AppId == appID) &&
+				(len(env) == 0 || provider.Environment == env) {
+				providers = append(providers, provider)
+			}
+		}
 		lines := governService.genLinesFromNode(withShared, domainProject, node, providers)
 		graph.Lines = append(graph.Lines, lines...)
 	}
 	graph.Nodes = nodes
-	rest.WriteResponse(w, r, nil, graph)
+	if len(serviceID) > 0 {
+		if !graph.hasNode(serviceID) {
+			rest.WriteError(w, pb.ErrServiceNotExists, "service does not exist in the graph")
+			return
+		}
+		graph = graph.Neighborhood(serviceID, hops)
+	}
+	if format == "" || format == GraphFormatJSON {
+		rest.WriteResponse(w, r, nil, graph)
+		return
+	}
+	writeGraph(w, graph, format)
+}
+
+func writeGraph(w http.ResponseWriter, graph Graph, format string) {
+	data, contentType, err := graph.Encode(format)
+	if err != nil {
+		rest.WriteError(w, pb.ErrInternal, err.Error())
+		return
+	}
+	w.Header().Set(rest.HeaderContentType, contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		log.Error("write graph failed", err)
+	}
 }
 
 func (governService *ResourceV4) genLinesFromNode(withShared bool, domainProject string, node Node, providers []*pb.MicroService) []Line {
@@ -115,6 +170,8 @@ func (governService *ResourceV4) genLinesFromNode(withShared bool, domainProject
 		line.From = node
 		line.To.Name = child.ServiceName
 		line.To.ID = child.ServiceId
+		line.To.AppID = child.AppId
+		line.To.Version = child.Version
 		lines = append(lines, line)
 	}
 	return lines
@@ -17,6 +17,17 @@
 
 package govern
 
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+)
+
 //Node 节点信息
 type Node struct {
 	ID       string   `json:"id"`
@@ -50,3 +61,197 @@ type Graph struct {
 	Circles []Circle `json:"circles"`
 	Visits  []string `json:"-"`
 }
+
+// the formats of the graph output
+const (
+	GraphFormatJSON    = "json"
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+	GraphFormatGraphML = "graphml"
+)
+
+func (g *Graph) hasNode(id string) bool {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Neighborhood returns the sub graph within hops around the node, hops <= 0 means no limit
+func (g *Graph) Neighborhood(id string, hops int) Graph {
+	adjacency := make(map[string][]string)
+	for _, line := range g.Lines {
+		adjacency[line.From.ID] = append(adjacency[line.From.ID], line.To.ID)
+		adjacency[line.To.ID] = append(adjacency[line.To.ID], line.From.ID)
+	}
+	distances := map[string]int{id: 0}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if hops > 0 && distances[current] >= hops {
+			continue
+		}
+		for _, next := range adjacency[current] {
+			if _, ok := distances[next]; ok {
+				continue
+			}
+			distances[next] = distances[current] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	var sub Graph
+	for _, node := range g.Nodes {
+		if _, ok := distances[node.ID]; ok {
+			sub.Nodes = append(sub.Nodes, node)
+		}
+	}
+	for _, line := range g.Lines {
+		_, from := distances[line.From.ID]
+		_, to := distances[line.To.ID]
+		if from && to {
+			sub.Lines = append(sub.Lines, line)
+		}
+	}
+	return sub
+}
+
+// Encode returns the graph in the format and its content type
+func (g *Graph) Encode(format string) ([]byte, string, error) {
+	switch format {
+	case "", GraphFormatJSON:
+		data, err := json.Marshal(g)
+		return data, rest.ContentTypeJSON, err
+	case GraphFormatDOT:
+		return g.dot(), "text/vnd.graphviz; charset=UTF-8", nil
+	case GraphFormatMermaid:
+		return g.mermaid(), rest.ContentTypeText, nil
+	case GraphFormatGraphML:
+		data, err := g.graphML()
+		return data, "application/graphml+xml; charset=UTF-8", err
+	default:
+		return nil, "", fmt.Errorf("unsupported graph format %s", format)
+	}
+}
+
+// allNodes returns the nodes including the ones only referenced by the lines
+func (g *Graph) allNodes() []Node {
+	nodes := append([]Node{}, g.Nodes...)
+	exists := make(map[string]bool, len(g.Nodes))
+	for _, node := range g.Nodes {
+		exists[node.ID] = true
+	}
+	for _, line := range g.Lines {
+		for _, node := range []Node{line.From, line.To} {
+			if !exists[node.ID] {
+				exists[node.ID] = true
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	return nodes
+}
+
+func (n *Node) label() string {
+	if len(n.Version) == 0 {
+		return n.Name
+	}
+	return n.Name + " " + n.Version
+}
+
+func (g *Graph) dot() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("digraph dependencies {\n")
+	for _, node := range g.allNodes() {
+		fmt.Fprintf(&buf, "  %s [label=%s];\n", strconv.Quote(node.ID), strconv.Quote(node.label()))
+	}
+	for _, line := range g.Lines {
+		fmt.Fprintf(&buf, "  %s -> %s;\n", strconv.Quote(line.From.ID), strconv.Quote(line.To.ID))
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func (g *Graph) mermaid() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("graph LR\n")
+	// the service ids may contain the characters not allowed in mermaid ids
+	ids := make(map[string]string)
+	for i, node := range g.allNodes() {
+		ids[node.ID] = "n" + strconv.Itoa(i)
+		fmt.Fprintf(&buf, "  %s[\"%s\"]\n", ids[node.ID], strings.ReplaceAll(node.label(), "\"", "#quot;"))
+	}
+	for _, line := range g.Lines {
+		fmt.Fprintf(&buf, "  %s --> %s\n", ids[line.From.ID], ids[line.To.ID])
+	}
+	return buf.Bytes()
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (g *Graph) graphML() ([]byte, error) {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", Name: "name", Type: "string"},
+			{ID: "appId", For: "node", Name: "appId", Type: "string"},
+			{ID: "version", For: "node", Name: "version", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "dependencies", EdgeDefault: "directed"},
+	}
+	for _, node := range g.allNodes() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.ID,
+			Data: []graphMLData{
+				{Key: "name", Value: node.Name},
+				{Key: "appId", Value: node.AppID},
+				{Key: "version", Value: node.Version},
+			},
+		})
+	}
+	for _, line := range g.Lines {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: line.From.ID, Target: line.To.ID})
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
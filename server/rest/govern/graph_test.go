@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package govern_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/server/rest/govern"
+)
+
+func newGraph() *govern.Graph {
+	a := govern.Node{ID: "a", Name: "svc-a", Version: "1.0.0"}
+	b := govern.Node{ID: "b", Name: "svc-b", Version: "1.0.0"}
+	c := govern.Node{ID: "c", Name: "svc-c", Version: "1.0.0"}
+	d := govern.Node{ID: "d", Name: "svc-\"d\"", Version: "1.0.0"}
+	return &govern.Graph{
+		Nodes: []govern.Node{a, b, c, d},
+		Lines: []govern.Line{{From: a, To: b}, {From: b, To: c}, {From: c, To: d}},
+	}
+}
+
+func TestGraph_Neighborhood(t *testing.T) {
+	g := newGraph()
+
+	sub := g.Neighborhood("b", 1)
+	assert.Equal(t, 3, len(sub.Nodes))
+	assert.Equal(t, 2, len(sub.Lines))
+
+	sub = g.Neighborhood("a", 0)
+	assert.Equal(t, 4, len(sub.Nodes))
+	assert.Equal(t, 3, len(sub.Lines))
+}
+
+func TestGraph_Encode(t *testing.T) {
+	g := newGraph()
+
+	data, _, err := g.Encode(govern.GraphFormatDOT)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "digraph dependencies {"))
+	assert.Contains(t, string(data), `"a" -> "b";`)
+	assert.Contains(t, string(data), `"d" [label="svc-\"d\" 1.0.0"];`)
+
+	data, _, err = g.Encode(govern.GraphFormatMermaid)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "n0 --> n1")
+	assert.Contains(t, string(data), `n3["svc-#quot;d#quot; 1.0.0"]`)
+
+	data, _, err = g.Encode(govern.GraphFormatGraphML)
+	assert.NoError(t, err)
+	var doc struct {
+		Nodes []struct{} `xml:"graph>node"`
+		Edges []struct{} `xml:"graph>edge"`
+	}
+	assert.NoError(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, 4, len(doc.Nodes))
+	assert.Equal(t, 3, len(doc.Edges))
+
+	_, _, err = g.Encode("png")
+	assert.Error(t, err)
+}
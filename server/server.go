@@ -31,19 +31,25 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	"github.com/apache/servicecomb-service-center/pkg/otlp"
 	"github.com/apache/servicecomb-service-center/pkg/plugin"
 	"github.com/apache/servicecomb-service-center/pkg/signal"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/command"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/apache/servicecomb-service-center/server/interceptor/cors"
 	"github.com/apache/servicecomb-service-center/server/plugin/security/tlsconf"
+	"github.com/apache/servicecomb-service-center/server/service"
 	"github.com/apache/servicecomb-service-center/server/service/gov"
 	"github.com/apache/servicecomb-service-center/server/service/rbac"
 	snf "github.com/apache/servicecomb-service-center/server/syncernotify"
 )
 
-const defaultCollectPeriod = 30 * time.Second
+const (
+	defaultCollectPeriod = 30 * time.Second
+	exporterOTLP         = "otlp"
+)
 
 var server ServiceCenterServer
 
@@ -90,6 +96,8 @@ func (s *ServiceCenterServer) waitForQuit() {
 
 func (s *ServiceCenterServer) initialize() {
 	s.initEndpoints()
+	// CORS
+	cors.Init()
 	// Metrics
 	s.initMetrics()
 	// SSL
@@ -112,10 +120,11 @@ func (s *ServiceCenterServer) initDatasource() {
 	// init datasource
 	kind := datasource.Kind(config.GetString("registry.kind", "", config.WithStandby("registry_plugin")))
 	if err := datasource.Init(datasource.Options{
-		Kind:           kind,
-		SslEnabled:     config.GetSSL().SslEnabled,
-		InstanceTTL:    config.GetRegistry().InstanceTTL,
-		SchemaEditable: config.GetRegistry().SchemaEditable,
+		Kind:              kind,
+		SslEnabled:        config.GetSSL().SslEnabled,
+		InstanceTTL:       config.GetRegistry().InstanceTTL,
+		SchemaEditable:    config.GetRegistry().SchemaEditable,
+		SchemaHistorySize: config.GetRegistry().SchemaHistorySize,
 	}); err != nil {
 		log.Fatal("init datasource failed", err)
 	}
@@ -155,6 +164,44 @@ func (s *ServiceCenterServer) initMetrics() {
 	}); err != nil {
 		log.Fatal("init metrics failed", err)
 	}
+	s.initMetricsReporters()
+}
+
+func (s *ServiceCenterServer) initMetricsReporters() {
+	opts := config.GetMetrics()
+	if opts.HasExporter(exporterOTLP) {
+		protocol := config.GetString("metrics.otlp.protocol", otlp.ProtocolGRPC)
+		endpoint := config.GetString("metrics.otlp.endpoint", "127.0.0.1:4317")
+		exporter, err := otlp.NewExporter(protocol, endpoint, otlp.Metrics)
+		if err != nil {
+			log.Fatal("init OTLP metrics exporter failed", err)
+		}
+		metrics.RegisterReporter(exporterOTLP, metrics.NewOTLPReporter(exporter, &otlp.Resource{
+			Attributes: []otlp.KeyValue{
+				otlp.String("service.name", strings.ToLower(core.Service.ServiceName)),
+				otlp.String("service.version", core.Service.Version),
+				otlp.String("service.instance.id", metrics.InstanceName()),
+			},
+			ScopeName: "github.com/apache/servicecomb-service-center",
+		}, config.GetDuration("metrics.otlp.timeout", 10*time.Second)))
+		log.Infof("push metrics to OTLP %s endpoint %s", protocol, endpoint)
+	}
+	for _, flavor := range []string{metrics.FlavorStatsD, metrics.FlavorDogStatsD} {
+		if !opts.HasExporter(flavor) {
+			continue
+		}
+		address := config.GetString("metrics.statsd.address", "127.0.0.1:8125")
+		reporter, err := metrics.NewStatsDReporter(metrics.StatsDOptions{
+			Address: address,
+			Prefix:  config.GetString("metrics.statsd.prefix", ""),
+			Flavor:  flavor,
+		})
+		if err != nil {
+			log.Fatal("init statsd metrics reporter failed", err)
+		}
+		metrics.RegisterReporter(flavor, reporter)
+		log.Infof("push metrics to %s %s", flavor, address)
+	}
 }
 
 func (s *ServiceCenterServer) initSSL() {
@@ -202,6 +249,10 @@ func (s *ServiceCenterServer) startServices() {
 			os.Exit(1)
 		}
 	}
+	// resume the draining instances
+	service.ResumeDrains(context.Background())
+	// hot reload configs
+	config.Watch()
 	// api service
 	s.startAPIService()
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/server/service/validator"
 )
 
@@ -36,6 +37,11 @@ func (s *MicroServiceService) AddDependenciesForMicroServices(ctx context.Contex
 	}
 
 	resp, err := datasource.Instance().AddOrUpdateDependencies(ctx, in.Dependencies, false)
+	if err == nil && resp.IsSucceed() {
+		if err := datasource.StampDependencies(ctx, in.Dependencies); err != nil {
+			log.Errorf(err, "stamp dependencies last seen time failed")
+		}
+	}
 	return &pb.AddDependenciesResponse{Response: resp}, err
 }
 
@@ -48,6 +54,11 @@ func (s *MicroServiceService) CreateDependenciesForMicroServices(ctx context.Con
 	}
 
 	resp, err := datasource.Instance().AddOrUpdateDependencies(ctx, in.Dependencies, true)
+	if err == nil && resp.IsSucceed() {
+		if err := datasource.StampDependencies(ctx, in.Dependencies); err != nil {
+			log.Errorf(err, "stamp dependencies last seen time failed")
+		}
+	}
 	return &pb.CreateDependenciesResponse{Response: resp}, err
 }
 
@@ -75,3 +86,16 @@ func (s *MicroServiceService) GetConsumerDependencies(ctx context.Context, in *p
 
 	return datasource.Instance().SearchConsumerDependency(ctx, in)
 }
+
+// GetImpact returns the consumers impacted if the services were retired
+func (s *MicroServiceService) GetImpact(ctx context.Context, in *proto.ImpactRequest) (*proto.ImpactResponse, error) {
+	err := validator.Validate(in)
+	if err != nil {
+		log.Errorf(err, "GetImpact failed for validating parameters failed")
+		return &proto.ImpactResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	return datasource.SearchImpact(ctx, in)
+}
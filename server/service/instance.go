@@ -21,19 +21,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/cari/pkg/errsvc"
 
 	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	apt "github.com/apache/servicecomb-service-center/server/core"
+	"github.com/apache/servicecomb-service-center/server/event"
 	"github.com/apache/servicecomb-service-center/server/health"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
 	"github.com/apache/servicecomb-service-center/server/service/validator"
 )
 
+// defaultDrainTimeout is the default seconds to wait before unregistering the draining instance
+const defaultDrainTimeout = 30
+
 type InstanceService struct {
 }
 
@@ -49,7 +58,7 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 	instanceFlag := fmt.Sprintf("endpoints %v, host '%s', serviceID %s",
 		in.Instance.Endpoints, in.Instance.HostName, in.Instance.ServiceId)
 	domainProject := util.ParseDomainProject(ctx)
-	quotaErr := checkInstanceQuota(ctx, domainProject, in.Instance.ServiceId)
+	quotaErr := checkInstanceQuota(ctx, domainProject, in.Instance.ServiceId, 1)
 	if quotaErr != nil {
 		log.Error(fmt.Sprintf("register instance failed, %s, operator %s",
 			instanceFlag, remoteIP), quotaErr)
@@ -101,6 +110,121 @@ func (s *InstanceService) HeartbeatSet(ctx context.Context,
 	return datasource.Instance().HeartbeatSet(ctx, in)
 }
 
+// BatchRegister registers the instances in one batch, the quota is applied
+// for all the valid instances at once
+func (s *InstanceService) BatchRegister(ctx context.Context,
+	in *proto.BatchRegisterInstancesRequest) (*proto.BatchInstancesResponse, error) {
+	remoteIP := util.GetIPFromContext(ctx)
+	if err := validator.Validate(in); err != nil {
+		log.Errorf(err, "batch register instances failed, invalid parameters, operator %s", remoteIP)
+		return &proto.BatchInstancesResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	results := make([]*pb.InstanceHbRst, len(in.Instances))
+	valid := &proto.BatchRegisterInstancesRequest{}
+	var indexes []int
+	for i, instance := range in.Instances {
+		if instance == nil {
+			results[i] = &pb.InstanceHbRst{ErrMessage: "Instance is nil"}
+			continue
+		}
+		results[i] = &pb.InstanceHbRst{ServiceId: instance.ServiceId, InstanceId: instance.InstanceId}
+		if err := validator.Validate(&pb.RegisterInstanceRequest{Instance: instance}); err != nil {
+			results[i].ErrMessage = err.Error()
+			continue
+		}
+		valid.Instances = append(valid.Instances, instance)
+		indexes = append(indexes, i)
+	}
+	if len(valid.Instances) == 0 {
+		log.Errorf(nil, "batch register instances failed, no valid instance, operator %s", remoteIP)
+		return &proto.BatchInstancesResponse{
+			Response:  pb.CreateResponse(pb.ErrInvalidParams, "Batch register instances failed."),
+			Instances: results,
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	quotaErr := checkInstanceQuota(ctx, domainProject, "", int64(len(valid.Instances)))
+	if quotaErr != nil {
+		log.Errorf(quotaErr, "batch register instances[%d] failed, operator %s", len(valid.Instances), remoteIP)
+		response := &proto.BatchInstancesResponse{
+			Response: pb.CreateResponseWithSCErr(quotaErr),
+		}
+		if quotaErr.InternalError() {
+			return response, quotaErr
+		}
+		return response, nil
+	}
+
+	resp, err := datasource.Instance().BatchRegisterInstances(ctx, valid)
+	if resp == nil {
+		return nil, err
+	}
+	mergeBatchResults(resp, results, indexes, "Batch register instances failed.")
+	return resp, err
+}
+
+// BatchUnregister unregisters the instances in one batch
+func (s *InstanceService) BatchUnregister(ctx context.Context,
+	in *proto.BatchUnregisterInstancesRequest) (*proto.BatchInstancesResponse, error) {
+	remoteIP := util.GetIPFromContext(ctx)
+	if err := validator.Validate(in); err != nil {
+		log.Errorf(err, "batch unregister instances failed, invalid parameters, operator %s", remoteIP)
+		return &proto.BatchInstancesResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	results := make([]*pb.InstanceHbRst, len(in.Instances))
+	valid := &proto.BatchUnregisterInstancesRequest{}
+	var indexes []int
+	for i, element := range in.Instances {
+		if element == nil {
+			results[i] = &pb.InstanceHbRst{ErrMessage: "Instance is nil"}
+			continue
+		}
+		results[i] = &pb.InstanceHbRst{ServiceId: element.ServiceId, InstanceId: element.InstanceId}
+		if err := validator.Validate(&pb.UnregisterInstanceRequest{
+			ServiceId: element.ServiceId, InstanceId: element.InstanceId}); err != nil {
+			results[i].ErrMessage = err.Error()
+			continue
+		}
+		valid.Instances = append(valid.Instances, element)
+		indexes = append(indexes, i)
+	}
+	if len(valid.Instances) == 0 {
+		log.Errorf(nil, "batch unregister instances failed, no valid instance, operator %s", remoteIP)
+		return &proto.BatchInstancesResponse{
+			Response:  pb.CreateResponse(pb.ErrInvalidParams, "Batch unregister instances failed."),
+			Instances: results,
+		}, nil
+	}
+
+	resp, err := datasource.Instance().BatchUnregisterInstances(ctx, valid)
+	if resp == nil {
+		return nil, err
+	}
+	mergeBatchResults(resp, results, indexes, "Batch unregister instances failed.")
+	return resp, err
+}
+
+// mergeBatchResults puts the datasource results of the valid items back to
+// the request order, the response fails if any item is invalid
+func mergeBatchResults(resp *proto.BatchInstancesResponse, results []*pb.InstanceHbRst, indexes []int, failed string) {
+	for i, result := range resp.Instances {
+		if i < len(indexes) {
+			results[indexes[i]] = result
+		}
+	}
+	resp.Instances = results
+	if len(indexes) < len(results) && resp.Response.IsSucceed() {
+		resp.Response = pb.CreateResponse(pb.ErrInvalidParams, failed)
+	}
+}
+
 func (s *InstanceService) GetOneInstance(ctx context.Context,
 	in *pb.GetOneInstanceRequest) (*pb.GetOneInstanceResponse, error) {
 	err := validator.Validate(in)
@@ -167,7 +291,11 @@ func (s *InstanceService) UpdateStatus(ctx context.Context, in *pb.UpdateInstanc
 		}, nil
 	}
 
-	return datasource.Instance().UpdateInstanceStatus(ctx, in)
+	resp, err := datasource.Instance().UpdateInstanceStatus(ctx, in)
+	if err == nil && resp.Response.GetCode() == pb.ResponseSuccess && in.Status != pb.MSI_OUTOFSERVICE {
+		cancelDrain(ctx, in.ServiceId, in.InstanceId)
+	}
+	return resp, err
 }
 
 func (s *InstanceService) UpdateInstanceProperties(ctx context.Context, in *pb.UpdateInstancePropsRequest) (*pb.UpdateInstancePropsResponse, error) {
@@ -182,6 +310,287 @@ func (s *InstanceService) UpdateInstanceProperties(ctx context.Context, in *pb.U
 	return datasource.Instance().UpdateInstanceProperties(ctx, in)
 }
 
+// Drain marks the instance OUTOFSERVICE, the status change is pushed to
+// the consumer watchers by the datasource event handlers, and the instance
+// will be unregistered automatically after the timeout.
+// The deadline is persisted in the instance properties, so any service
+// center node can resume the unregistration after restarting.
+func (s *InstanceService) Drain(ctx context.Context, in *proto.DrainInstanceRequest) (*proto.DrainInstanceResponse, error) {
+	remoteIP := util.GetIPFromContext(ctx)
+	if err := validator.Validate(in); err != nil {
+		log.Errorf(err, "drain instance failed, invalid parameters, operator %s", remoteIP)
+		return &proto.DrainInstanceResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	instance, respErr := getDrainInstance(ctx, in.ServiceId, in.InstanceId)
+	if respErr != nil {
+		log.Errorf(respErr, "drain instance[%s/%s] failed, operator %s", in.ServiceId, in.InstanceId, remoteIP)
+		resp := &proto.DrainInstanceResponse{
+			Response: pb.CreateResponseWithSCErr(respErr),
+		}
+		if respErr.InternalError() {
+			return resp, respErr
+		}
+		return resp, nil
+	}
+	domainProject := util.ParseDomainProject(ctx)
+	if deadline, ok := drainDeadlineOf(instance); ok {
+		task := s.resumeDrain(ctx, domainProject, in.ServiceId, in.InstanceId, deadline)
+		return &proto.DrainInstanceResponse{
+			Response: pb.CreateResponse(pb.ResponseSuccess, "Instance is draining."),
+			Status:   task.Status(),
+		}, nil
+	}
+
+	timeout := in.Timeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	deadline := time.Unix(time.Now().Add(time.Duration(timeout)*time.Second).Unix(), 0)
+	task, ok := event.StartDrain(domainProject, in.ServiceId, in.InstanceId, deadline)
+	if !ok {
+		return &proto.DrainInstanceResponse{
+			Response: pb.CreateResponse(pb.ResponseSuccess, "Instance is draining."),
+			Status:   task.Status(),
+		}, nil
+	}
+
+	respErr = persistDrainDeadline(ctx, instance, deadline)
+	if respErr == nil {
+		respErr = updateDrainStatus(ctx, in.ServiceId, in.InstanceId)
+	}
+	if respErr != nil {
+		event.FinishDrain(domainProject, in.ServiceId, in.InstanceId)
+		log.Errorf(respErr, "drain instance[%s/%s] failed, operator %s", in.ServiceId, in.InstanceId, remoteIP)
+		resp := &proto.DrainInstanceResponse{
+			Response: pb.CreateResponseWithSCErr(respErr),
+		}
+		if respErr.InternalError() {
+			return resp, respErr
+		}
+		return resp, nil
+	}
+
+	log.Infof("start to drain instance[%s/%s], it will be unregistered after %ds, operator %s",
+		in.ServiceId, in.InstanceId, timeout, remoteIP)
+	s.armDrain(ctx, task)
+	return &proto.DrainInstanceResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Drain instance successfully."),
+		Status:   task.Status(),
+	}, nil
+}
+
+// ResumeDrains re-arms the unregistration of the draining instances
+// persisted in the instance properties, it is called when service center starts
+func ResumeDrains(ctx context.Context) {
+	cache := datasource.Instance().DumpCache(ctx)
+	if cache == nil {
+		return
+	}
+	for _, kv := range cache.Instances {
+		deadline, ok := drainDeadlineOf(kv.Value)
+		if !ok {
+			continue
+		}
+		// key format: /cse-sr/inst/files/{domain}/{project}/{serviceId}/{instanceId}
+		arr := strings.Split(strings.TrimPrefix(kv.Key, datasource.InstanceKeyPrefix+datasource.SPLIT), datasource.SPLIT)
+		if len(arr) != 4 {
+			continue
+		}
+		instanceCtx := util.SetDomainProject(context.Background(), arr[0], arr[1])
+		instanceService.(*InstanceService).resumeDrain(instanceCtx,
+			util.ToDomainProject(arr[0], arr[1]), kv.Value.ServiceId, kv.Value.InstanceId, deadline)
+		log.Infof("resume draining instance[%s/%s], it will be unregistered at %s",
+			kv.Value.ServiceId, kv.Value.InstanceId, deadline.Format(time.RFC3339))
+	}
+}
+
+func (s *InstanceService) resumeDrain(ctx context.Context, domainProject, serviceID, instanceID string,
+	deadline time.Time) *event.DrainTask {
+	task, ok := event.StartDrain(domainProject, serviceID, instanceID, deadline)
+	if ok {
+		s.armDrain(ctx, task)
+	}
+	return task
+}
+
+func (s *InstanceService) armDrain(ctx context.Context, task *event.DrainTask) {
+	// the request context will be canceled after responding
+	unregisterCtx := util.SetDomainProject(context.Background(), util.ParseDomain(ctx), util.ParseProject(ctx))
+	gopool.Go(func(_ context.Context) {
+		s.unregisterAfterDrain(unregisterCtx, task)
+	})
+}
+
+func (s *InstanceService) unregisterAfterDrain(ctx context.Context, task *event.DrainTask) {
+	defer event.FinishDrain(task.DomainProject, task.ServiceID, task.InstanceID)
+
+	timer := time.NewTimer(time.Until(task.Deadline))
+	defer timer.Stop()
+	select {
+	case <-task.Done():
+		return
+	case <-timer.C:
+	}
+
+	// the instance may be UP again or drained again with a new deadline
+	instance, respErr := getDrainInstance(ctx, task.ServiceID, task.InstanceID)
+	if respErr != nil {
+		log.Warnf("get draining instance[%s/%s] failed, %s", task.ServiceID, task.InstanceID, respErr.Error())
+		return
+	}
+	if deadline, ok := drainDeadlineOf(instance); !ok || deadline.Unix() != task.Deadline.Unix() {
+		log.Infof("instance[%s/%s] is not draining any more, skip unregistering it", task.ServiceID, task.InstanceID)
+		return
+	}
+
+	status := task.Status()
+	resp, err := datasource.Instance().UnregisterInstance(ctx, &pb.UnregisterInstanceRequest{
+		ServiceId:  task.ServiceID,
+		InstanceId: task.InstanceID,
+	})
+	if err != nil {
+		log.Errorf(err, "unregister draining instance[%s/%s] failed", task.ServiceID, task.InstanceID)
+		return
+	}
+	if resp.Response.GetCode() != pb.ResponseSuccess {
+		// other service center nodes may unregister it already
+		log.Warnf("unregister draining instance[%s/%s] failed, %s",
+			task.ServiceID, task.InstanceID, resp.Response.GetMessage())
+		return
+	}
+	log.Infof("draining instance[%s/%s] is unregistered, delivered to %d/%d watchers of this node",
+		task.ServiceID, task.InstanceID, status.Delivered, status.Notified)
+}
+
+func (s *InstanceService) GetDrainStatus(ctx context.Context, in *proto.GetDrainStatusRequest) (*proto.DrainInstanceResponse, error) {
+	if err := validator.Validate(in); err != nil {
+		log.Errorf(err, "get instance drain status failed, invalid parameters")
+		return &proto.DrainInstanceResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	domainProject := util.ParseDomainProject(ctx)
+	task, ok := event.GetDrain(domainProject, in.ServiceId, in.InstanceId)
+	if !ok {
+		// the instance may be drained by other service center nodes
+		instance, respErr := getDrainInstance(ctx, in.ServiceId, in.InstanceId)
+		if respErr != nil && respErr.InternalError() {
+			return &proto.DrainInstanceResponse{
+				Response: pb.CreateResponseWithSCErr(respErr),
+			}, respErr
+		}
+		deadline, draining := drainDeadlineOf(instance)
+		if respErr != nil || !draining {
+			return &proto.DrainInstanceResponse{
+				Response: pb.CreateResponse(pb.ErrInstanceNotExists, "Instance is not draining."),
+			}, nil
+		}
+		task = s.resumeDrain(ctx, domainProject, in.ServiceId, in.InstanceId, deadline)
+	}
+	return &proto.DrainInstanceResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Get instance drain status successfully."),
+		Status:   task.Status(),
+	}, nil
+}
+
+func getDrainInstance(ctx context.Context, serviceID, instanceID string) (*pb.MicroServiceInstance, *errsvc.Error) {
+	resp, err := datasource.Instance().GetInstance(ctx, &pb.GetOneInstanceRequest{
+		ProviderServiceId:  serviceID,
+		ProviderInstanceId: instanceID,
+	})
+	if err != nil {
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	if resp.Response.GetCode() != pb.ResponseSuccess {
+		return nil, pb.NewError(resp.Response.GetCode(), resp.Response.GetMessage())
+	}
+	return resp.Instance, nil
+}
+
+// drainDeadlineOf returns the persisted deadline if the instance is draining
+func drainDeadlineOf(instance *pb.MicroServiceInstance) (time.Time, bool) {
+	if instance == nil || instance.Status != pb.MSI_OUTOFSERVICE {
+		return time.Time{}, false
+	}
+	v, ok := instance.Properties[proto.PropDrainDeadline]
+	if !ok {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
+
+func persistDrainDeadline(ctx context.Context, instance *pb.MicroServiceInstance, deadline time.Time) *errsvc.Error {
+	properties := make(map[string]string, len(instance.Properties)+1)
+	for k, v := range instance.Properties {
+		properties[k] = v
+	}
+	properties[proto.PropDrainDeadline] = strconv.FormatInt(deadline.Unix(), 10)
+	return updateDrainProperties(ctx, instance, properties)
+}
+
+// cancelDrain stops draining the instance after it leaves OUTOFSERVICE, the persisted
+// deadline is removed, then a later OUTOFSERVICE status does not resume the draining
+func cancelDrain(ctx context.Context, serviceID, instanceID string) {
+	event.FinishDrain(util.ParseDomainProject(ctx), serviceID, instanceID)
+
+	instance, respErr := getDrainInstance(ctx, serviceID, instanceID)
+	if respErr != nil {
+		log.Errorf(respErr, "cancel draining instance[%s/%s] failed", serviceID, instanceID)
+		return
+	}
+	if _, ok := instance.Properties[proto.PropDrainDeadline]; !ok {
+		return
+	}
+	properties := make(map[string]string, len(instance.Properties))
+	for k, v := range instance.Properties {
+		if k != proto.PropDrainDeadline {
+			properties[k] = v
+		}
+	}
+	if respErr = updateDrainProperties(ctx, instance, properties); respErr != nil {
+		log.Errorf(respErr, "cancel draining instance[%s/%s] failed", serviceID, instanceID)
+		return
+	}
+	log.Infof("cancel draining instance[%s/%s], the status is changed", serviceID, instanceID)
+}
+
+func updateDrainProperties(ctx context.Context, instance *pb.MicroServiceInstance, properties map[string]string) *errsvc.Error {
+	resp, err := datasource.Instance().UpdateInstanceProperties(ctx, &pb.UpdateInstancePropsRequest{
+		ServiceId:  instance.ServiceId,
+		InstanceId: instance.InstanceId,
+		Properties: properties,
+	})
+	if err != nil {
+		return pb.NewError(pb.ErrInternal, err.Error())
+	}
+	if resp.Response.GetCode() != pb.ResponseSuccess {
+		return pb.NewError(resp.Response.GetCode(), resp.Response.GetMessage())
+	}
+	return nil
+}
+
+func updateDrainStatus(ctx context.Context, serviceID, instanceID string) *errsvc.Error {
+	resp, err := datasource.Instance().UpdateInstanceStatus(ctx, &pb.UpdateInstanceStatusRequest{
+		ServiceId:  serviceID,
+		InstanceId: instanceID,
+		Status:     pb.MSI_OUTOFSERVICE,
+	})
+	if err != nil {
+		return pb.NewError(pb.ErrInternal, err.Error())
+	}
+	if resp.Response.GetCode() != pb.ResponseSuccess {
+		return pb.NewError(resp.Response.GetCode(), resp.Response.GetMessage())
+	}
+	return nil
+}
+
 func (s *InstanceService) ClusterHealth(ctx context.Context) (*pb.GetInstancesResponse, error) {
 	if err := health.GlobalHealthChecker().Healthy(); err != nil {
 		return &pb.GetInstancesResponse{
@@ -228,10 +637,10 @@ func (s *InstanceService) ClusterHealth(ctx context.Context) (*pb.GetInstancesRe
 	}, nil
 }
 
-func checkInstanceQuota(ctx context.Context, domainProject string, serviceID string) *errsvc.Error {
+func checkInstanceQuota(ctx context.Context, domainProject string, serviceID string, size int64) *errsvc.Error {
 	if !apt.IsSCInstance(ctx) {
 		res := quota.NewApplyQuotaResource(quota.TypeInstance,
-			domainProject, serviceID, 1)
+			domainProject, serviceID, size)
 		err := quota.Apply(ctx, res)
 		return err
 	}
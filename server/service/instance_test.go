@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
@@ -2260,4 +2261,143 @@ var _ = Describe("'Instance' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'batch register' and 'batch unregister' operartion", func() {
+		var (
+			serviceId   string
+			instanceIds []string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "batch_instance_service",
+					AppId:       "batch_instance",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+			serviceId = respCreate.ServiceId
+		})
+
+		Context("when batch register instances", func() {
+			It("should be passed", func() {
+				By("all instances are valid")
+				resp, err := instanceResource.BatchRegister(getContext(), &proto.BatchRegisterInstancesRequest{
+					Instances: []*pb.MicroServiceInstance{
+						{
+							ServiceId: serviceId,
+							HostName:  "UT-HOST",
+							Endpoints: []string{"batch:127.0.0.1:8080"},
+						},
+						{
+							ServiceId: serviceId,
+							HostName:  "UT-HOST",
+							Endpoints: []string{"batch:127.0.0.2:8080"},
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(resp.Instances)).To(Equal(2))
+				for _, result := range resp.Instances {
+					Expect(result.ErrMessage).To(BeEmpty())
+					Expect(result.InstanceId).ToNot(BeEmpty())
+					instanceIds = append(instanceIds, result.InstanceId)
+				}
+
+				By("some instances are invalid")
+				resp, err = instanceResource.BatchRegister(getContext(), &proto.BatchRegisterInstancesRequest{
+					Instances: []*pb.MicroServiceInstance{
+						{
+							ServiceId: serviceId,
+							HostName:  "UT-HOST",
+							Endpoints: []string{"batch:127.0.0.3:8080"},
+						},
+						{
+							ServiceId: serviceId,
+							HostName:  TOO_LONG_HOSTNAME,
+							Endpoints: []string{"batch:127.0.0.4:8080"},
+						},
+						{
+							ServiceId: "not-exist-service",
+							HostName:  "UT-HOST",
+							Endpoints: []string{"batch:127.0.0.5:8080"},
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+				Expect(len(resp.Instances)).To(Equal(3))
+				Expect(resp.Instances[0].ErrMessage).To(BeEmpty())
+				Expect(resp.Instances[1].ErrMessage).ToNot(BeEmpty())
+				Expect(resp.Instances[2].ErrMessage).ToNot(BeEmpty())
+				instanceIds = append(instanceIds, resp.Instances[0].InstanceId)
+			})
+
+			It("should be failed", func() {
+				By("empty instances")
+				resp, err := instanceResource.BatchRegister(getContext(), &proto.BatchRegisterInstancesRequest{})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+
+				By("too many instances")
+				var instances []*pb.MicroServiceInstance
+				for i := 0; i < 65; i++ {
+					instances = append(instances, &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-HOST",
+						Endpoints: []string{"batch:127.0.1." + strconv.Itoa(i) + ":8080"},
+					})
+				}
+				resp, err = instanceResource.BatchRegister(getContext(), &proto.BatchRegisterInstancesRequest{
+					Instances: instances,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+
+				By("nil instance")
+				resp, err = instanceResource.BatchRegister(getContext(), &proto.BatchRegisterInstancesRequest{
+					Instances: []*pb.MicroServiceInstance{nil},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+				Expect(resp.Instances[0].ErrMessage).ToNot(BeEmpty())
+			})
+		})
+
+		Context("when batch unregister instances", func() {
+			It("should be passed", func() {
+				var elements []*pb.HeartbeatSetElement
+				for _, instanceId := range instanceIds {
+					elements = append(elements, &pb.HeartbeatSetElement{ServiceId: serviceId, InstanceId: instanceId})
+				}
+				resp, err := instanceResource.BatchUnregister(getContext(), &proto.BatchUnregisterInstancesRequest{
+					Instances: elements,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(resp.Instances)).To(Equal(len(instanceIds)))
+
+				By("instance does not exist")
+				resp, err = instanceResource.BatchUnregister(getContext(), &proto.BatchUnregisterInstancesRequest{
+					Instances: []*pb.HeartbeatSetElement{{ServiceId: serviceId, InstanceId: "not-exist-id"}},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).ToNot(Equal(pb.ResponseSuccess))
+				Expect(resp.Instances[0].ErrMessage).ToNot(BeEmpty())
+
+				By("nil instance")
+				resp, err = instanceResource.BatchUnregister(getContext(), &proto.BatchUnregisterInstancesRequest{
+					Instances: []*pb.HeartbeatSetElement{nil},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+				Expect(resp.Instances[0].ErrMessage).ToNot(BeEmpty())
+			})
+		})
+	})
 })
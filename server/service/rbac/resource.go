@@ -52,10 +52,14 @@ var (
 
 	APIProConDependency = "/v4/:project/registry/microservices/:providerId/consumers"
 	APIConProDependency = "/v4/:project/registry/microservices/:consumerId/providers"
+	APIImpact           = "/v4/:project/registry/impact"
 
 	APIHeartbeats          = "/v4/:project/registry/heartbeats"
 	APIInstanceWatcher     = "/v4/:project/registry/microservices/:serviceId/watcher"
 	APIInstanceListWatcher = "/v4/:project/registry/microservices/:serviceId/listwatcher"
+	APIRegistryEvents      = "/v4/:project/registry/events"
+
+	APIRegistryWebhooks = "/v4/:project/registry/webhooks"
 
 	APIServiceTag    = "/v4/:project/registry/microservices/:serviceId/tags"
 	APIServiceTagKey = "/v4/:project/registry/microservices/:serviceId/tags/:key"
@@ -79,6 +83,7 @@ func InitResourceMap() {
 
 	rbac.PartialMapResource("instances", ResourceService)
 	rbac.PartialMapResource(APILegacyGov, ResourceService)
+	rbac.PartialMapResource(APIRegistryWebhooks, ResourceService)
 
 	rbac.MapResource(APIServiceInfo, ResourceService)
 	rbac.MapResource(APIServicesList, ResourceService)
@@ -86,9 +91,11 @@ func InitResourceMap() {
 	rbac.MapResource(APIServiceExistence, ResourceService)
 	rbac.MapResource(APIProConDependency, ResourceService)
 	rbac.MapResource(APIConProDependency, ResourceService)
+	rbac.MapResource(APIImpact, ResourceService)
 	rbac.MapResource(APIHeartbeats, ResourceService)
 	rbac.MapResource(APIInstanceWatcher, ResourceService)
 	rbac.MapResource(APIInstanceListWatcher, ResourceService)
+	rbac.MapResource(APIRegistryEvents, ResourceService)
 	rbac.MapResource(APIServiceRuleList, ResourceService)
 	rbac.MapResource(APIServiceRule, ResourceService)
 	rbac.MapResource(APIServiceTag, ResourceService)
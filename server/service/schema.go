@@ -79,6 +79,9 @@ func (s *MicroServiceService) DeleteSchema(ctx context.Context, in *pb.DeleteSch
 // If the request contains a new schemaID,
 // the new schemaID will be automatically added to the service information.
 // Schema is allowed to add/delete/modify.
+// The breaking changes are handled by the compatibility policy of the
+// service environment, see SchemaCompatibilityPolicy.
+// The modified schemas are recorded in the schema history.
 func (s *MicroServiceService) ModifySchemas(ctx context.Context, in *pb.ModifySchemasRequest) (*pb.ModifySchemasResponse, error) {
 	err := validator.Validate(in)
 	if err != nil {
@@ -88,7 +91,21 @@ func (s *MicroServiceService) ModifySchemas(ctx context.Context, in *pb.ModifySc
 			Response: pb.CreateResponse(pb.ErrInvalidParams, "Invalid request."),
 		}, nil
 	}
-	return datasource.Instance().ModifySchemas(ctx, in)
+	respErr := s.checkSchemasCompatibility(ctx, in.ServiceId, in.Schemas, true)
+	if respErr != nil {
+		resp := &pb.ModifySchemasResponse{
+			Response: pb.CreateResponseWithSCErr(respErr),
+		}
+		if respErr.InternalError() {
+			return resp, respErr
+		}
+		return resp, nil
+	}
+	resp, err := datasource.Instance().ModifySchemas(ctx, in)
+	if err == nil && resp.Response.GetCode() == pb.ResponseSuccess {
+		addSchemaRevisions(ctx, in.ServiceId, in.Schemas)
+	}
+	return resp, err
 }
 
 // ModifySchema modifies a specific schema
@@ -101,9 +118,19 @@ func (s *MicroServiceService) ModifySchemas(ctx context.Context, in *pb.ModifySc
 // If the request contains a new schemaID,
 // the new schemaID will be automatically added to the service information.
 // Schema is allowed to add/modify.
+// The breaking changes are handled by the compatibility policy of the
+// service environment, see SchemaCompatibilityPolicy.
+// The modified schemas are recorded in the schema history.
 func (s *MicroServiceService) ModifySchema(ctx context.Context, request *pb.ModifySchemaRequest) (*pb.ModifySchemaResponse, error) {
 	domainProject := util.ParseDomainProject(ctx)
 	respErr := s.canModifySchema(ctx, domainProject, request)
+	if respErr == nil {
+		respErr = s.checkSchemasCompatibility(ctx, request.ServiceId, []*pb.Schema{{
+			SchemaId: request.SchemaId,
+			Summary:  request.Summary,
+			Schema:   request.Schema,
+		}}, false)
+	}
 	if respErr != nil {
 		resp := &pb.ModifySchemaResponse{
 			Response: pb.CreateResponseWithSCErr(respErr),
@@ -114,7 +141,15 @@ func (s *MicroServiceService) ModifySchema(ctx context.Context, request *pb.Modi
 		return resp, nil
 	}
 
-	return datasource.Instance().ModifySchema(ctx, request)
+	resp, err := datasource.Instance().ModifySchema(ctx, request)
+	if err == nil && resp.Response.GetCode() == pb.ResponseSuccess {
+		addSchemaRevisions(ctx, request.ServiceId, []*pb.Schema{{
+			SchemaId: request.SchemaId,
+			Summary:  request.Summary,
+			Schema:   request.Schema,
+		}})
+	}
+	return resp, err
 }
 
 func (s *MicroServiceService) canModifySchema(ctx context.Context, domainProject string, in *pb.ModifySchemaRequest) *errsvc.Error {
@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/schema"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/service/validator"
+)
+
+const (
+	// SchemaCompatibilityReject rejects the breaking changes of schemas
+	SchemaCompatibilityReject = "reject"
+	// SchemaCompatibilityWarn logs the breaking changes of schemas
+	SchemaCompatibilityWarn = "warn"
+	// SchemaCompatibilityAllow skips the compatibility checking
+	SchemaCompatibilityAllow = "allow"
+)
+
+// SchemaCompatibilityPolicy returns the compatibility policy of the environment,
+// configured by 'registry.schema.compatibility.<environment>', the empty
+// environment is treated as production as the schema editable checking does.
+// The checking is skipped in development and testing by default
+func SchemaCompatibilityPolicy(env string) string {
+	env = datasource.SchemaEnvironment(env)
+	def := SchemaCompatibilityWarn
+	if env == pb.ENV_DEV || env == pb.ENV_TEST {
+		def = SchemaCompatibilityAllow
+	}
+	policy := strings.ToLower(config.GetString("registry.schema.compatibility."+env, def))
+	switch policy {
+	case SchemaCompatibilityReject, SchemaCompatibilityWarn, SchemaCompatibilityAllow:
+		return policy
+	default:
+		return def
+	}
+}
+
+// CheckSchemaCompatibility is the dry-run of the compatibility checking,
+// it compares the schema with the registered one without modifying it
+func (s *MicroServiceService) CheckSchemaCompatibility(ctx context.Context,
+	in *proto.CheckSchemaCompatibilityRequest) (*proto.CheckSchemaCompatibilityResponse, error) {
+	err := validator.Validate(&pb.ModifySchemaRequest{ServiceId: in.ServiceId, SchemaId: in.SchemaId, Schema: in.Schema})
+	if err != nil {
+		log.Errorf(err, "check schema[%s/%s] compatibility failed", in.ServiceId, in.SchemaId)
+		return &proto.CheckSchemaCompatibilityResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	service, respErr := getServiceOfSchema(ctx, in.ServiceId)
+	if respErr != nil {
+		resp := &proto.CheckSchemaCompatibilityResponse{
+			Response: pb.CreateResponseWithSCErr(respErr),
+		}
+		if respErr.InternalError() {
+			return resp, respErr
+		}
+		return resp, nil
+	}
+
+	schemaResp, err := datasource.Instance().GetSchema(ctx, &pb.GetSchemaRequest{
+		ServiceId: in.ServiceId,
+		SchemaId:  in.SchemaId,
+	})
+	if err != nil {
+		log.Errorf(err, "check schema[%s/%s] compatibility failed, get schema failed", in.ServiceId, in.SchemaId)
+		return &proto.CheckSchemaCompatibilityResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+		}, err
+	}
+	code := schemaResp.Response.GetCode()
+	if code != pb.ResponseSuccess && code != pb.ErrSchemaNotExists {
+		return &proto.CheckSchemaCompatibilityResponse{Response: schemaResp.Response}, nil
+	}
+
+	report, err := schema.Check(schemaResp.Schema, in.Schema)
+	if err != nil {
+		log.Errorf(err, "check schema[%s/%s] compatibility failed", in.ServiceId, in.SchemaId)
+		return &proto.CheckSchemaCompatibilityResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	return &proto.CheckSchemaCompatibilityResponse{
+		Response:   pb.CreateResponse(pb.ResponseSuccess, "Check schema compatibility successfully."),
+		Policy:     SchemaCompatibilityPolicy(service.Environment),
+		Compatible: report.Compatible(),
+		Changes:    report.Changes,
+	}, nil
+}
+
+// checkSchemasCompatibility applies the compatibility policy of the service
+// environment to the schemas going to be modified, if covered is true, the
+// registered schemas not in the list are treated as removed
+func (s *MicroServiceService) checkSchemasCompatibility(ctx context.Context, serviceID string,
+	schemas []*pb.Schema, covered bool) *errsvc.Error {
+	service, respErr := getServiceOfSchema(ctx, serviceID)
+	if respErr != nil {
+		if respErr.InternalError() {
+			return respErr
+		}
+		// let datasource return the not exist error
+		return nil
+	}
+	policy := SchemaCompatibilityPolicy(service.Environment)
+	if policy == SchemaCompatibilityAllow {
+		return nil
+	}
+
+	resp, err := datasource.Instance().GetAllSchemas(ctx, &pb.GetAllSchemaRequest{
+		ServiceId:  serviceID,
+		WithSchema: true,
+	})
+	if err != nil {
+		log.Errorf(err, "check service[%s] schemas compatibility failed, get schemas failed", serviceID)
+		return pb.NewError(pb.ErrInternal, err.Error())
+	}
+	if resp.Response.GetCode() != pb.ResponseSuccess {
+		return nil
+	}
+	registered := make(map[string]string, len(resp.Schemas))
+	for _, old := range resp.Schemas {
+		registered[old.SchemaId] = old.Schema
+	}
+
+	var breaking []string
+	check := func(schemaID, oldSchema, newSchema string) {
+		report, err := schema.Check(oldSchema, newSchema)
+		if err != nil {
+			log.Warnf("skip checking schema[%s/%s] compatibility, %s", serviceID, schemaID, err.Error())
+			return
+		}
+		if !report.Compatible() {
+			breaking = append(breaking, fmt.Sprintf("schema[%s]: %s", schemaID, report))
+		}
+	}
+	modified := make(map[string]struct{}, len(schemas))
+	for _, in := range schemas {
+		modified[in.SchemaId] = struct{}{}
+		if old, ok := registered[in.SchemaId]; ok {
+			check(in.SchemaId, old, in.Schema)
+		}
+	}
+	if covered {
+		for schemaID, old := range registered {
+			if _, ok := modified[schemaID]; !ok {
+				check(schemaID, old, "")
+			}
+		}
+	}
+	if len(breaking) == 0 {
+		return nil
+	}
+
+	remoteIP := util.GetIPFromContext(ctx)
+	msg := fmt.Sprintf("breaking changes found, %s", strings.Join(breaking, "; "))
+	if policy == SchemaCompatibilityReject {
+		log.Errorf(nil, "modify service[%s] schemas failed, %s, operator: %s", serviceID, msg, remoteIP)
+		return pb.NewError(pb.ErrModifySchemaNotAllow, msg)
+	}
+	log.Warnf("modify service[%s] schemas with %s, operator: %s", serviceID, msg, remoteIP)
+	return nil
+}
+
+func getServiceOfSchema(ctx context.Context, serviceID string) (*pb.MicroService, *errsvc.Error) {
+	resp, err := datasource.Instance().GetService(ctx, &pb.GetServiceRequest{ServiceId: serviceID})
+	if err != nil {
+		log.Errorf(err, "get service[%s] failed", serviceID)
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	if resp.Response.GetCode() != pb.ResponseSuccess {
+		return nil, pb.NewError(resp.Response.GetCode(), resp.Response.GetMessage())
+	}
+	return resp.Service, nil
+}
@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/schema"
+	"github.com/apache/servicecomb-service-center/server/service/rbac"
+	"github.com/apache/servicecomb-service-center/server/service/validator"
+)
+
+func (s *MicroServiceService) ListSchemaRevisions(ctx context.Context,
+	in *proto.ListSchemaRevisionsRequest) (*proto.ListSchemaRevisionsResponse, error) {
+	err := validator.Validate(&pb.GetSchemaRequest{ServiceId: in.ServiceId, SchemaId: in.SchemaId})
+	if err != nil {
+		log.Errorf(err, "list schema[%s/%s] revisions failed", in.ServiceId, in.SchemaId)
+		return &proto.ListSchemaRevisionsResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	revisions, err := datasource.Instance().ListSchemaRevisions(ctx, in.ServiceId, in.SchemaId)
+	if err != nil {
+		return &proto.ListSchemaRevisionsResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+		}, err
+	}
+	return &proto.ListSchemaRevisionsResponse{
+		Response:  pb.CreateResponse(pb.ResponseSuccess, "List schema revisions successfully."),
+		Revisions: revisions,
+	}, nil
+}
+
+func (s *MicroServiceService) GetSchemaRevision(ctx context.Context,
+	in *proto.GetSchemaRevisionRequest) (*proto.GetSchemaRevisionResponse, error) {
+	err := validator.Validate(&pb.GetSchemaRequest{ServiceId: in.ServiceId, SchemaId: in.SchemaId})
+	if err != nil || in.Revision <= 0 {
+		if err == nil {
+			err = errors.New("revision must be a positive integer")
+		}
+		log.Errorf(err, "get schema[%s/%s] revision failed", in.ServiceId, in.SchemaId)
+		return &proto.GetSchemaRevisionResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	revision, respErr := getSchemaRevision(ctx, in.ServiceId, in.SchemaId, in.Revision)
+	if respErr != nil {
+		resp := &proto.GetSchemaRevisionResponse{
+			Response: pb.CreateResponseWithSCErr(respErr),
+		}
+		if respErr.InternalError() {
+			return resp, respErr
+		}
+		return resp, nil
+	}
+	return &proto.GetSchemaRevisionResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Get schema revision successfully."),
+		Revision: revision,
+	}, nil
+}
+
+// DiffSchemaRevisions compares two revisions of the schema, the latest
+// revision is used if To is 0, and the previous revision of To is used if
+// From is 0
+func (s *MicroServiceService) DiffSchemaRevisions(ctx context.Context,
+	in *proto.DiffSchemaRevisionsRequest) (*proto.DiffSchemaRevisionsResponse, error) {
+	err := validator.Validate(&pb.GetSchemaRequest{ServiceId: in.ServiceId, SchemaId: in.SchemaId})
+	if err != nil || in.From < 0 || in.To < 0 {
+		if err == nil {
+			err = errors.New("revision must not be negative")
+		}
+		log.Errorf(err, "diff schema[%s/%s] revisions failed", in.ServiceId, in.SchemaId)
+		return &proto.DiffSchemaRevisionsResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	from, to := in.From, in.To
+	if from == 0 || to == 0 {
+		revisions, err := datasource.Instance().ListSchemaRevisions(ctx, in.ServiceId, in.SchemaId)
+		if err != nil {
+			return &proto.DiffSchemaRevisionsResponse{
+				Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+			}, err
+		}
+		from, to = resolveDiffRevisions(revisions, from, to)
+	}
+
+	var fromRevision *proto.SchemaRevision
+	toRevision, respErr := getSchemaRevision(ctx, in.ServiceId, in.SchemaId, to)
+	if respErr == nil && from > 0 {
+		fromRevision, respErr = getSchemaRevision(ctx, in.ServiceId, in.SchemaId, from)
+	}
+	if respErr != nil {
+		resp := &proto.DiffSchemaRevisionsResponse{
+			Response: pb.CreateResponseWithSCErr(respErr),
+		}
+		if respErr.InternalError() {
+			return resp, respErr
+		}
+		return resp, nil
+	}
+	if fromRevision == nil {
+		fromRevision = &proto.SchemaRevision{}
+	}
+
+	diff, err := schema.Diff(fromRevision.Schema, toRevision.Schema,
+		fmt.Sprintf("%s@%d", in.SchemaId, from), fmt.Sprintf("%s@%d", in.SchemaId, to))
+	if err != nil {
+		return &proto.DiffSchemaRevisionsResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+		}, err
+	}
+	resp := &proto.DiffSchemaRevisionsResponse{
+		Response:   pb.CreateResponse(pb.ResponseSuccess, "Diff schema revisions successfully."),
+		From:       from,
+		To:         to,
+		Diff:       diff,
+		Compatible: true,
+	}
+	report, err := schema.Check(fromRevision.Schema, toRevision.Schema)
+	if err != nil {
+		log.Warnf("skip checking schema[%s/%s] compatibility, %s", in.ServiceId, in.SchemaId, err.Error())
+		return resp, nil
+	}
+	resp.Compatible = report.Compatible()
+	resp.Changes = report.Changes
+	return resp, nil
+}
+
+// resolveDiffRevisions fills the zero revisions by the history sorted in descending order
+func resolveDiffRevisions(revisions []*proto.SchemaRevision, from, to int64) (int64, int64) {
+	if to == 0 && len(revisions) > 0 {
+		to = revisions[0].Revision
+	}
+	if from == 0 {
+		for _, revision := range revisions {
+			if revision.Revision < to {
+				return revision.Revision, to
+			}
+		}
+	}
+	return from, to
+}
+
+func getSchemaRevision(ctx context.Context, serviceID, schemaID string, revision int64) (*proto.SchemaRevision, *errsvc.Error) {
+	result, err := datasource.Instance().GetSchemaRevision(ctx, serviceID, schemaID, revision)
+	if err != nil {
+		if errors.Is(err, datasource.ErrSchemaRevisionNotExists) {
+			return nil, pb.NewError(pb.ErrSchemaNotExists, "Schema revision does not exist.")
+		}
+		log.Errorf(err, "get schema[%s/%s] revision %d failed", serviceID, schemaID, revision)
+		return nil, pb.NewError(pb.ErrInternal, err.Error())
+	}
+	return result, nil
+}
+
+// addSchemaRevisions records the modified schemas into the history, the
+// failure does not affect the modification
+func addSchemaRevisions(ctx context.Context, serviceID string, schemas []*pb.Schema) {
+	account := rbac.UserFromContext(ctx)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, in := range schemas {
+		err := datasource.Instance().AddSchemaRevision(ctx, serviceID, in.SchemaId, &proto.SchemaRevision{
+			Hash:      fmt.Sprintf("%x", sha256.Sum256([]byte(in.Schema))),
+			Summary:   in.Summary,
+			Timestamp: timestamp,
+			Account:   account,
+			Schema:    in.Schema,
+		})
+		if err != nil {
+			log.Errorf(err, "add schema[%s/%s] revision failed", serviceID, in.SchemaId)
+		}
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/schema"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
 	"github.com/apache/servicecomb-service-center/server/service"
 	pb "github.com/go-chassis/cari/discovery"
@@ -1382,4 +1384,178 @@ var _ = Describe("'Schema' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'compatibility' operation", func() {
+		const (
+			schemaV1 = `{"swagger":"2.0","paths":{"/users":{"get":{}},"/orders":{"get":{}}}}`
+			schemaV2 = `{"swagger":"2.0","paths":{"/users":{"get":{}}}}`
+		)
+		var serviceId string
+
+		It("should be passed, create service and schema", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "compatibility_schema_group",
+					ServiceName: "compatibility_schema_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+					Environment: pb.ENV_ACCEPT,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+			serviceId = respCreateService.ServiceId
+
+			resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "com.huawei.test",
+				Schema:    schemaV1,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := serviceResource.CheckSchemaCompatibility(getContext(), &proto.CheckSchemaCompatibilityRequest{
+					ServiceId: serviceId,
+					SchemaId:  invalidSchemaId,
+					Schema:    schemaV2,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+
+				resp, err = serviceResource.CheckSchemaCompatibility(getContext(), &proto.CheckSchemaCompatibilityRequest{
+					ServiceId: "notExistService",
+					SchemaId:  "com.huawei.test",
+					Schema:    schemaV2,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrServiceNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should report the breaking changes", func() {
+				resp, err := serviceResource.CheckSchemaCompatibility(getContext(), &proto.CheckSchemaCompatibilityRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+					Schema:    schemaV2,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(resp.Policy).To(Equal(service.SchemaCompatibilityWarn))
+				Expect(resp.Compatible).To(BeFalse())
+				Expect(len(resp.Changes)).To(Equal(1))
+				Expect(resp.Changes[0].Kind).To(Equal(schema.KindPathRemoved))
+
+				resp, err = serviceResource.CheckSchemaCompatibility(getContext(), &proto.CheckSchemaCompatibilityRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.new",
+					Schema:    schemaV2,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(resp.Compatible).To(BeTrue())
+			})
+
+			It("should be passed to modify with warn policy", func() {
+				resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+					Schema:    schemaV2,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+			})
+		})
+	})
+
+	Describe("execute 'revision' operation", func() {
+		const (
+			schemaV1 = `{"swagger":"2.0","paths":{"/users":{"get":{}},"/orders":{"get":{}}}}`
+			schemaV2 = `{"swagger":"2.0","paths":{"/users":{"get":{}}}}`
+		)
+		var serviceId string
+
+		It("should be passed, create service and modify schema", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "revision_schema_group",
+					ServiceName: "revision_schema_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+					Environment: pb.ENV_DEV,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+			serviceId = respCreateService.ServiceId
+
+			for _, content := range []string{schemaV1, schemaV1, schemaV2} {
+				resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+					Schema:    content,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+			}
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				respList, err := serviceResource.ListSchemaRevisions(getContext(), &proto.ListSchemaRevisionsRequest{
+					ServiceId: serviceId,
+					SchemaId:  invalidSchemaId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respList.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+
+				respGet, err := serviceResource.GetSchemaRevision(getContext(), &proto.GetSchemaRevisionRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+					Revision:  100,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.GetCode()).To(Equal(pb.ErrSchemaNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should return the history", func() {
+				respList, err := serviceResource.ListSchemaRevisions(getContext(), &proto.ListSchemaRevisionsRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+				})
+				Expect(err).To(BeNil())
+				Expect(respList.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(respList.Revisions)).To(Equal(2))
+				Expect(respList.Revisions[0].Revision).To(Equal(int64(2)))
+				Expect(respList.Revisions[0].Schema).To(BeEmpty())
+
+				respGet, err := serviceResource.GetSchemaRevision(getContext(), &proto.GetSchemaRevisionRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+					Revision:  1,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(respGet.Revision.Schema).To(Equal(schemaV1))
+
+				respDiff, err := serviceResource.DiffSchemaRevisions(getContext(), &proto.DiffSchemaRevisionsRequest{
+					ServiceId: serviceId,
+					SchemaId:  "com.huawei.test",
+				})
+				Expect(err).To(BeNil())
+				Expect(respDiff.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(respDiff.From).To(Equal(int64(1)))
+				Expect(respDiff.To).To(Equal(int64(2)))
+				Expect(respDiff.Diff).ToNot(BeEmpty())
+				Expect(respDiff.Compatible).To(BeFalse())
+			})
+		})
+	})
 })
@@ -22,11 +22,11 @@ import (
 )
 
 var (
-	serviceService  proto.ServiceCtrlServer
+	serviceService  proto.ServiceCtrlServerEx
 	instanceService proto.ServiceInstanceCtrlServerEx
 )
 
-func AssembleResources() (proto.ServiceCtrlServer, proto.ServiceInstanceCtrlServerEx) {
+func AssembleResources() (proto.ServiceCtrlServerEx, proto.ServiceInstanceCtrlServerEx) {
 	instanceService = &InstanceService{}
 	serviceService = NewMicroServiceService(instanceService)
 	return serviceService, instanceService
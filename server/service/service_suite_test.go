@@ -33,7 +33,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-var serviceResource proto.ServiceCtrlServer
+var serviceResource proto.ServiceCtrlServerEx
 var instanceResource proto.ServiceInstanceCtrlServerEx
 
 var _ = BeforeSuite(func() {
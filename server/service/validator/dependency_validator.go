@@ -26,6 +26,7 @@ import (
 var (
 	addDependenciesReqValidator       validate.Validator
 	overwriteDependenciesReqValidator validate.Validator
+	impactReqValidator                validate.Validator
 )
 
 var (
@@ -74,3 +75,10 @@ func CreateDependenciesReqValidator() *validate.Validator {
 		v.AddSub("Dependencies", defaultDependencyValidator())
 	})
 }
+
+func ImpactReqValidator() *validate.Validator {
+	return impactReqValidator.Init(func(v *validate.Validator) {
+		v.AddRules(MicroServiceKeyValidator().GetRules())
+		v.AddRule("VersionRule", ExistenceReqValidator().GetRule("Version"))
+	})
+}
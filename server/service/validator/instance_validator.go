@@ -18,9 +18,12 @@
 package validator
 
 import (
+	"fmt"
 	"math"
 	"regexp"
 
+	"github.com/apache/servicecomb-service-center/pkg/lb"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/pkg/validate"
 	"github.com/go-chassis/cari/discovery"
@@ -34,6 +37,8 @@ var (
 	registerInstanceReqValidator    validate.Validator
 	heartbeatReqValidator           validate.Validator
 	updateInstancePropsReqValidator validate.Validator
+	drainInstanceReqValidator       validate.Validator
+	batchInstancesReqValidator      validate.Validator
 )
 
 var (
@@ -49,6 +54,13 @@ var (
 	regionRegex, _               = regexp.Compile(`^[A-Za-z0-9_.-]+$`)
 )
 
+// maxDrainTimeout is the max seconds to wait before unregistering the draining instance
+const maxDrainTimeout = 3600
+
+// maxBatchInstances limits the batch size, all the instances of a batch
+// must be written in one etcd transaction, which allows 128 operations at most
+const maxBatchInstances = 64
+
 func FindInstanceReqValidator() *validate.Validator {
 	return findInstanceReqValidator.Init(func(v *validate.Validator) {
 		v.AddRule("ConsumerServiceId", GetInstanceReqValidator().GetRule("ConsumerServiceId"))
@@ -96,6 +108,13 @@ func UpdateInstanceReqValidator() *validate.Validator {
 	})
 }
 
+func DrainInstanceReqValidator() *validate.Validator {
+	return drainInstanceReqValidator.Init(func(v *validate.Validator) {
+		v.AddRules(HeartbeatReqValidator().GetRules())
+		v.AddRule("Timeout", &validate.Rule{Max: maxDrainTimeout})
+	})
+}
+
 func UpdateInstancePropsReqValidator() *validate.Validator {
 	return updateInstancePropsReqValidator.Init(func(v *validate.Validator) {
 		v.AddRules(heartbeatReqValidator.GetRules())
@@ -130,3 +149,25 @@ func RegisterInstanceReqValidator() *validate.Validator {
 		v.AddSub("Instance", &microServiceInstanceValidator)
 	})
 }
+
+// BatchInstancesReqValidator only checks the batch size, the instances
+// are validated one by one to return the result of each
+func BatchInstancesReqValidator() *validate.Validator {
+	return batchInstancesReqValidator.Init(func(v *validate.Validator) {
+		v.AddRule("Instances", &validate.Rule{Min: 1, Max: maxBatchInstances})
+	})
+}
+
+// ValidateReservedProperties rejects the instance properties maintained by service center
+func ValidateReservedProperties(properties map[string]string) error {
+	if _, ok := properties[proto.PropDrainDeadline]; ok {
+		return fmt.Errorf("property %s is reserved, use the drain API instead", proto.PropDrainDeadline)
+	}
+	return nil
+}
+
+// ValidateInstanceWeight checks the traffic weight in instance properties
+func ValidateInstanceWeight(properties map[string]string) error {
+	_, err := lb.ParseWeight(properties)
+	return err
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validator_test
+
+import (
+	"testing"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/server/service/validator"
+)
+
+func TestValidateUpdateInstanceProperties(t *testing.T) {
+	in := &pb.UpdateInstancePropsRequest{
+		ServiceId:  "service1",
+		InstanceId: "instance1",
+		Properties: map[string]string{"a": "b"},
+	}
+	assert.NoError(t, validator.Validate(in))
+
+	in.Properties["sc.weight"] = "-1"
+	assert.Error(t, validator.Validate(in))
+
+	in.Properties["sc.weight"] = "10"
+	in.Properties[proto.PropDrainDeadline] = "1600000000"
+	assert.Error(t, validator.Validate(in))
+}
@@ -24,6 +24,7 @@ import (
 	pb "github.com/go-chassis/cari/discovery"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/validate"
 )
 
@@ -69,6 +70,11 @@ func Validate(v interface{}) error {
 		return CreateDependenciesReqValidator().Validate(v)
 	case *pb.AddDependenciesRequest:
 		return AddDependenciesReqValidator().Validate(v)
+	case *proto.ImpactRequest:
+		if len(t.ServiceId) > 0 {
+			return GetServiceReqValidator().Validate(v)
+		}
+		return ImpactReqValidator().Validate(v)
 	case *pb.GetServiceTagsRequest:
 		return GetTagsReqValidator().Validate(v)
 	case *pb.AddServiceTagsRequest:
@@ -92,15 +98,28 @@ func Validate(v interface{}) error {
 	case *pb.UpdateInstanceStatusRequest:
 		return UpdateInstanceReqValidator().Validate(v)
 	case *pb.RegisterInstanceRequest:
-		return RegisterInstanceReqValidator().Validate(v)
+		if err := RegisterInstanceReqValidator().Validate(v); err != nil {
+			return err
+		}
+		return ValidateInstanceWeight(t.Instance.Properties)
 	case *pb.FindInstancesRequest:
 		return FindInstanceReqValidator().Validate(v)
 	case *pb.BatchFindInstancesRequest:
 		return BatchFindInstanceReqValidator().Validate(v)
-	case *pb.HeartbeatRequest, *pb.UnregisterInstanceRequest:
+	case *pb.HeartbeatRequest, *pb.UnregisterInstanceRequest, *proto.GetDrainStatusRequest:
 		return HeartbeatReqValidator().Validate(v)
+	case *proto.DrainInstanceRequest:
+		return DrainInstanceReqValidator().Validate(v)
+	case *proto.BatchRegisterInstancesRequest, *proto.BatchUnregisterInstancesRequest:
+		return BatchInstancesReqValidator().Validate(v)
 	case *pb.UpdateInstancePropsRequest:
-		return UpdateInstancePropsReqValidator().Validate(v)
+		if err := UpdateInstancePropsReqValidator().Validate(v); err != nil {
+			return err
+		}
+		if err := ValidateReservedProperties(t.Properties); err != nil {
+			return err
+		}
+		return ValidateInstanceWeight(t.Properties)
 	case *pb.GetServiceRulesRequest:
 		return GetRulesReqValidator().Validate(v)
 	case *pb.AddServiceRulesRequest:
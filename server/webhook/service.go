@@ -0,0 +1,211 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+var ServiceAPI = &Service{}
+
+// Service manages the webhooks of the project in the context
+type Service struct {
+}
+
+func (*Service) CreateWebhook(ctx context.Context, in *webhookpb.CreateWebhookRequest) (*webhookpb.WebhookResponse, error) {
+	if message := invalidWebhook(in, true); len(message) > 0 {
+		log.Errorf(nil, "webhook create request failed: %s", message)
+		return &webhookpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, message),
+		}, nil
+	}
+	now := time.Now().Format(time.RFC3339)
+	webhook := &webhookpb.Webhook{
+		Id:          util.GenerateUUID(),
+		Description: in.Description,
+		Url:         in.Url,
+		Secret:      in.Secret,
+		Events:      in.Events,
+		AppId:       in.AppId,
+		ServiceName: in.ServiceName,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err := datasource.Instance().PutWebhookSubscription(ctx, util.ParseDomainProject(ctx), webhook)
+	if err != nil {
+		log.Errorf(err, "webhook create failed")
+		return &webhookpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhook cannot be created."),
+		}, err
+	}
+	log.Infof("webhook created: (%s, %v, %s)", webhook.Id, webhook.Events, webhook.Url)
+	return &webhookpb.WebhookResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Webhook created successfully."),
+		Webhook:  withoutSecret(webhook),
+	}, nil
+}
+
+func (*Service) GetWebhook(ctx context.Context, webhookID string) (*webhookpb.WebhookResponse, error) {
+	webhook, resp, err := getWebhook(ctx, webhookID)
+	if resp != nil {
+		return &webhookpb.WebhookResponse{Response: resp}, err
+	}
+	return &webhookpb.WebhookResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Webhook retrieved successfully."),
+		Webhook:  withoutSecret(webhook),
+	}, nil
+}
+
+func (*Service) ListWebhooks(ctx context.Context) (*webhookpb.ListWebhooksResponse, error) {
+	webhooks, err := datasource.Instance().ListWebhookSubscriptions(ctx, util.ParseDomainProject(ctx))
+	if err != nil {
+		log.Errorf(err, "webhooks cannot be searched")
+		return &webhookpb.ListWebhooksResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhooks cannot be searched."),
+		}, err
+	}
+	result := make([]*webhookpb.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, withoutSecret(webhook))
+	}
+	return &webhookpb.ListWebhooksResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Webhooks retrieved successfully."),
+		Webhooks: result,
+	}, nil
+}
+
+// UpdateWebhook replaces the webhook, the secret is kept if it is empty in the request
+func (*Service) UpdateWebhook(ctx context.Context, in *webhookpb.UpdateWebhookRequest) (*webhookpb.WebhookResponse, error) {
+	if in == nil {
+		return &webhookpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	if message := invalidWebhook(&in.CreateWebhookRequest, false); len(message) > 0 {
+		log.Errorf(nil, "webhook[%s] update request failed: %s", in.WebhookId, message)
+		return &webhookpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, message),
+		}, nil
+	}
+	webhook, resp, err := getWebhook(ctx, in.WebhookId)
+	if resp != nil {
+		return &webhookpb.WebhookResponse{Response: resp}, err
+	}
+	webhook.Description = in.Description
+	webhook.Url = in.Url
+	if len(in.Secret) > 0 {
+		webhook.Secret = in.Secret
+	}
+	webhook.Events = in.Events
+	webhook.AppId = in.AppId
+	webhook.ServiceName = in.ServiceName
+	webhook.UpdatedAt = time.Now().Format(time.RFC3339)
+	err = datasource.Instance().PutWebhookSubscription(ctx, util.ParseDomainProject(ctx), webhook)
+	if err != nil {
+		log.Errorf(err, "webhook[%s] update failed", in.WebhookId)
+		return &webhookpb.WebhookResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhook cannot be updated."),
+		}, err
+	}
+	log.Infof("webhook updated: (%s, %v, %s)", webhook.Id, webhook.Events, webhook.Url)
+	return &webhookpb.WebhookResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Webhook updated successfully."),
+		Webhook:  withoutSecret(webhook),
+	}, nil
+}
+
+func (*Service) DeleteWebhook(ctx context.Context, webhookID string) (*pb.Response, error) {
+	_, resp, err := getWebhook(ctx, webhookID)
+	if resp != nil {
+		return resp, err
+	}
+	err = datasource.Instance().DeleteWebhookSubscription(ctx, util.ParseDomainProject(ctx), webhookID)
+	if err != nil {
+		log.Errorf(err, "webhook[%s] delete failed", webhookID)
+		return pb.CreateResponse(pb.ErrInternal, "webhook cannot be deleted."), err
+	}
+	log.Infof("webhook deleted: %s", webhookID)
+	return pb.CreateResponse(pb.ResponseSuccess, "Webhook deleted successfully."), nil
+}
+
+// ListDeliveries returns the delivery logs of the webhook, the latest first
+func (*Service) ListDeliveries(ctx context.Context, webhookID string) (*webhookpb.ListDeliveriesResponse, error) {
+	_, resp, err := getWebhook(ctx, webhookID)
+	if resp != nil {
+		return &webhookpb.ListDeliveriesResponse{Response: resp}, err
+	}
+	deliveries, err := listDeliveries(ctx, util.ParseDomainProject(ctx), webhookID)
+	if err != nil {
+		log.Errorf(err, "webhook[%s] deliveries cannot be searched", webhookID)
+		return &webhookpb.ListDeliveriesResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "webhook deliveries cannot be searched."),
+		}, err
+	}
+	return &webhookpb.ListDeliveriesResponse{
+		Response:   pb.CreateResponse(pb.ResponseSuccess, "Webhook deliveries retrieved successfully."),
+		Deliveries: deliveries,
+	}, nil
+}
+
+// getWebhook returns the webhook of the id, the response is not nil if it can not be found
+func getWebhook(ctx context.Context, webhookID string) (*webhookpb.Webhook, *pb.Response, error) {
+	if len(webhookID) == 0 {
+		return nil, pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."), nil
+	}
+	webhook, err := datasource.Instance().GetWebhookSubscription(ctx, util.ParseDomainProject(ctx), webhookID)
+	if err != nil {
+		log.Errorf(err, "webhook[%s] cannot be searched", webhookID)
+		return nil, pb.CreateResponse(pb.ErrInternal, "webhook cannot be searched."), err
+	}
+	if webhook == nil {
+		return nil, pb.CreateResponse(pb.ErrInvalidParams, "Webhook does not exist."), nil
+	}
+	return webhook, nil, nil
+}
+
+func withoutSecret(webhook *webhookpb.Webhook) *webhookpb.Webhook {
+	copied := *webhook
+	copied.Secret = ""
+	return &copied
+}
+
+func invalidWebhook(in *webhookpb.CreateWebhookRequest, requireSecret bool) string {
+	if in == nil || len(in.Events) == 0 || (requireSecret && len(in.Secret) == 0) {
+		return "Request format invalid."
+	}
+	for _, event := range in.Events {
+		if !util.SliceHave(webhookpb.Events, event) {
+			return fmt.Sprintf("Unknown event %s.", event)
+		}
+	}
+	u, err := url.Parse(in.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return "Webhook url is invalid."
+	}
+	return ""
+}
@@ -0,0 +1,263 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webhook sends the registry lifecycle events to the webhooks subscribed by the projects
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/queue"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	hook "github.com/apache/servicecomb-service-center/pkg/webhook"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+// the headers of the webhook requests
+const (
+	HeaderEvent     = "X-SC-Event"
+	HeaderDelivery  = "X-SC-Delivery"
+	HeaderSignature = "X-SC-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// deliveryQueue delivers the events asynchronously
+var deliveryQueue = hook.NewQueue(func(sender *hook.Sender) queue.Worker {
+	return &deliveryWorker{sender: sender}
+})
+
+// Event is the change of a service, an instance or a schema,
+// Instance and Schema are nil if the service changed
+type Event struct {
+	// ID identifies the change in the cluster, the service centers catching
+	// the same change must generate the same ID, then the event is sent once
+	ID            string
+	Action        pb.EventType
+	DomainProject string
+	Service       *pb.MicroService
+	Instance      *pb.MicroServiceInstance
+	Schema        *pb.Schema
+	CreateAt      time.Time
+}
+
+// Names returns the webhook events of the change
+func (e *Event) Names() []string {
+	var suffix string
+	switch e.Action {
+	case pb.EVT_CREATE:
+		suffix = "created"
+	case pb.EVT_UPDATE:
+		suffix = "updated"
+	case pb.EVT_DELETE:
+		suffix = "deleted"
+	default:
+		return nil
+	}
+	switch {
+	case e.Schema != nil:
+		return []string{"schema." + suffix}
+	case e.Instance != nil:
+		names := []string{"instance." + suffix}
+		if e.Action != pb.EVT_DELETE && e.Instance.Status == pb.MSI_DOWN {
+			names = append(names, webhookpb.EventInstanceDown)
+		}
+		return names
+	default:
+		return []string{"service." + suffix}
+	}
+}
+
+// Match returns true if the webhook subscribes the event of the change
+func (e *Event) Match(webhook *webhookpb.Webhook, name string) bool {
+	if !util.SliceHave(webhook.Events, name) {
+		return false
+	}
+	if len(webhook.AppId) == 0 && len(webhook.ServiceName) == 0 {
+		return true
+	}
+	if e.Service == nil {
+		return false
+	}
+	if len(webhook.AppId) > 0 && webhook.AppId != e.Service.AppId {
+		return false
+	}
+	return len(webhook.ServiceName) == 0 || webhook.ServiceName == e.Service.ServiceName
+}
+
+// DeliveryID returns the id of the delivery of the event, it is the same in the cluster
+func (e *Event) DeliveryID(name string) string {
+	sum := sha256.Sum256([]byte(e.ID + "/" + name))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (e *Event) serviceID() string {
+	if e.Service == nil {
+		return ""
+	}
+	return e.Service.ServiceId
+}
+
+// Payload returns the body posted to the webhooks
+func (e *Event) Payload(name, deliveryID string) *webhookpb.Payload {
+	domain, project := util.FromDomainProject(e.DomainProject)
+	return &webhookpb.Payload{
+		Id:        deliveryID,
+		Event:     name,
+		Domain:    domain,
+		Project:   project,
+		Service:   e.Service,
+		Instance:  e.Instance,
+		Schema:    e.Schema,
+		Timestamp: e.CreateAt.Format(time.RFC3339),
+	}
+}
+
+// Sign returns the signature of the body in header X-SC-Signature
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Fire sends the event to the subscribed webhooks without blocking the caller
+func Fire(evt *Event) {
+	if len(evt.Names()) == 0 {
+		return
+	}
+	if evt.CreateAt.IsZero() {
+		evt.CreateAt = time.Now()
+	}
+	deliveryQueue.Do(context.Background(), queue.Task{Payload: evt, Async: true})
+}
+
+type deliveryWorker struct {
+	sender *hook.Sender
+}
+
+func (w *deliveryWorker) Handle(ctx context.Context, obj interface{}) {
+	evt := obj.(*Event)
+	webhooks, err := datasource.Instance().ListWebhookSubscriptions(ctx, evt.DomainProject)
+	if err != nil {
+		log.Errorf(err, "webhooks of project[%s] cannot be searched", evt.DomainProject)
+		return
+	}
+	for _, name := range evt.Names() {
+		for _, webhook := range webhooks {
+			if evt.Match(webhook, name) {
+				w.deliver(ctx, webhook, evt, name)
+			}
+		}
+	}
+}
+
+// deliver claims the delivery of the event and sends it,
+// it returns if another service center has claimed it
+func (w *deliveryWorker) deliver(ctx context.Context, webhook *webhookpb.Webhook, evt *Event, name string) {
+	delivery := &webhookpb.Delivery{
+		Id:        evt.DeliveryID(name),
+		WebhookId: webhook.Id,
+		Event:     name,
+		ServiceId: evt.serviceID(),
+		Status:    webhookpb.DeliveryPending,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+	ok, err := datasource.Instance().CreateWebhookDelivery(ctx, evt.DomainProject, delivery)
+	if err != nil {
+		log.Errorf(err, "webhook[%s] delivery[%s] cannot be created", webhook.Id, delivery.Id)
+		return
+	}
+	if !ok {
+		log.Debugf("webhook[%s] delivery[%s] is claimed by another service center", webhook.Id, delivery.Id)
+		return
+	}
+	body, err := json.Marshal(evt.Payload(name, delivery.Id))
+	if err != nil {
+		log.Errorf(err, "webhook[%s] payload of event[%s] cannot be encoded", webhook.Id, name)
+		return
+	}
+	w.send(ctx, webhook, delivery, body)
+	if err := saveDelivery(ctx, evt.DomainProject, delivery); err != nil {
+		log.Errorf(err, "webhook[%s] delivery[%s] cannot be saved", webhook.Id, delivery.Id)
+	}
+}
+
+// send posts the signed payload and records the result in the delivery
+func (w *deliveryWorker) send(ctx context.Context, webhook *webhookpb.Webhook, delivery *webhookpb.Delivery, body []byte) {
+	headers := make(http.Header)
+	headers.Set(rest.HeaderContentType, rest.ContentTypeJSON)
+	headers.Set(HeaderEvent, delivery.Event)
+	headers.Set(HeaderDelivery, delivery.Id)
+	headers.Set(HeaderSignature, Sign(webhook.Secret, body))
+
+	result := w.sender.Send(ctx, &hook.Request{
+		Method:  http.MethodPost,
+		URL:     webhook.Url,
+		Headers: headers,
+		Body:    body,
+	})
+	delivery.Status = webhookpb.DeliveryFailed
+	if result.Success {
+		delivery.Status = webhookpb.DeliverySucceeded
+	}
+	delivery.Attempts = result.Attempts
+	delivery.StatusCode = result.StatusCode
+	delivery.Response = result.Response
+	delivery.Error = result.Error
+	delivery.DeliveredAt = result.DoneAt.Format(time.RFC3339)
+	log.Infof("webhook[%s] delivery[%s] of event[%s] %s", webhook.Id, delivery.Id, delivery.Event, delivery.Status)
+}
+
+type deliveries []*webhookpb.Delivery
+
+func (d deliveries) Len() int          { return len(d) }
+func (d deliveries) Swap(i, j int)     { d[i], d[j] = d[j], d[i] }
+func (d deliveries) ID(i int) string   { return d[i].Id }
+func (d deliveries) Time(i int) string { return d[i].CreatedAt }
+
+// saveDelivery saves the delivery and removes the oldest ones beyond the limit
+func saveDelivery(ctx context.Context, domainProject string, delivery *webhookpb.Delivery) error {
+	return hook.Save(ctx, func(ctx context.Context) error {
+		return datasource.Instance().PutWebhookDelivery(ctx, domainProject, delivery)
+	}, func(ctx context.Context) (hook.Records, error) {
+		list, err := datasource.Instance().ListWebhookDeliveries(ctx, domainProject, delivery.WebhookId)
+		return deliveries(list), err
+	}, func(ctx context.Context, id string) error {
+		return datasource.Instance().DeleteWebhookDelivery(ctx, domainProject, delivery.WebhookId, id)
+	})
+}
+
+// listDeliveries returns the deliveries of the webhook, the latest first
+func listDeliveries(ctx context.Context, domainProject, webhookID string) ([]*webhookpb.Delivery, error) {
+	list, err := datasource.Instance().ListWebhookDeliveries(ctx, domainProject, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	hook.SortRecords(deliveries(list))
+	return list, nil
+}
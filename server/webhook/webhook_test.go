@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	hook "github.com/apache/servicecomb-service-center/pkg/webhook"
+	"github.com/apache/servicecomb-service-center/server/webhook/webhookpb"
+)
+
+func TestEvent_Names(t *testing.T) {
+	service := &pb.MicroService{ServiceId: "s1", AppId: "app", ServiceName: "svc"}
+	evt := &Event{Action: pb.EVT_CREATE, Service: service}
+	assert.Equal(t, []string{webhookpb.EventServiceCreated}, evt.Names())
+
+	evt = &Event{Action: pb.EVT_INIT, Service: service}
+	assert.Empty(t, evt.Names())
+
+	evt = &Event{Action: pb.EVT_UPDATE, Service: service, Instance: &pb.MicroServiceInstance{Status: pb.MSI_UP}}
+	assert.Equal(t, []string{webhookpb.EventInstanceUpdated}, evt.Names())
+
+	evt.Instance.Status = pb.MSI_DOWN
+	assert.Equal(t, []string{webhookpb.EventInstanceUpdated, webhookpb.EventInstanceDown}, evt.Names())
+
+	evt.Action = pb.EVT_DELETE
+	assert.Equal(t, []string{webhookpb.EventInstanceDeleted}, evt.Names())
+
+	evt = &Event{Action: pb.EVT_UPDATE, Service: service, Schema: &pb.Schema{SchemaId: "schema1"}}
+	assert.Equal(t, []string{webhookpb.EventSchemaUpdated}, evt.Names())
+
+	for _, name := range []string{webhookpb.EventServiceCreated, webhookpb.EventInstanceDown, webhookpb.EventSchemaDeleted} {
+		assert.Contains(t, webhookpb.Events, name)
+	}
+}
+
+func TestEvent_Match(t *testing.T) {
+	evt := &Event{Action: pb.EVT_CREATE, Service: &pb.MicroService{ServiceId: "s1", AppId: "app", ServiceName: "svc"}}
+	webhook := &webhookpb.Webhook{Events: []string{webhookpb.EventServiceCreated}}
+	assert.True(t, evt.Match(webhook, webhookpb.EventServiceCreated))
+	assert.False(t, evt.Match(webhook, webhookpb.EventServiceDeleted))
+
+	webhook.AppId = "app"
+	assert.True(t, evt.Match(webhook, webhookpb.EventServiceCreated))
+	webhook.ServiceName = "svc"
+	assert.True(t, evt.Match(webhook, webhookpb.EventServiceCreated))
+	webhook.ServiceName = "other"
+	assert.False(t, evt.Match(webhook, webhookpb.EventServiceCreated))
+	webhook.AppId, webhook.ServiceName = "other", ""
+	assert.False(t, evt.Match(webhook, webhookpb.EventServiceCreated))
+
+	evt.Service = nil
+	assert.False(t, evt.Match(webhook, webhookpb.EventServiceCreated))
+}
+
+func TestEvent_DeliveryID(t *testing.T) {
+	evt := &Event{ID: "/cse-sr/ms/files/default/default/s1/10/CREATE"}
+	id := evt.DeliveryID(webhookpb.EventInstanceCreated)
+	assert.Len(t, id, 32)
+	assert.Equal(t, id, (&Event{ID: evt.ID}).DeliveryID(webhookpb.EventInstanceCreated))
+	assert.NotEqual(t, id, evt.DeliveryID(webhookpb.EventInstanceDown))
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestDeliveryWorker_Send(t *testing.T) {
+	sender, err := hook.NewSender()
+	assert.NoError(t, err)
+	sender.Backoff = &backoff.PowerBackoff{InitDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	w := &deliveryWorker{sender: sender}
+	evt := &Event{
+		ID:            "doc1/1/CREATE",
+		Action:        pb.EVT_CREATE,
+		DomainProject: "default/default",
+		Service:       &pb.MicroService{ServiceId: "s1"},
+		CreateAt:      time.Now(),
+	}
+	delivery := &webhookpb.Delivery{
+		Id:        evt.DeliveryID(webhookpb.EventServiceCreated),
+		WebhookId: "w1",
+		Event:     webhookpb.EventServiceCreated,
+		Status:    webhookpb.DeliveryPending,
+	}
+	body, err := json.Marshal(evt.Payload(delivery.Event, delivery.Id))
+	assert.NoError(t, err)
+
+	t.Run("retry until succeeded, should pass", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requests++
+			content, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, body, content)
+			assert.Equal(t, Sign("secret", content), r.Header.Get(HeaderSignature))
+			assert.Equal(t, webhookpb.EventServiceCreated, r.Header.Get(HeaderEvent))
+			assert.Equal(t, delivery.Id, r.Header.Get(HeaderDelivery))
+			if requests == 1 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rw.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		d := *delivery
+		w.send(context.Background(), &webhookpb.Webhook{Id: "w1", Url: server.URL, Secret: "secret"}, &d, body)
+		assert.Equal(t, webhookpb.DeliverySucceeded, d.Status)
+		assert.Equal(t, 2, d.Attempts)
+		assert.Equal(t, http.StatusOK, d.StatusCode)
+		assert.Equal(t, "ok", d.Response)
+		assert.Empty(t, d.Error)
+		assert.NotEmpty(t, d.DeliveredAt)
+	})
+
+	t.Run("give up after max attempts, should pass", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		d := *delivery
+		w.send(context.Background(), &webhookpb.Webhook{Id: "w1", Url: server.URL, Secret: "secret"}, &d, body)
+		assert.Equal(t, webhookpb.DeliveryFailed, d.Status)
+		assert.Equal(t, hook.MaxAttempts, d.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, d.StatusCode)
+		assert.NotEmpty(t, d.Error)
+	})
+}
+
+func TestInvalidWebhook(t *testing.T) {
+	in := &webhookpb.CreateWebhookRequest{
+		Url:    "https://example.com/hook",
+		Secret: "secret",
+		Events: []string{webhookpb.EventInstanceDown},
+	}
+	assert.Empty(t, invalidWebhook(in, true))
+
+	in.Secret = ""
+	assert.NotEmpty(t, invalidWebhook(in, true))
+	assert.Empty(t, invalidWebhook(in, false))
+
+	in.Events = []string{"unknown"}
+	assert.Equal(t, "Unknown event unknown.", invalidWebhook(in, false))
+
+	in.Events = []string{webhookpb.EventInstanceDown}
+	in.Url = "ftp://example.com"
+	assert.Equal(t, "Webhook url is invalid.", invalidWebhook(in, false))
+}
@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhookpb
+
+import (
+	"github.com/go-chassis/cari/discovery"
+)
+
+// the registry lifecycle events which trigger the webhooks
+const (
+	EventServiceCreated  = "service.created"
+	EventServiceUpdated  = "service.updated"
+	EventServiceDeleted  = "service.deleted"
+	EventInstanceCreated = "instance.created"
+	EventInstanceUpdated = "instance.updated"
+	EventInstanceDeleted = "instance.deleted"
+	// EventInstanceDown is triggered when an instance is registered or updated with status DOWN
+	EventInstanceDown  = "instance.down"
+	EventSchemaCreated = "schema.created"
+	EventSchemaUpdated = "schema.updated"
+	EventSchemaDeleted = "schema.deleted"
+)
+
+// Events are all the events which can be subscribed
+var Events = []string{
+	EventServiceCreated, EventServiceUpdated, EventServiceDeleted,
+	EventInstanceCreated, EventInstanceUpdated, EventInstanceDeleted, EventInstanceDown,
+	EventSchemaCreated, EventSchemaUpdated, EventSchemaDeleted,
+}
+
+// the status of the deliveries
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook subscribes the events of a project, the payloads are posted to Url,
+// empty AppId or ServiceName matches any service
+type Webhook struct {
+	Id          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Url         string `json:"url"`
+	// Secret signs the payloads, it is never returned
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+	AppId       string   `json:"appId,omitempty"`
+	ServiceName string   `json:"serviceName,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	UpdatedAt   string   `json:"updatedAt,omitempty"`
+}
+
+// Delivery is the delivery log of an event sent to the webhook
+type Delivery struct {
+	Id          string `json:"id"`
+	WebhookId   string `json:"webhookId"`
+	Event       string `json:"event"`
+	ServiceId   string `json:"serviceId,omitempty"`
+	Status      string `json:"status"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	Response    string `json:"response,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Attempts    int    `json:"attempts"`
+	CreatedAt   string `json:"createdAt"`
+	DeliveredAt string `json:"deliveredAt,omitempty"`
+}
+
+// Payload is the body posted to the webhook
+type Payload struct {
+	Id        string                          `json:"id"`
+	Event     string                          `json:"event"`
+	Domain    string                          `json:"domain"`
+	Project   string                          `json:"project"`
+	Service   *discovery.MicroService         `json:"service,omitempty"`
+	Instance  *discovery.MicroServiceInstance `json:"instance,omitempty"`
+	Schema    *discovery.Schema               `json:"schema,omitempty"`
+	Timestamp string                          `json:"timestamp"`
+}
+
+type CreateWebhookRequest struct {
+	Description string   `json:"description,omitempty"`
+	Url         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+	AppId       string   `json:"appId,omitempty"`
+	ServiceName string   `json:"serviceName,omitempty"`
+}
+
+type UpdateWebhookRequest struct {
+	WebhookId string `json:"-"`
+	CreateWebhookRequest
+}
+
+type WebhookResponse struct {
+	Response *discovery.Response `json:"-"`
+	Webhook  *Webhook            `json:"webhook,omitempty"`
+}
+
+type ListWebhooksResponse struct {
+	Response *discovery.Response `json:"-"`
+	Webhooks []*Webhook          `json:"webhooks"`
+}
+
+type ListDeliveriesResponse struct {
+	Response   *discovery.Response `json:"-"`
+	Deliveries []*Delivery         `json:"deliveries"`
+}
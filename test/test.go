@@ -41,6 +41,9 @@ func init() {
 	} else {
 		archaius.Set("registry.heartbeat.kind", "checker")
 	}
-	datasource.Init(datasource.Options{Kind: datasource.Kind(t.(string))})
+	datasource.Init(datasource.Options{
+		Kind:              datasource.Kind(t.(string)),
+		SchemaHistorySize: 10,
+	})
 	core.ServiceAPI, core.InstanceAPI = service.AssembleResources()
 }